@@ -1,5 +1,7 @@
 package api
 
+import "github.com/peteshima/cardgame-api/models"
+
 // CreateCustomDeckRequest represents the request body for creating custom decks
 type CreateCustomDeckRequest struct {
 	Name string `json:"name" binding:"required"`
@@ -29,7 +31,98 @@ type CribbageDiscardRequest struct {
 	CardIndices []int `json:"card_indices" binding:"required"`
 }
 
+// CribbageClaimRequest represents the score a player claims for their hand or crib in a muggins game
+type CribbageClaimRequest struct {
+	Points *int `json:"points" binding:"required"`
+}
+
+// CribbageMugginsRequest represents the claim an opponent calls muggins on, by its index in the hand's claims
+type CribbageMugginsRequest struct {
+	Claim *int `json:"claim" binding:"required"`
+}
+
 // CribbagePlayRequest represents the request body for cribbage play phase
+// CardIndex is a pointer so that playing the first card, index 0, still satisfies required
 type CribbagePlayRequest struct {
-	CardIndex int `json:"card_index" binding:"required"`
-}
\ No newline at end of file
+	CardIndex *int `json:"card_index" binding:"required"`
+}
+// PokerStartRequest represents the optional stakes for starting a Texas Hold'em game
+type PokerStartRequest struct {
+	SmallBlind    int `json:"small_blind"`
+	BigBlind      int `json:"big_blind"`
+	StartingStack int `json:"starting_stack"`
+}
+
+// PokerActionRequest represents the request body for poker bets and raises
+type PokerActionRequest struct {
+	Amount int `json:"amount"`
+}
+
+// PokerEvaluateRequest represents the request body for evaluating and comparing poker hands.
+// Board cards, if any, are shared by every hand.
+type PokerEvaluateRequest struct {
+	Board []models.Card   `json:"board,omitempty"`
+	Hands [][]models.Card `json:"hands" binding:"required"`
+}
+
+// WarStartRequest represents the optional round limit for starting a game of War
+type WarStartRequest struct {
+	MaxRounds int `json:"max_rounds"`
+}
+
+// GoFishAskRequest represents the request body for asking another player for a rank
+type GoFishAskRequest struct {
+	TargetID string `json:"target_id" binding:"required"`
+	Rank     int    `json:"rank" binding:"required"`
+}
+
+// GameActionRequest represents an action sent by a client over a game's WebSocket
+type GameActionRequest struct {
+	Action      string `json:"action"`
+	PlayerID    string `json:"player_id"`
+	PileID      string `json:"pile_id,omitempty"`
+	CardIndex   int    `json:"card_index,omitempty"`
+	CardIndices []int  `json:"card_indices,omitempty"`
+}
+
+// CreateBlackjackTableRequest represents the optional table rules for a new blackjack or Glitchjack game.
+// Give a preset name or a full set of rules; with neither the default rules are used.
+// A spanish21 deck type deals blackjack tables under Spanish 21 rules, and provably fair tables
+// commit to a server seed before the deal.
+type CreateBlackjackTableRequest struct {
+	Preset       string                 `json:"preset,omitempty"`
+	Rules        *models.BlackjackRules `json:"rules,omitempty"`
+	MaxPlayers   int                    `json:"max_players,omitempty"`
+	DeckType     string                 `json:"deck_type,omitempty"`
+	ProvablyFair bool                   `json:"provably_fair,omitempty"`
+}
+
+// ClientSeedRequest represents a client seed mixed into a provably fair table's shuffle.
+type ClientSeedRequest struct {
+	ClientSeed string `json:"client_seed" binding:"required"`
+}
+
+// VerifyShuffleRequest represents the seeds and table a provably fair deck is rebuilt from.
+// Game type is blackjack or glitchjack, deck type standard or spanish21, and decks defaults to 1.
+type VerifyShuffleRequest struct {
+	ServerSeed string `json:"server_seed" binding:"required"`
+	ClientSeed string `json:"client_seed"`
+	Commitment string `json:"commitment,omitempty"`
+	GameType   string `json:"game_type,omitempty"`
+	DeckType   string `json:"deck_type,omitempty"`
+	Decks      int    `json:"decks,omitempty"`
+}
+
+// CreateCribbageTableRequest represents the optional options for a new cribbage game.
+// Players default to 2 and the match to a single game; muggins makes players claim their own counts.
+type CreateCribbageTableRequest struct {
+	MaxPlayers    int  `json:"max_players,omitempty"`
+	BestOf        int  `json:"best_of,omitempty"`
+	Muggins       bool `json:"muggins,omitempty"`
+	MugginsWindow int  `json:"muggins_window,omitempty"`
+}
+
+// ChipsRequest represents a number of chips to buy in for or to bet on the next deal.
+type ChipsRequest struct {
+	Amount int `json:"amount"`
+}
@@ -283,7 +283,6 @@ func ToCardWithImagesPtr(c *Card, baseURL string) CardWithImages {
 // generateDeckName creates a random, family-friendly name for new decks.
 // It combines a random adjective with a random noun to ensure memorable, unique names.
 func generateDeckName() string {
-	rand.Seed(time.Now().UnixNano())
 	adjective := safeAdjectives[rand.Intn(len(safeAdjectives))]
 	noun := safeNouns[rand.Intn(len(safeNouns))]
 	return adjective + " " + noun
@@ -373,7 +372,6 @@ func (d *Deck) ResetWithDecksAndType(numDecks int, deckType DeckType) {
 // Shuffle randomizes the order of all cards in the deck using Fisher-Yates algorithm.
 // This ensures fair card distribution and prevents predictable card sequences.
 func (d *Deck) Shuffle() {
-	rand.Seed(time.Now().UnixNano())
 	for i := len(d.Cards) - 1; i > 0; i-- {
 		j := rand.Intn(i + 1)
 		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
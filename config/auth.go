@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peteshima/cardgame-api/middleware"
+)
+
+// LoadAPIKeys builds the API key ring from the file named by API_KEYS_FILE and the comma-separated API_KEYS.
+// Each entry is "id:role:sha256-of-key", so only hashes are ever stored; the file may also contain blank
+// lines and # comments. With no entries at all authentication is disabled.
+func LoadAPIKeys() (*middleware.KeyRing, error) {
+	entries := []string{}
+
+	if path := strings.TrimSpace(os.Getenv("API_KEYS_FILE")); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading API_KEYS_FILE: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			entries = append(entries, line)
+		}
+	}
+
+	if envKeys := strings.TrimSpace(os.Getenv("API_KEYS")); envKeys != "" {
+		for _, entry := range strings.Split(envKeys, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	keys := make([]middleware.APIKey, 0, len(entries))
+	for _, entry := range entries {
+		key, err := middleware.ParseAPIKey(entry)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return middleware.NewKeyRing(keys)
+}
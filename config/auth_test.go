@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peteshima/cardgame-api/middleware"
+)
+
+func TestLoadAPIKeys(t *testing.T) {
+	t.Setenv("API_KEYS_FILE", "")
+	t.Setenv("API_KEYS", "")
+	keys, err := LoadAPIKeys()
+	require.NoError(t, err)
+	assert.False(t, keys.Enabled())
+
+	path := filepath.Join(t.TempDir(), "api_keys.txt")
+	contents := "# id:role:sha256\n\nops:admin:" + middleware.HashAPIKey("admin-secret") + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	t.Setenv("API_KEYS_FILE", path)
+	t.Setenv("API_KEYS", "web:player:"+middleware.HashAPIKey("player-secret"))
+
+	keys, err = LoadAPIKeys()
+	require.NoError(t, err)
+	assert.Equal(t, 2, keys.Len())
+
+	key, found := keys.Lookup("admin-secret")
+	assert.True(t, found)
+	assert.Equal(t, "ops", key.ID)
+	assert.Equal(t, middleware.RoleAdmin, key.Role)
+
+	key, found = keys.Lookup("player-secret")
+	assert.True(t, found)
+	assert.Equal(t, middleware.RolePlayer, key.Role)
+
+	_, found = keys.Lookup(middleware.HashAPIKey("admin-secret"))
+	assert.False(t, found, "the stored hash must not work as a key")
+
+	t.Setenv("API_KEYS", "web:player:plaintext")
+	_, err = LoadAPIKeys()
+	assert.Error(t, err)
+
+	t.Setenv("API_KEYS", "")
+	t.Setenv("API_KEYS_FILE", filepath.Join(t.TempDir(), "missing.txt"))
+	_, err = LoadAPIKeys()
+	assert.Error(t, err)
+}
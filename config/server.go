@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -70,4 +71,63 @@ func GetPort() string {
 		port = "8080"
 	}
 	return port
-}
\ No newline at end of file
+}
+
+// GetStoreBackend returns the persistence backend selected by STORE_BACKEND.
+// Supported values are "memory" (default) and "bolt" for an embedded on-disk database.
+func GetStoreBackend() string {
+	backend := strings.ToLower(strings.TrimSpace(os.Getenv("STORE_BACKEND")))
+	if backend == "" {
+		backend = "memory"
+	}
+	return backend
+}
+
+// GetStorePath returns the database file used by on-disk store backends, defaulting to cardgame.db.
+func GetStorePath() string {
+	path := os.Getenv("STORE_PATH")
+	if path == "" {
+		path = "cardgame.db"
+	}
+	return path
+}
+
+// GetAllowedOrigins returns the browser origins allowed by CORS and WebSocket upgrades.
+// ALLOWED_ORIGINS takes a comma-separated list; the defaults cover the local and hosted front-ends.
+func GetAllowedOrigins() []string {
+	if envOrigins := os.Getenv("ALLOWED_ORIGINS"); envOrigins != "" {
+		origins := strings.Split(envOrigins, ",")
+		for i, origin := range origins {
+			origins[i] = strings.TrimSpace(origin)
+		}
+		return origins
+	}
+	return []string{"http://localhost:3001", "http://glitchjack.com"}
+}
+
+// GetGameMaxAge returns how long a game may sit idle before it expires, from GAME_MAX_AGE.
+// Defaults to 24 hours when unset or not a valid positive duration.
+func GetGameMaxAge() time.Duration {
+	return getDuration("GAME_MAX_AGE", 24*time.Hour)
+}
+
+// GetGameCleanupInterval returns how often expired games are removed, from GAME_CLEANUP_INTERVAL.
+// Defaults to 10 minutes when unset or not a valid positive duration.
+func GetGameCleanupInterval() time.Duration {
+	return getDuration("GAME_CLEANUP_INTERVAL", 10*time.Minute)
+}
+
+// GetAdminToken returns the shared secret that unlocks the unredacted observer view, from ADMIN_TOKEN.
+// An empty value disables the admin view entirely.
+func GetAdminToken() string {
+	return strings.TrimSpace(os.Getenv("ADMIN_TOKEN"))
+}
+
+// getDuration parses a positive Go duration string from the environment, falling back to a default.
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
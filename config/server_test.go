@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -90,4 +91,37 @@ func TestGetBaseURL(t *testing.T) {
 
 	baseURL = GetBaseURL(c)
 	assert.Equal(t, "http://localhost:8080", baseURL)
-}
\ No newline at end of file
+}
+
+func TestGetAllowedOrigins(t *testing.T) {
+	os.Unsetenv("ALLOWED_ORIGINS")
+	assert.Equal(t, []string{"http://localhost:3001", "http://glitchjack.com"}, GetAllowedOrigins())
+
+	os.Setenv("ALLOWED_ORIGINS", "https://a.example, https://b.example")
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, GetAllowedOrigins())
+
+	// Clean up
+	os.Unsetenv("ALLOWED_ORIGINS")
+}
+
+func TestGetGameExpiry(t *testing.T) {
+	os.Unsetenv("GAME_MAX_AGE")
+	os.Unsetenv("GAME_CLEANUP_INTERVAL")
+	assert.Equal(t, 24*time.Hour, GetGameMaxAge())
+	assert.Equal(t, 10*time.Minute, GetGameCleanupInterval())
+
+	os.Setenv("GAME_MAX_AGE", "2h")
+	os.Setenv("GAME_CLEANUP_INTERVAL", "30s")
+	assert.Equal(t, 2*time.Hour, GetGameMaxAge())
+	assert.Equal(t, 30*time.Second, GetGameCleanupInterval())
+
+	// Invalid and non-positive values fall back to the defaults
+	os.Setenv("GAME_MAX_AGE", "soon")
+	os.Setenv("GAME_CLEANUP_INTERVAL", "-1m")
+	assert.Equal(t, 24*time.Hour, GetGameMaxAge())
+	assert.Equal(t, 10*time.Minute, GetGameCleanupInterval())
+
+	// Clean up
+	os.Unsetenv("GAME_MAX_AGE")
+	os.Unsetenv("GAME_CLEANUP_INTERVAL")
+}
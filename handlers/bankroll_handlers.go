@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/peteshima/cardgame-api/api"
+	"github.com/peteshima/cardgame-api/models"
+	"github.com/peteshima/cardgame-api/validators"
+)
+
+// PlayerBuyIn adds chips to a player's bankroll at a blackjack or Glitchjack table.
+func (h *HandlerDependencies) PlayerBuyIn(c *gin.Context) {
+	h.chipsAction(c, h.BankrollService.BuyIn, " buys in")
+}
+
+// PlaceBet stakes chips on the next deal; a bet of 0 withdraws the player's bet.
+func (h *HandlerDependencies) PlaceBet(c *gin.Context) {
+	h.chipsAction(c, h.BankrollService.PlaceBet, " bets")
+}
+
+// GetBankrolls reports every player's bankroll, current bet and chip ledger along with the table limits.
+func (h *HandlerDependencies) GetBankrolls(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game := h.BankrollService.GetBankrolls(gameID)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	players := make([]gin.H, 0, len(game.Players))
+	for _, player := range game.Players {
+		ledger := player.Ledger
+		if ledger == nil {
+			ledger = []models.LedgerEntry{}
+		}
+		players = append(players, gin.H{
+			"player_id":     player.ID,
+			"name":          player.Name,
+			"bankroll":      player.Bankroll,
+			"bet":           player.Bet,
+			"insurance_bet": player.InsuranceBet,
+			"ledger":        ledger,
+		})
+	}
+
+	rules := game.Rules()
+	c.JSON(http.StatusOK, gin.H{
+		"game_id": game.ID,
+		"status":  game.Status.String(),
+		"min_bet": rules.MinBet,
+		"max_bet": rules.MaxBet,
+		"players": players,
+	})
+}
+
+// chipsAction runs a buy-in or bet for the player in the path and writes their new balance.
+func (h *HandlerDependencies) chipsAction(c *gin.Context, action func(string, string, int) (*models.Game, *models.Player, error), verb string) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	playerID := validators.SanitizeString(c.Param("playerId"), 50)
+
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	if !validators.ValidatePlayerID(playerID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid player ID format",
+		})
+		return
+	}
+
+	var request api.ChipsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	game, player, err := action(gameID, playerID, request.Amount)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":   game.ID,
+		"player_id": player.ID,
+		"bankroll":  player.Bankroll,
+		"bet":       player.Bet,
+		"message":   player.Name + verb,
+	})
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBankrollRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/game/:gameId/buy-in/:playerId", deps.PlayerBuyIn)
+	router.POST("/game/:gameId/bet/:playerId", deps.PlaceBet)
+	router.GET("/game/:gameId/bankrolls", deps.GetBankrolls)
+
+	request := func(method, path, body string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	game := deps.GameService.CreateGame(1)
+	_, alice, _ := deps.GameService.AddPlayerToGame(game.ID, "Alice")
+	base := "/game/" + game.ID
+
+	code, response := request("POST", base+"/buy-in/"+alice.ID, `{"amount": 500}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(500), response["bankroll"])
+	assert.Equal(t, "Alice buys in", response["message"])
+
+	code, response = request("POST", base+"/bet/"+alice.ID, `{"amount": 1000}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "bet must be between 10 and 500", response["error"])
+
+	code, response = request("POST", base+"/bet/"+alice.ID, `{"amount": 50}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(450), response["bankroll"])
+	assert.Equal(t, float64(50), response["bet"])
+
+	code, response = request("POST", base+"/bet/"+alice.ID, `not json`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("POST", "/game/550e8400-e29b-41d4-a716-446655440000/bet/"+alice.ID, `{"amount": 50}`)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, response = request("GET", base+"/bankrolls", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(10), response["min_bet"])
+	players := response["players"].([]interface{})
+	require.Len(t, players, 1)
+	entry := players[0].(map[string]interface{})
+	assert.Equal(t, float64(450), entry["bankroll"])
+	assert.Len(t, entry["ledger"], 2)
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/peteshima/cardgame-api/api"
+	"github.com/peteshima/cardgame-api/models"
 	"github.com/peteshima/cardgame-api/validators"
 )
 
@@ -40,6 +43,44 @@ func (h *HandlerDependencies) StartBlackjackGame(c *gin.Context) {
 	})
 }
 
+// NextRound collects the finished round's cards and reopens betting, dealing on from the same shoe
+// until the cut card comes out.
+func (h *HandlerDependencies) NextRound(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.BlackjackService.NextRound(gameID)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":          game.ID,
+		"status":           game.Status.String(),
+		"round":            game.Round,
+		"remaining_cards":  game.Deck.RemainingCards(),
+		"shoe_penetration": game.ShoePenetration(),
+		"reshuffled":       game.Events[len(game.Events)-1].Deck != nil,
+		"shoe":             game.ShoeStatus(),
+		"message":          fmt.Sprintf("Round %d open for bets", game.Round),
+	})
+}
+
 func (h *HandlerDependencies) PlayerHit(c *gin.Context) {
 	gameID := validators.SanitizeString(c.Param("gameId"), 50)
 	playerID := validators.SanitizeString(c.Param("playerId"), 50)
@@ -150,18 +191,26 @@ func (h *HandlerDependencies) GetGameResults(c *gin.Context) {
 
 	dealerValue, dealerBlackjack := game.Dealer.BlackjackHandValue()
 
-	// Add player details to results
+	// Add player details to results, with each split hand's outcome once the game is finished
+	handResults := game.GetHandResults()
 	playerResults := make([]gin.H, 0)
 	for _, player := range game.Players {
 		playerValue, playerBlackjack := player.BlackjackHandValue()
-		playerResults = append(playerResults, gin.H{
+		details := gin.H{
 			"player_id":     player.ID,
 			"player_name":   player.Name,
 			"hand_value":    playerValue,
 			"has_blackjack": playerBlackjack,
 			"is_busted":     player.IsBusted(),
 			"result":        results[player.ID],
-		})
+		}
+		if result, ok := handResults[player.ID]; ok {
+			details["hands"] = result.Hands
+			details["insurance"] = result.Insurance
+			details["insurance_payout"] = result.InsurancePayout
+			details["bankroll"] = player.Bankroll
+		}
+		playerResults = append(playerResults, details)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -175,4 +224,192 @@ func (h *HandlerDependencies) GetGameResults(c *gin.Context) {
 		"players": playerResults,
 		"results": results,
 	})
-}
\ No newline at end of file
+}
+
+// PlayerSplit splits the player's pair into two hands, which are then played in turn.
+func (h *HandlerDependencies) PlayerSplit(c *gin.Context) {
+	h.blackjackHandAction(c, h.BlackjackService.PlayerSplit, " splits")
+}
+
+// PlayerDouble doubles down: the hand takes one more card and stands.
+func (h *HandlerDependencies) PlayerDouble(c *gin.Context) {
+	h.blackjackHandAction(c, h.BlackjackService.PlayerDouble, " doubles down")
+}
+
+// PlayerInsure takes insurance against a dealer blackjack, or even money on a player blackjack.
+func (h *HandlerDependencies) PlayerInsure(c *gin.Context) {
+	h.blackjackHandAction(c, h.BlackjackService.PlayerInsure, " takes insurance")
+}
+
+// PlayerSurrender gives up the player's hand as their first decision.
+func (h *HandlerDependencies) PlayerSurrender(c *gin.Context) {
+	h.blackjackHandAction(c, h.BlackjackService.PlayerSurrender, " surrenders")
+}
+
+// blackjackHandAction validates the game and player IDs, applies the action and responds with
+// the player's hands and which of them is in play.
+func (h *HandlerDependencies) blackjackHandAction(c *gin.Context, action func(string, string) (*models.Game, *models.Player, error), verb string) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	playerID := validators.SanitizeString(c.Param("playerId"), 50)
+
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	if !validators.ValidatePlayerID(playerID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid player ID format",
+		})
+		return
+	}
+
+	game, player, err := action(gameID, playerID)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":        game.ID,
+		"player_id":      playerID,
+		"player_name":    player.Name,
+		"hands":          player.Hands,
+		"active_hand":    player.ActiveHand,
+		"insured":        player.Insured,
+		"status":         game.Status.String(),
+		"current_player": game.CurrentPlayer,
+		"message":        player.Name + verb,
+	})
+}
+
+// CreateBlackjackTable creates a blackjack game under table rules chosen by preset or given in full.
+// The number of decks comes from the rules, and a provably fair table deals from a committed server seed.
+func (h *HandlerDependencies) CreateBlackjackTable(c *gin.Context) {
+	table, ok := bindTableRules(c)
+	if !ok {
+		return
+	}
+	rules := table.rules
+
+	game, err := h.BlackjackService.CreateBlackjackGame(rules, table.deckType, table.maxPlayers)
+	if err == nil {
+		game, err = h.applyGameOptions(game, table.options)
+	}
+	if err == nil && table.provablyFair {
+		game, err = h.FairnessService.MakeProvablyFair(game.ID)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	h.updateGamesCreatedMetric(c, table.deckType, rules.Decks)
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"deck_name":       game.Deck.Name,
+		"message":         "New Blackjack table created",
+		"remaining_cards": game.Deck.RemainingCards(),
+		"max_players":     game.MaxPlayers,
+		"deck_type":       game.Deck.DeckType.String(),
+		"rules":           game.Rules(),
+		"fair":            game.ViewFor(models.Spectator).Fair,
+		"created":         game.Created,
+	})
+}
+
+// tableOptions are the choices a blackjack or Glitchjack table is created with.
+type tableOptions struct {
+	rules        models.BlackjackRules
+	maxPlayers   int
+	deckType     models.DeckType
+	provablyFair bool
+	options      gameOptions
+}
+
+// bindTableRules reads the optional table request body, resolving a preset into its rules and
+// defaulting to six players and a standard deck, along with the seed and shuffle query parameters. It writes a 400 response
+// and returns false when the body or parameters are invalid.
+func bindTableRules(c *gin.Context) (tableOptions, bool) {
+	var request api.CreateBlackjackTableRequest
+	if err := bindOptionalJSON(c, &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return tableOptions{}, false
+	}
+
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return tableOptions{}, false
+	}
+	if options.seed != nil && request.ProvablyFair {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Seeded tables cannot be provably fair",
+		})
+		return tableOptions{}, false
+	}
+	if options.shuffle != nil && request.ProvablyFair {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Provably fair tables always use a perfect shuffle",
+		})
+		return tableOptions{}, false
+	}
+
+	deckType := models.Standard
+	switch validators.SanitizeString(request.DeckType, 20) {
+	case "", "standard":
+	case "spanish21":
+		deckType = models.Spanish21
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid deck_type (must be standard or spanish21)",
+		})
+		return tableOptions{}, false
+	}
+
+	if request.MaxPlayers == 0 {
+		request.MaxPlayers = 6
+	}
+	if request.MaxPlayers < 1 || request.MaxPlayers > 10 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid max_players (must be 1-10)",
+		})
+		return tableOptions{}, false
+	}
+
+	rules := models.DefaultBlackjackRules()
+	switch {
+	case request.Preset != "" && request.Rules != nil:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Give a preset or rules, not both",
+		})
+		return tableOptions{}, false
+	case request.Preset != "":
+		preset, err := models.BlackjackRulesPreset(validators.SanitizeString(request.Preset, 30))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return tableOptions{}, false
+		}
+		rules = preset
+	case request.Rules != nil:
+		rules = *request.Rules
+	}
+	return tableOptions{rules: rules, maxPlayers: request.MaxPlayers, deckType: deckType, provablyFair: request.ProvablyFair, options: options}, true
+}
@@ -299,4 +299,169 @@ func TestRemovePlayer(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), "Invalid player ID format")
-}
\ No newline at end of file
+}
+
+func TestBlackjackHandActions(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/game/:gameId/stand/:playerId", deps.PlayerStand)
+	router.POST("/game/:gameId/split/:playerId", deps.PlayerSplit)
+	router.POST("/game/:gameId/double/:playerId", deps.PlayerDouble)
+	router.POST("/game/:gameId/insurance/:playerId", deps.PlayerInsure)
+	router.POST("/game/:gameId/surrender/:playerId", deps.PlayerSurrender)
+	router.GET("/game/:gameId/results", deps.GetGameResults)
+
+	request := func(method, path string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	// Alice is dealt a pair of eights against a dealer seven, then a Three, Ten and Two
+	game := deps.GameService.CreateGame(1)
+	_, alice, _ := deps.GameService.AddPlayerToGame(game.ID, "Alice")
+	stacked := []models.Card{{Rank: models.Eight}, {Rank: models.Ten}, {Rank: models.Eight, Suit: models.Spades},
+		{Rank: models.Seven}, {Rank: models.Three}, {Rank: models.Ten}, {Rank: models.Two}}
+	game.Deck.Cards = append(stacked, game.Deck.Cards...)
+	_, err := deps.BlackjackService.StartBlackjackGame(game.ID)
+	assert.NoError(t, err)
+	base := "/game/" + game.ID
+
+	code, response := request("POST", base+"/split/"+alice.ID)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, response["hands"], 2)
+	assert.Equal(t, "Alice splits", response["message"])
+
+	code, response = request("POST", base+"/insurance/"+alice.ID)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, response["error"], "dealer shows an ace")
+
+	code, response = request("POST", base+"/double/"+alice.ID)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(1), response["active_hand"])
+
+	code, _ = request("POST", base+"/surrender/"+alice.ID)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = request("POST", base+"/stand/"+alice.ID)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, response = request("GET", base+"/results")
+	assert.Equal(t, http.StatusOK, code)
+	players := response["players"].([]interface{})
+	hands := players[0].(map[string]interface{})["hands"].([]interface{})
+	assert.Len(t, hands, 2)
+	assert.Equal(t, "win", hands[0].(map[string]interface{})["result"])
+	assert.Equal(t, true, hands[0].(map[string]interface{})["doubled"])
+	assert.Equal(t, "win,lose", response["results"].(map[string]interface{})[alice.ID])
+
+	code, _ = request("POST", "/game/invalid-id/split/"+alice.ID)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("POST", "/game/00000000-0000-0000-0000-000000000000/double/"+alice.ID)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestCreateBlackjackTableRules(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/game/new/blackjack", deps.CreateBlackjackTable)
+	router.POST("/game/new/glitchjack", deps.CreateGlitchjackTable)
+	router.GET("/game/:gameId/state", deps.GetGameState)
+
+	request := func(method, path, body string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, response := request("POST", "/game/new/blackjack", `{"preset": "vegas_strip", "rules": {"decks": 2}}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Give a preset or rules, not both", response["error"])
+
+	code, _ = request("POST", "/game/new/blackjack", `{"preset": "monte_carlo"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("POST", "/game/new/glitchjack", `{"max_players": 11}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, response = request("POST", "/game/new/glitchjack", `{"rules": {"decks": 2, "blackjack_payout": "6:5", "surrender": "sometimes"}}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, response["error"], "surrender")
+	code, _ = request("POST", "/game/new/blackjack", `{"deck_type": "tarot"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, response = request("POST", "/game/new/glitchjack", `{"deck_type": "spanish21"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Glitchjack tables deal their own random decks", response["error"])
+
+	// Tables created with rules report them in the game state
+	rules, _ := models.BlackjackRulesPreset(models.PresetAtlanticCity)
+	game, err := deps.BlackjackService.CreateBlackjackGame(rules, models.Standard, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, 8*52, game.Deck.RemainingCards())
+	code, response = request("GET", "/game/"+game.ID+"/state", "")
+	assert.Equal(t, http.StatusOK, code)
+	state := response["blackjack_rules"].(map[string]interface{})
+	assert.Equal(t, "late", state["surrender"])
+	assert.Equal(t, true, state["dealer_peek"])
+}
+
+func TestNextRound(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/game/:gameId/next-round", deps.NextRound)
+	router.GET("/game/:gameId/state", deps.GetGameState)
+	router.GET("/game/:gameId", deps.GetGameInfo)
+
+	request := func(method, path string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	game := deps.GameService.CreateGame(1)
+	_, alice, _ := deps.GameService.AddPlayerToGame(game.ID, "Alice")
+	base := "/game/" + game.ID
+	_, err := deps.BlackjackService.StartBlackjackGame(game.ID)
+	assert.NoError(t, err)
+
+	code, response := request("POST", base+"/next-round")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "the current round has not finished", response["error"])
+
+	_, _, err = deps.BlackjackService.PlayerStand(game.ID, alice.ID)
+	assert.NoError(t, err)
+	code, response = request("POST", base+"/next-round")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(2), response["round"])
+	assert.Equal(t, "waiting", response["status"])
+	assert.Equal(t, false, response["reshuffled"])
+
+	code, response = request("GET", base+"/state")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(2), response["round"])
+	assert.Greater(t, response["shoe_penetration"], float64(0))
+
+	// The shoe's progress towards the cut card is part of the game info
+	code, response = request("GET", base)
+	assert.Equal(t, http.StatusOK, code)
+	shoe := response["shoe"].(map[string]interface{})
+	assert.Equal(t, float64(52), shoe["size"])
+	assert.Equal(t, float64(39), shoe["cut_card"])
+	assert.Equal(t, float64(52)-shoe["remaining"].(float64), shoe["dealt"])
+	assert.Greater(t, shoe["penetration_percent"], float64(0))
+	assert.Equal(t, false, shoe["cut_card_reached"])
+	assert.Equal(t, shoe["dealt"], shoe["discards"], "the first round's cards wait in the discard pile")
+
+	code, _ = request("POST", "/game/550e8400-e29b-41d4-a716-446655440000/next-round")
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -1,27 +1,106 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/peteshima/cardgame-api/config"
+	"github.com/peteshima/cardgame-api/models"
 	"github.com/peteshima/cardgame-api/validators"
 	"github.com/peteshima/cardgame-api/api"
 )
 
 func (h *HandlerDependencies) CreateNewCribbageGame(c *gin.Context) {
-	game := h.CribbageService.CreateCribbageGame()
+	h.createCribbageGame(c, 2)
+}
+
+// CreateNewCribbageGameWithPlayers creates a cribbage game for 2-4 players; four play as partners.
+func (h *HandlerDependencies) CreateNewCribbageGameWithPlayers(c *gin.Context) {
+	playersStr := validators.SanitizeString(c.Param("players"), 10)
+	maxPlayers, valid := validators.ValidateNumber(playersStr)
+	if !valid || maxPlayers < 2 || maxPlayers > 4 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid players parameter (must be 2-4)",
+		})
+		return
+	}
+
+	h.createCribbageGame(c, maxPlayers)
+}
+
+// CreateCribbageTable creates a cribbage game from an optional body choosing the number of players,
+// how many games the match is played over and whether hands are claimed under muggins.
+func (h *HandlerDependencies) CreateCribbageTable(c *gin.Context) {
+	var request api.CreateCribbageTableRequest
+	if err := bindOptionalJSON(c, &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	if request.MaxPlayers == 0 {
+		request.MaxPlayers = 2
+	}
+	if request.MaxPlayers < 2 || request.MaxPlayers > 4 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid max_players (must be 2-4)",
+		})
+		return
+	}
+
+	rules := models.DefaultCribbageRules()
+	if request.BestOf != 0 {
+		rules.BestOf = request.BestOf
+	}
+	rules.Muggins = request.Muggins
+	rules.MugginsWindow = request.MugginsWindow
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+
+	game, err := h.CribbageService.CreateCribbageTable(rules, request.MaxPlayers)
+	if err == nil {
+		game, err = h.applyGameOptions(game, options)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	writeCribbageCreated(c, game)
+}
+
+// createCribbageGame creates the game and writes the creation response.
+func (h *HandlerDependencies) createCribbageGame(c *gin.Context, maxPlayers int) {
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.CribbageService.CreateCribbageGameWithPlayers(maxPlayers), options)
+	if !ok {
+		return
+	}
+	writeCribbageCreated(c, game)
+}
+
+// writeCribbageCreated writes the response for a newly created cribbage game.
+func writeCribbageCreated(c *gin.Context, game *models.Game) {
 	c.JSON(http.StatusOK, gin.H{
-		"game_id":        game.ID,
-		"game_type":      game.GameType.String(),
-		"deck_name":      game.Deck.Name,
-		"deck_type":      game.Deck.DeckType.String(),
-		"max_players":    game.MaxPlayers,
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"deck_name":       game.Deck.Name,
+		"deck_type":       game.Deck.DeckType.String(),
+		"max_players":     game.MaxPlayers,
 		"current_players": len(game.Players),
-		"message":        "New Cribbage game created",
+		"message":         "New Cribbage game created",
 		"remaining_cards": game.Deck.RemainingCards(),
-		"created":        game.Created,
+		"rules":           game.CurrentCribbageRules(),
+		"created":         game.Created,
 	})
 }
 
@@ -119,5 +198,259 @@ func (h *HandlerDependencies) CribbageDiscard(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// Additional cribbage handlers would go here but I'll keep it minimal for now
-// The pattern is the same: validate inputs, call service, return response
\ No newline at end of file
+// CribbagePlay pegs a card from the player's hand onto the running count.
+func (h *HandlerDependencies) CribbagePlay(c *gin.Context) {
+	gameID, playerID, ok := cribbagePlayerParams(c)
+	if !ok {
+		return
+	}
+
+	var request api.CribbagePlayRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	game, _, err := h.CribbageService.CribbagePlay(gameID, playerID, *request.CardIndex)
+	h.writeCribbageResponse(c, game, err, gin.H{"message": "Card played"})
+}
+
+// CribbageGo says go for a player who has no card that keeps the count at 31 or under.
+func (h *HandlerDependencies) CribbageGo(c *gin.Context) {
+	gameID, playerID, ok := cribbagePlayerParams(c)
+	if !ok {
+		return
+	}
+
+	game, _, err := h.CribbageService.CribbageGo(gameID, playerID)
+	h.writeCribbageResponse(c, game, err, gin.H{"message": "Go"})
+}
+
+// CribbageShow counts the non-dealer's hand, the dealer's hand and the crib, then passes the deal.
+func (h *HandlerDependencies) CribbageShow(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, scores, ok := h.CribbageService.CribbageShow(gameID)
+	var err error
+	if !ok {
+		err = fmt.Errorf("not in show phase")
+		if game != nil && game.CurrentCribbageRules().Muggins {
+			err = fmt.Errorf("muggins games are counted by each player claiming their own score")
+		}
+	}
+	h.writeCribbageResponse(c, game, err, gin.H{"message": "Hands counted", "scores": scores})
+}
+
+// CribbageClaim claims the player's score for their hand, or the dealer's for the crib, in a muggins game.
+func (h *HandlerDependencies) CribbageClaim(c *gin.Context) {
+	gameID, playerID, ok := cribbagePlayerParams(c)
+	if !ok {
+		return
+	}
+
+	var request api.CribbageClaimRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	game, err := h.CribbageService.CribbageClaim(gameID, playerID, *request.Points)
+	h.writeCribbageResponse(c, game, err, gin.H{"message": "Score claimed"})
+}
+
+// CribbageMuggins calls muggins on an opponent's claim, taking the points it missed.
+func (h *HandlerDependencies) CribbageMuggins(c *gin.Context) {
+	gameID, playerID, ok := cribbagePlayerParams(c)
+	if !ok {
+		return
+	}
+
+	var request api.CribbageMugginsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	game, err := h.CribbageService.CribbageMuggins(gameID, playerID, *request.Claim)
+	h.writeCribbageResponse(c, game, err, gin.H{"message": "Muggins"})
+}
+
+// CribbageNextHand shuffles a fresh deck and deals the next hand after the show.
+func (h *HandlerDependencies) CribbageNextHand(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.CribbageService.CribbageNextHand(gameID)
+	h.writeCribbageResponse(c, game, err, gin.H{"message": "Next hand dealt"})
+}
+
+// CribbageNextGame shuffles a fresh deck and deals the first hand of the next game of a match.
+func (h *HandlerDependencies) CribbageNextGame(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.CribbageService.CribbageNextGame(gameID)
+	h.writeCribbageResponse(c, game, err, gin.H{"message": "Next game dealt"})
+}
+
+// GetCribbageState returns the table as the caller may see it: their own hand, the count and
+// cards played, the starter, the scores, and the crib once it is turned over.
+func (h *HandlerDependencies) GetCribbageState(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.CribbageService.GetCribbageGame(gameID)
+	if game != nil && err == nil {
+		if _, valid := requestViewer(c, game); !valid {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid player or admin token",
+			})
+			return
+		}
+	}
+	h.writeCribbageResponse(c, game, err, nil)
+}
+
+// cribbagePlayerParams validates the game and player IDs in the path, writing a 400 response if either is malformed.
+func cribbagePlayerParams(c *gin.Context) (string, string, bool) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	playerID := validators.SanitizeString(c.Param("playerId"), 50)
+
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return "", "", false
+	}
+
+	if !validators.ValidatePlayerID(playerID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid player ID format",
+		})
+		return "", "", false
+	}
+	return gameID, playerID, true
+}
+
+// writeCribbageResponse writes the outcome of a cribbage service call, adding any extra fields to the state.
+func (h *HandlerDependencies) writeCribbageResponse(c *gin.Context, game *models.Game, err error, extra gin.H) {
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	viewer, _ := requestViewer(c, game)
+	response := cribbageStateResponse(game, viewer, config.GetBaseURL(c))
+	for key, value := range extra {
+		response[key] = value
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// cribbageStateResponse describes a cribbage table from the viewer's point of view.
+func cribbageStateResponse(game *models.Game, viewer models.Viewer, baseURL string) gin.H {
+	view := game.ViewFor(viewer)
+	state := view.CribbageState
+
+	players := make([]gin.H, 0, len(view.Players))
+	for i, player := range view.Players {
+		entry := gin.H{
+			"id":        player.ID,
+			"name":      player.Name,
+			"hand":      convertCardsWithImages(player.Hand, baseURL),
+			"hand_size": player.HandSize(),
+		}
+		if i < len(state.PlayerScores) {
+			entry["score"] = state.PlayerScores[i]
+		}
+		if state.TeamScores != nil {
+			entry["team"] = i % 2
+		}
+		players = append(players, entry)
+	}
+
+	response := gin.H{
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"status":          game.Status.String(),
+		"phase":           state.Phase.String(),
+		"dealer":          state.Dealer,
+		"current_player":  game.CurrentPlayer,
+		"play_total":      state.PlayTotal,
+		"play_count":      state.PlayCount,
+		"played_cards":    convertCardsWithImages(state.PlayedCards, baseURL),
+		"crib_size":       len(state.Crib),
+		"player_scores":   state.PlayerScores,
+		"game_score":      state.GameScore,
+		"remaining_cards": game.Deck.RemainingCards(),
+		"players":         players,
+		"board":           state.Board,
+		"peg_history":     state.PegHistory,
+		"viewer":          viewer,
+	}
+	if state.Starter != nil {
+		response["starter"] = state.Starter.ToCardWithImages(baseURL)
+	}
+	if viewer.CanSeeAll() || state.Phase == models.CribbageShow || state.Phase == models.CribbageFinished {
+		response["crib"] = convertCardsWithImages(state.Crib, baseURL)
+	}
+	if state.LastPeg != nil {
+		response["last_peg"] = state.LastPeg
+	}
+	if len(state.Show) > 0 {
+		response["show"] = state.Show
+	}
+	if state.TeamScores != nil {
+		response["team_scores"] = state.TeamScores
+	}
+	if state.Match != nil {
+		response["match"] = state.Match
+	}
+	if len(state.Claims) > 0 {
+		response["claims"] = state.Claims
+	}
+	if len(state.Winners) > 0 {
+		response["winners"] = state.Winners
+		if winner := game.GetPlayer(state.Winners[0]); winner != nil {
+			response["winner"] = winner.Name
+			response["winner_id"] = winner.ID
+		}
+	}
+	return response
+}
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peteshima/cardgame-api/models"
+)
+
+func TestCribbageRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/game/:gameId/cribbage/play/:playerId", deps.CribbagePlay)
+	router.POST("/game/:gameId/cribbage/go/:playerId", deps.CribbageGo)
+	router.POST("/game/:gameId/cribbage/show", deps.CribbageShow)
+	router.POST("/game/:gameId/cribbage/next-hand", deps.CribbageNextHand)
+	router.GET("/game/:gameId/cribbage/state", deps.GetCribbageState)
+
+	request := func(method, path, body, token string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if token != "" {
+			req.Header.Set("X-Player-Token", token)
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, _ := request("GET", "/game/not-a-uuid/cribbage/state", "", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	game := deps.CribbageService.CreateCribbageGame()
+	path := "/game/" + game.ID + "/cribbage"
+	code, _ = request("GET", path+"/state", "", "")
+	assert.Equal(t, http.StatusBadRequest, code, "the game has not been started")
+
+	_, alice, aliceToken, _ := deps.GameService.JoinGame(game.ID, "Alice")
+	_, bob, _, _ := deps.GameService.JoinGame(game.ID, "Bob")
+	_, err := deps.CribbageService.StartCribbageGame(game.ID)
+	require.NoError(t, err)
+
+	code, state := request("GET", path+"/state", "", aliceToken)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "discard", state["phase"])
+	players := state["players"].([]interface{})
+	aliceCard := players[0].(map[string]interface{})["hand"].([]interface{})[0].(map[string]interface{})
+	bobCard := players[1].(map[string]interface{})["hand"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(alice.Hand[0].Rank), aliceCard["rank"])
+	assert.Equal(t, float64(0), bobCard["rank"], "Bob's hand is private")
+	code, _ = request("GET", path+"/state", "", "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = request("POST", path+"/next-hand", "", "")
+	assert.Equal(t, http.StatusBadRequest, code, "the hand has not been played")
+	code, _ = request("POST", path+"/show", "", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	_, _, err = deps.CribbageService.CribbageDiscard(game.ID, alice.ID, []int{0, 1})
+	require.NoError(t, err)
+	_, _, err = deps.CribbageService.CribbageDiscard(game.ID, bob.ID, []int{0, 1})
+	require.NoError(t, err)
+
+	code, state = request("GET", path+"/state", "", aliceToken)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "play", state["phase"])
+	assert.Equal(t, float64(4), state["crib_size"])
+	assert.NotContains(t, state, "crib", "the crib stays face down until the show")
+	assert.NotNil(t, state["starter"])
+
+	code, _ = request("POST", path+"/play/"+bob.ID, `{}`, "")
+	assert.Equal(t, http.StatusBadRequest, code, "the card index is required")
+	code, _ = request("POST", path+"/play/"+alice.ID, `{"card_index":0}`, "")
+	assert.Equal(t, http.StatusBadRequest, code, "Alice deals, so Bob leads")
+	code, _ = request("POST", path+"/go/"+bob.ID, "", "")
+	assert.Equal(t, http.StatusBadRequest, code, "Bob can play a card")
+
+	// Peg out the hand, each player laying their first card that fits or saying go
+	for game.CribbageState.Phase.String() == "play" {
+		player := game.Players[game.CurrentPlayer]
+		index := -1
+		for i, card := range player.Hand {
+			if game.CribbageState.PlayTotal+card.CribbagePlayValue() <= 31 {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			code, state = request("POST", path+"/go/"+player.ID, "", "")
+		} else {
+			code, state = request("POST", path+"/play/"+player.ID, fmt.Sprintf(`{"card_index":%d}`, index), "")
+		}
+		require.Equal(t, http.StatusOK, code, state["error"])
+	}
+	assert.Equal(t, "show", state["phase"])
+	assert.Len(t, state["crib"], 4)
+
+	code, state = request("POST", path+"/show", "", "")
+	require.Equal(t, http.StatusOK, code)
+	scores := state["scores"].(map[string]interface{})
+	assert.Contains(t, scores, "crib")
+	show := state["show"].([]interface{})
+	require.Len(t, show, 3)
+	crib := show[2].(map[string]interface{})
+	assert.Equal(t, true, crib["crib"])
+	assert.Equal(t, scores["crib"], crib["score"].(map[string]interface{})["total"])
+	assert.Equal(t, "deal", state["phase"])
+
+	code, state = request("POST", path+"/next-hand", "", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "discard", state["phase"])
+	assert.Equal(t, float64(1), state["dealer"])
+	assert.Equal(t, float64(40), state["remaining_cards"])
+}
+
+func TestCribbagePartnershipRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.GET("/game/new/cribbage/:players", deps.CreateNewCribbageGameWithPlayers)
+	router.GET("/game/:gameId/cribbage/state", deps.GetCribbageState)
+
+	request := func(path string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, _ := request("/game/new/cribbage/5")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, created := request("/game/new/cribbage/4")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(4), created["max_players"])
+
+	gameID := created["game_id"].(string)
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		_, _, ok := deps.GameService.AddPlayerToGame(gameID, name)
+		require.True(t, ok)
+	}
+	_, err := deps.CribbageService.StartCribbageGame(gameID)
+	require.NoError(t, err)
+
+	code, state := request("/game/" + gameID + "/cribbage/state")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{float64(0), float64(0)}, state["team_scores"])
+	players := state["players"].([]interface{})
+	require.Len(t, players, 4)
+	assert.Equal(t, float64(0), players[2].(map[string]interface{})["team"], "Carol partners Alice")
+	assert.Equal(t, float64(5), players[3].(map[string]interface{})["hand_size"])
+}
+
+func TestCribbageMatchRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/game/new/cribbage", deps.CreateCribbageTable)
+	router.POST("/game/:gameId/cribbage/next-game", deps.CribbageNextGame)
+
+	request := func(path, body string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, _ := request("/game/new/cribbage", `{"best_of":4}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("/game/new/cribbage", `{"max_players":5}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, created := request("/game/new/cribbage", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(1), created["rules"].(map[string]interface{})["best_of"])
+	code, created = request("/game/new/cribbage", `{"best_of":3}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(3), created["rules"].(map[string]interface{})["best_of"])
+
+	gameID := created["game_id"].(string)
+	for _, name := range []string{"Alice", "Bob"} {
+		_, _, ok := deps.GameService.AddPlayerToGame(gameID, name)
+		require.True(t, ok)
+	}
+	game, err := deps.CribbageService.StartCribbageGame(gameID)
+	require.NoError(t, err)
+	code, _ = request("/game/"+gameID+"/cribbage/next-game", "")
+	assert.Equal(t, http.StatusBadRequest, code, "the first game is still being played")
+
+	game.CribbageState.Phase = models.CribbageFinished
+	code, state := request("/game/"+gameID+"/cribbage/next-game", "")
+	require.Equal(t, http.StatusOK, code, state["error"])
+	assert.Equal(t, "discard", state["phase"])
+	assert.Equal(t, float64(1), state["dealer"])
+	assert.Equal(t, float64(3), state["match"].(map[string]interface{})["best_of"])
+	assert.Len(t, state["board"], 2)
+}
+
+func TestCribbageMugginsRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/game/new/cribbage", deps.CreateCribbageTable)
+	router.POST("/game/:gameId/cribbage/show", deps.CribbageShow)
+	router.POST("/game/:gameId/cribbage/claim/:playerId", deps.CribbageClaim)
+	router.POST("/game/:gameId/cribbage/muggins/:playerId", deps.CribbageMuggins)
+
+	request := func(path, body string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, _ := request("/game/new/cribbage", `{"muggins":true,"muggins_window":301}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, created := request("/game/new/cribbage", `{"muggins":true,"muggins_window":60}`)
+	require.Equal(t, http.StatusOK, code)
+	rules := created["rules"].(map[string]interface{})
+	assert.Equal(t, true, rules["muggins"])
+	assert.Equal(t, float64(60), rules["muggins_window"])
+
+	gameID := created["game_id"].(string)
+	game, alice, _ := deps.GameService.AddPlayerToGame(gameID, "Alice")
+	_, bob, _ := deps.GameService.AddPlayerToGame(gameID, "Bob")
+	_, err := deps.CribbageService.StartCribbageGame(gameID)
+	require.NoError(t, err)
+	_, _, err = deps.CribbageService.CribbageDiscard(gameID, alice.ID, []int{0, 1})
+	require.NoError(t, err)
+	_, _, err = deps.CribbageService.CribbageDiscard(gameID, bob.ID, []int{0, 1})
+	require.NoError(t, err)
+	for game.CribbageState.Phase == models.CribbagePlay {
+		player := game.Players[game.CurrentPlayer]
+		played := false
+		for i, card := range player.Hand {
+			if game.CribbageState.PlayTotal+card.CribbagePlayValue() <= 31 {
+				_, _, err = deps.CribbageService.CribbagePlay(gameID, player.ID, i)
+				played = true
+				break
+			}
+		}
+		if !played {
+			_, _, err = deps.CribbageService.CribbageGo(gameID, player.ID)
+		}
+		require.NoError(t, err)
+	}
+
+	path := "/game/" + gameID + "/cribbage"
+	code, response := request(path+"/show", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, response["error"], "claiming")
+	code, _ = request(path+"/claim/"+bob.ID, `{}`)
+	assert.Equal(t, http.StatusBadRequest, code, "points are required")
+	code, _ = request(path+"/claim/"+alice.ID, `{"points":0}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Bob claims first")
+
+	code, state := request(path+"/claim/"+bob.ID, `{"points":0}`)
+	require.Equal(t, http.StatusOK, code, state["error"])
+	claims := state["claims"].([]interface{})
+	require.Len(t, claims, 1)
+	assert.Equal(t, bob.ID, claims[0].(map[string]interface{})["player_id"])
+
+	code, _ = request(path+"/muggins/"+bob.ID, `{"claim":0}`)
+	assert.Equal(t, http.StatusBadRequest, code, "Bob cannot mug himself")
+	if game.CribbageState.Claims[0].Missed > 0 {
+		code, state = request(path+"/muggins/"+alice.ID, `{"claim":0}`)
+		require.Equal(t, http.StatusOK, code, state["error"])
+		assert.Equal(t, alice.ID, state["claims"].([]interface{})[0].(map[string]interface{})["mugged_by"])
+	}
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/peteshima/cardgame-api/api"
+	"github.com/peteshima/cardgame-api/config"
+	"github.com/peteshima/cardgame-api/models"
+	"github.com/peteshima/cardgame-api/validators"
+)
+
+// maxVerifyDecks caps the shoe a verification request may rebuild.
+const maxVerifyDecks = 100
+
+// GetFairness reports a provably fair table's commitment, client seed and nonce, and the server seeds
+// of every finished round. The current server seed is only shown to admins until the round is over.
+func (h *HandlerDependencies) GetFairness(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game := h.FairnessService.GetFairGame(gameID)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	viewer, valid := requestViewer(c, game)
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid player or admin token",
+		})
+		return
+	}
+
+	if game.Fair == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "game is not provably fair",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id": game.ID,
+		"status":  game.Status.String(),
+		"fair":    game.ViewFor(viewer).Fair,
+	})
+}
+
+// SetClientSeed mixes a player's client seed into a provably fair table's shuffle before the deal.
+func (h *HandlerDependencies) SetClientSeed(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	var request api.ClientSeedRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	game, err := h.FairnessService.SetClientSeed(gameID, request.ClientSeed)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id": game.ID,
+		"fair":    game.ViewFor(models.Spectator).Fair,
+		"message": "Client seed set",
+	})
+}
+
+// VerifyShuffle rebuilds the deck a provably fair table dealt from its seeds, so anyone holding the
+// revealed server seed can check both the commitment and the order the cards came out in.
+func (h *HandlerDependencies) VerifyShuffle(c *gin.Context) {
+	var request api.VerifyShuffleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	gameType := models.Blackjack
+	switch request.GameType {
+	case "", "blackjack":
+	case "glitchjack":
+		gameType = models.Glitchjack
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game_type (must be blackjack or glitchjack)",
+		})
+		return
+	}
+
+	deckType := models.Standard
+	switch request.DeckType {
+	case "", "standard":
+	case "spanish21":
+		deckType = models.Spanish21
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid deck_type (must be standard or spanish21)",
+		})
+		return
+	}
+
+	if request.Decks == 0 {
+		request.Decks = 1
+	}
+	if request.Decks < 1 || request.Decks > maxVerifyDecks {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid decks (must be 1-100)",
+		})
+		return
+	}
+
+	deck := models.FairDeck(request.ServerSeed, request.ClientSeed, gameType, deckType, request.Decks)
+	cards := make([]*models.Card, len(deck.Cards))
+	for i := range deck.Cards {
+		deck.Cards[i].FaceUp = true // Every card is shown so the order can be checked
+		cards[i] = &deck.Cards[i]
+	}
+
+	commitment := models.FairCommitment(request.ServerSeed)
+	response := gin.H{
+		"commitment": commitment,
+		"cards":      convertCardsWithImages(cards, config.GetBaseURL(c)),
+		"card_count": len(cards),
+	}
+	if request.Commitment != "" {
+		response["commitment_matches"] = request.Commitment == commitment
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairnessRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.GET("/game/:gameId/fairness", deps.GetFairness)
+	router.POST("/game/:gameId/fairness/client-seed", deps.SetClientSeed)
+	router.POST("/fairness/verify", deps.VerifyShuffle)
+
+	request := func(method, path, body string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	plain := deps.GameService.CreateGame(1)
+	code, response := request("GET", "/game/"+plain.ID+"/fairness", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "game is not provably fair", response["error"])
+
+	game, err := deps.FairnessService.MakeProvablyFair(plain.ID)
+	require.NoError(t, err)
+	base := "/game/" + game.ID
+
+	code, response = request("POST", base+"/fairness/client-seed", `{"client_seed": "lucky"}`)
+	require.Equal(t, http.StatusOK, code)
+
+	code, response = request("GET", base+"/fairness", "")
+	require.Equal(t, http.StatusOK, code)
+	fair := response["fair"].(map[string]interface{})
+	assert.Equal(t, "lucky", fair["client_seed"])
+	assert.Nil(t, fair["server_seed"], "the server seed stays hidden until the round is over")
+
+	// The verifier rebuilds the exact deck from the seeds and checks the commitment
+	body := fmt.Sprintf(`{"server_seed": %q, "client_seed": "lucky", "commitment": %q}`, game.Fair.ServerSeed, fair["commitment"])
+	code, response = request("POST", "/fairness/verify", body)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, response["commitment_matches"])
+	assert.Equal(t, float64(52), response["card_count"])
+	top := response["cards"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(game.Deck.Cards[0].Rank), top["rank"])
+	assert.Equal(t, float64(game.Deck.Cards[0].Suit), top["suit"])
+
+	code, _ = request("POST", "/fairness/verify", `{"server_seed": "abc", "game_type": "poker"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
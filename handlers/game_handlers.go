@@ -22,7 +22,14 @@ func (h *HandlerDependencies) CreateNewGame(c *gin.Context) {
 		zap.String("type", "standard"),
 	)
 
-	game := h.GameService.CreateGame(1)
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.GameService.CreateGame(1), options)
+	if !ok {
+		return
+	}
 	
 	// Update metrics
 	h.updateGamesCreatedMetric(c, models.Standard, 1)
@@ -56,7 +63,14 @@ func (h *HandlerDependencies) CreateNewGameWithDecks(c *gin.Context) {
 		return
 	}
 
-	game := h.GameService.CreateGameWithDecks(numDecks)
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.GameService.CreateGameWithDecks(numDecks), options)
+	if !ok {
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":        game.ID,
 		"deck_name":      game.Deck.Name,
@@ -89,8 +103,16 @@ func (h *HandlerDependencies) CreateNewGameWithType(c *gin.Context) {
 		return
 	}
 	
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+
 	deckType := models.ParseDeckType(typeStr)
-	game := h.GameService.CreateGameWithType(numDecks, deckType)
+	game, ok := h.setupGame(c, h.GameService.CreateGameWithType(numDecks, deckType), options)
+	if !ok {
+		return
+	}
 	
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":        game.ID,
@@ -131,8 +153,16 @@ func (h *HandlerDependencies) CreateNewGameWithPlayers(c *gin.Context) {
 		return
 	}
 	
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+
 	deckType := models.ParseDeckType(typeStr)
-	game := h.GameService.CreateGameWithAllOptions(numDecks, deckType, models.Blackjack, maxPlayers)
+	game, ok := h.setupGame(c, h.GameService.CreateGameWithAllOptions(numDecks, deckType, models.Blackjack, maxPlayers), options)
+	if !ok {
+		return
+	}
 	
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":        game.ID,
@@ -148,7 +178,8 @@ func (h *HandlerDependencies) CreateNewGameWithPlayers(c *gin.Context) {
 }
 
 // ShuffleDeck randomizes the order of cards in an existing game's deck.
-// It validates the game ID and performs an in-place shuffle of all remaining cards.
+// It validates the game ID and performs an in-place shuffle of all remaining cards, in the game's
+// shuffle mode or the one given by the optional mode query parameter.
 func (h *HandlerDependencies) ShuffleDeck(c *gin.Context) {
 	gameID := validators.SanitizeString(c.Param("gameId"), 50)
 	if !validators.ValidateUUID(gameID) {
@@ -157,26 +188,53 @@ func (h *HandlerDependencies) ShuffleDeck(c *gin.Context) {
 		})
 		return
 	}
+
+	mode, ok := queryShuffleMode(c, "mode")
+	if !ok {
+		return
+	}
 	
-	game, exists := h.GameService.ShuffleGameDeck(gameID)
-	if !exists {
+	var game *models.Game
+	var err error
+	if mode != nil {
+		game, err = h.GameService.ShuffleGameDeckWith(gameID, *mode)
+	} else {
+		game, _ = h.GameService.ShuffleGameDeck(gameID)
+	}
+	if game == nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Game not found",
 		})
 		return
 	}
 
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if mode == nil {
+		mode = game.ShuffleMode
+	}
+	shuffleMode := models.ShufflePerfect
+	if mode != nil {
+		shuffleMode = mode.String()
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":        game.ID,
 		"deck_name":      game.Deck.Name,
 		"deck_type":      game.Deck.DeckType.String(),
 		"message":        "Deck shuffled",
 		"remaining_cards": game.Deck.RemainingCards(),
+		"shuffle_mode":   shuffleMode,
 	})
 }
 
 // GetGameInfo retrieves basic information about a game including deck details and card count.
-// It validates the game ID and returns deck name, type, remaining cards, and timestamps.
+// It validates the game ID and returns deck name, type, remaining cards, timestamps and the seed of a deterministic game.
 func (h *HandlerDependencies) GetGameInfo(c *gin.Context) {
 	gameID := validators.SanitizeString(c.Param("gameId"), 50)
 	if !validators.ValidateUUID(gameID) {
@@ -193,7 +251,16 @@ func (h *HandlerDependencies) GetGameInfo(c *gin.Context) {
 		})
 		return
 	}
+	
+	viewer, valid := requestViewer(c, game)
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid player or admin token",
+		})
+		return
+	}
 
+	// Only admins see the undealt order; everyone else gets face-down placeholders
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":        game.ID,
 		"deck_name":      game.Deck.Name,
@@ -202,11 +269,16 @@ func (h *HandlerDependencies) GetGameInfo(c *gin.Context) {
 		"is_empty":       game.Deck.IsEmpty(),
 		"created":        game.Created,
 		"last_used":      game.LastUsed,
-		"cards":          game.Deck.Cards,
+		"seed":           game.Seed,
+		"shuffle_mode":   game.ShuffleMode,
+		"shoe":           game.ShoeStatus(),
+		"cards":          game.ViewFor(viewer).Deck.Cards,
 	})
 }
 
-// GetGameState retrieves complete game state with blackjack values and card images
+// GetGameState retrieves complete game state with blackjack values and card images.
+// Cards are redacted for the requesting player or spectator; the admin token shows everything.
+// An optional ?at=<seq> query rebuilds the game from its event log as it was after that event.
 func (h *HandlerDependencies) GetGameState(c *gin.Context) {
 	gameID := validators.SanitizeString(c.Param("gameId"), 50)
 	if !validators.ValidateUUID(gameID) {
@@ -223,27 +295,98 @@ func (h *HandlerDependencies) GetGameState(c *gin.Context) {
 		})
 		return
 	}
+	
+	viewer, valid := requestViewer(c, game)
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid player or admin token",
+		})
+		return
+	}
+	
+	if atParam := c.Query("at"); atParam != "" {
+		seq, valid := validators.ValidateNumber(validators.SanitizeString(atParam, 10))
+		if !valid {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid event sequence number",
+			})
+			return
+		}
+		
+		replayed, err := h.GameService.ReplayGameAt(gameID, seq)
+		if replayed == nil && err == nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Game not found",
+			})
+			return
+		}
+		if err != nil {
+			h.Logger.Warn("Failed to replay game",
+				zap.String("game_id", gameID),
+				zap.Int("seq", seq),
+				zap.Error(err),
+			)
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		
+		state := gameStateResponse(replayed.ViewFor(viewer), config.GetBaseURL(c))
+		state["at"] = seq
+		state["viewer"] = viewer
+		c.JSON(http.StatusOK, state)
+		return
+	}
 
-	baseURL := config.GetBaseURL(c)
-	discardInfo := convertDiscardPiles(game.DiscardPiles)
-	playersWithValues := convertPlayersWithImages(game.Players, baseURL)
-	dealerInfo := convertDealerInfo(game.Dealer, baseURL)
+	state := gameStateResponse(game.ViewFor(viewer), config.GetBaseURL(c))
+	state["viewer"] = viewer
+	c.JSON(http.StatusOK, state)
+}
+
+// GetGameEvents returns the append-only event log for a game.
+// An optional ?since=<seq> query returns only the events recorded after that sequence number.
+func (h *HandlerDependencies) GetGameEvents(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+	
+	since := 0
+	if sinceParam := c.Query("since"); sinceParam != "" {
+		var valid bool
+		since, valid = validators.ValidateNumber(validators.SanitizeString(sinceParam, 10))
+		if !valid {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid event sequence number",
+			})
+			return
+		}
+	}
+	
+	game, exists := h.GameService.GetGame(gameID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+	
+	viewer, valid := requestViewer(c, game)
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid player or admin token",
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"game_id":         game.ID,
-		"game_type":       game.GameType.String(),
-		"status":          game.Status.String(),
-		"current_player":  game.CurrentPlayer,
-		"deck_name":       game.Deck.Name,
-		"deck_type":       game.Deck.DeckType.String(),
-		"remaining_cards": game.Deck.RemainingCards(),
-		"max_players":     game.MaxPlayers,
-		"current_players": len(game.Players),
-		"players":         playersWithValues,
-		"dealer":          dealerInfo,
-		"discard_piles":   discardInfo,
-		"created":         game.Created,
-		"last_used":       game.LastUsed,
+		"game_id":      game.ID,
+		"total_events": len(game.Events),
+		"events":       game.EventsFor(viewer, since),
 	})
 }
 
@@ -273,7 +416,7 @@ func (h *HandlerDependencies) AddPlayer(c *gin.Context) {
 		return
 	}
 
-	game, player, success := h.GameService.AddPlayerToGame(gameID, request.Name)
+	game, player, token, success := h.GameService.JoinGame(gameID, request.Name)
 	if !success {
 		if game == nil {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -287,10 +430,13 @@ func (h *HandlerDependencies) AddPlayer(c *gin.Context) {
 		return
 	}
 
+	// The token is only ever shown here; the player sends it back as X-Player-Token to see their own cards
 	c.JSON(http.StatusOK, gin.H{
-		"game_id":     game.ID,
-		"player":      player,
-		"message":     "Player added successfully",
+		"game_id":      game.ID,
+		"player_id":    player.ID,
+		"player":       game.ViewFor(models.Viewer{PlayerID: player.ID}).GetPlayer(player.ID),
+		"player_token": token,
+		"message":      "Player added successfully",
 	})
 }
 
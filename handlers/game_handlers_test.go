@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/peteshima/cardgame-api/managers"
@@ -304,4 +307,198 @@ func TestDeleteGame(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 	assert.Contains(t, w.Body.String(), "Game not found")
-}
\ No newline at end of file
+}
+
+func TestPlayerTokenRedactsGameState(t *testing.T) {
+	t.Setenv("ADMIN_TOKEN", "observer-secret")
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/game/:gameId/players", deps.AddPlayer)
+	router.GET("/game/:gameId", deps.GetGameInfo)
+	router.GET("/game/:gameId/state", deps.GetGameState)
+	router.GET("/game/:gameId/events", deps.GetGameEvents)
+
+	game := deps.GameService.CreateGame(1)
+
+	join := func(name string) (string, string) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/game/"+game.ID+"/players", bytes.NewBufferString(`{"name":"`+name+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "token_hash")
+
+		var response struct {
+			PlayerID    string `json:"player_id"`
+			PlayerToken string `json:"player_token"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotEmpty(t, response.PlayerToken)
+		return response.PlayerID, response.PlayerToken
+	}
+	aliceID, aliceToken := join("Alice")
+	bobID, _ := join("Bob")
+	deps.GameService.DealToPlayer(game.ID, aliceID, false)
+	deps.GameService.DealToPlayer(game.ID, bobID, false)
+	aliceCard := game.GetPlayer(aliceID).Hand[0]
+	bobCard := game.GetPlayer(bobID).Hand[0]
+
+	get := func(path string, header string, token string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if header != "" {
+			req.Header.Set(header, token)
+		}
+		router.ServeHTTP(w, req)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w.Code, body
+	}
+	hand := func(state map[string]interface{}, seat int) map[string]interface{} {
+		player := state["players"].([]interface{})[seat].(map[string]interface{})
+		return player["hand"].([]interface{})[0].(map[string]interface{})
+	}
+
+	code, state := get("/game/"+game.ID+"/state", "X-Player-Token", aliceToken)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, aliceID, state["viewer"].(map[string]interface{})["player_id"])
+	assert.Equal(t, float64(aliceCard.Rank), hand(state, 0)["rank"])
+	assert.Equal(t, float64(0), hand(state, 1)["rank"])
+
+	_, state = get("/game/"+game.ID+"/state", "", "")
+	assert.Equal(t, float64(0), hand(state, 0)["rank"])
+
+	_, state = get("/game/"+game.ID+"/state", "X-Admin-Token", "observer-secret")
+	assert.Equal(t, float64(bobCard.Rank), hand(state, 1)["rank"])
+
+	code, _ = get("/game/"+game.ID+"/state", "X-Player-Token", "forged")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = get("/game/"+game.ID+"/state", "X-Admin-Token", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	// Only admins see the undealt deck order
+	_, info := get("/game/"+game.ID, "X-Player-Token", aliceToken)
+	top := info["cards"].([]interface{})[0].(map[string]interface{})
+	assert.Len(t, info["cards"], game.Deck.RemainingCards())
+	assert.Equal(t, float64(0), top["rank"])
+	_, info = get("/game/"+game.ID, "X-Admin-Token", "observer-secret")
+	top = info["cards"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(game.Deck.Cards[0].Rank), top["rank"])
+
+	// Bob's deal is hidden from Alice in the event log, along with the deck snapshot
+	_, log := get("/game/"+game.ID+"/events?token="+aliceToken, "", "")
+	events := log["events"].([]interface{})
+	assert.NotContains(t, events[0].(map[string]interface{}), "deck")
+	bobDeal := events[len(events)-1].(map[string]interface{})["dealt"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(0), bobDeal["rank"])
+}
+
+func TestAdminAPIKeySeesEveryCard(t *testing.T) {
+	deps := setupTestHandler()
+	keys, err := middleware.NewKeyRing([]middleware.APIKey{
+		{ID: "ops", Role: middleware.RoleAdmin, Hash: middleware.HashAPIKey("admin-secret")},
+		{ID: "dealer-bot", Role: middleware.RoleTableHost, Hash: middleware.HashAPIKey("host-secret")},
+	})
+	require.NoError(t, err)
+	router := gin.New()
+	router.Use(middleware.APIKeyMiddleware(keys))
+	router.GET("/game/:gameId", deps.GetGameInfo)
+
+	game := deps.GameService.CreateGame(1)
+	topRank := func(key string) float64 {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/game/"+game.ID, nil)
+		req.Header.Set("X-API-Key", key)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		var info map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		return info["cards"].([]interface{})[0].(map[string]interface{})["rank"].(float64)
+	}
+
+	assert.Equal(t, float64(game.Deck.Cards[0].Rank), topRank("admin-secret"))
+	assert.Equal(t, float64(0), topRank("host-secret"))
+}
+
+func TestSeededGameCreation(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.GET("/game/new/:decks", deps.CreateNewGameWithDecks)
+	router.GET("/game/new/glitchjack/:decks", deps.CreateNewGlitchjackGameWithDecks)
+	router.GET("/game/:gameId", deps.GetGameInfo)
+
+	request := func(path string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("X-Admin-Token", "admin-secret")
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+	t.Setenv("ADMIN_TOKEN", "admin-secret")
+
+	for _, path := range []string{"/game/new/2", "/game/new/glitchjack/2"} {
+		code, first := request(path + "?seed=42")
+		require.Equal(t, http.StatusOK, code)
+		_, second := request(path + "?seed=42")
+		assert.Equal(t, first["deck_name"], second["deck_name"], path)
+
+		_, firstInfo := request("/game/" + first["game_id"].(string))
+		_, secondInfo := request("/game/" + second["game_id"].(string))
+		assert.Equal(t, float64(42), firstInfo["seed"], path)
+		assert.Equal(t, firstInfo["cards"], secondInfo["cards"], path)
+	}
+
+	code, response := request("/game/new/1?seed=-1")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, response["error"], "Invalid seed parameter")
+
+	_, response = request("/game/new/1")
+	_, info := request("/game/" + response["game_id"].(string))
+	assert.Nil(t, info["seed"], "games are random unless seeded")
+}
+
+func TestShuffleModes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.GET("/game/new/:decks", deps.CreateNewGameWithDecks)
+	router.GET("/game/:gameId/shuffle", deps.ShuffleDeck)
+	router.GET("/game/:gameId", deps.GetGameInfo)
+
+	request := func(method, path string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, created := request("GET", "/game/new/1?shuffle=casino")
+	require.Equal(t, http.StatusOK, code)
+	gameID := created["game_id"].(string)
+	_, info := request("GET", "/game/"+gameID)
+	assert.Equal(t, map[string]interface{}{"mode": "casino"}, info["shuffle_mode"])
+
+	code, response := request("GET", "/game/"+gameID+"/shuffle")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "casino", response["shuffle_mode"], "shuffles use the game's mode")
+
+	code, response = request("GET", "/game/"+gameID+"/shuffle?mode=riffle:2")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "riffle:2", response["shuffle_mode"])
+	assert.Equal(t, float64(52), response["remaining_cards"])
+
+	code, response = request("GET", "/game/"+gameID+"/shuffle?mode=juggle")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, response["error"], "Invalid mode parameter")
+
+	code, _ = request("GET", "/game/new/1?shuffle=riffle:99")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	_, created = request("GET", "/game/new/1")
+	code, response = request("GET", "/game/"+created["game_id"].(string)+"/shuffle")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "perfect", response["shuffle_mode"])
+}
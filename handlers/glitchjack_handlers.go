@@ -18,7 +18,14 @@ func (h *HandlerDependencies) CreateNewGlitchjackGame(c *gin.Context) {
 		zap.String("client_ip", c.ClientIP()),
 	)
 
-	game := h.GlitchjackService.CreateGlitchjackGame()
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.GlitchjackService.CreateGlitchjackGame(), options)
+	if !ok {
+		return
+	}
 	
 	// Update metrics
 	h.updateGamesCreatedMetric(c, models.Standard, 1)
@@ -53,7 +60,14 @@ func (h *HandlerDependencies) CreateNewGlitchjackGameWithDecks(c *gin.Context) {
 		return
 	}
 
-	game := h.GlitchjackService.CreateGlitchjackGameWithOptions(numDecks, 6)
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.GlitchjackService.CreateGlitchjackGameWithOptions(numDecks, 6), options)
+	if !ok {
+		return
+	}
 	
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":         game.ID,
@@ -88,7 +102,14 @@ func (h *HandlerDependencies) CreateNewGlitchjackGameWithPlayers(c *gin.Context)
 		return
 	}
 	
-	game := h.GlitchjackService.CreateGlitchjackGameWithOptions(numDecks, maxPlayers)
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.GlitchjackService.CreateGlitchjackGameWithOptions(numDecks, maxPlayers), options)
+	if !ok {
+		return
+	}
 	
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":         game.ID,
@@ -102,6 +123,49 @@ func (h *HandlerDependencies) CreateNewGlitchjackGameWithPlayers(c *gin.Context)
 	})
 }
 
+// CreateGlitchjackTable creates a Glitchjack game under table rules chosen by preset or given in full.
+// One random deck is generated for each deck in the rules, from a committed server seed when the table is provably fair.
+func (h *HandlerDependencies) CreateGlitchjackTable(c *gin.Context) {
+	table, ok := bindTableRules(c)
+	if !ok {
+		return
+	}
+	rules := table.rules
+	if table.deckType != models.Standard {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Glitchjack tables deal their own random decks",
+		})
+		return
+	}
+
+	game, err := h.GlitchjackService.CreateGlitchjackGameWithRules(rules, table.maxPlayers)
+	if err == nil {
+		game, err = h.applyGameOptions(game, table.options)
+	}
+	if err == nil && table.provablyFair {
+		game, err = h.FairnessService.MakeProvablyFair(game.ID)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	h.updateGamesCreatedMetric(c, models.Standard, rules.Decks)
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"deck_name":       game.Deck.Name,
+		"message":         "New Glitchjack table created",
+		"remaining_cards": game.Deck.RemainingCards(),
+		"max_players":     game.MaxPlayers,
+		"rules":           game.Rules(),
+		"fair":            game.ViewFor(models.Spectator).Fair,
+		"created":         game.Created,
+	})
+}
+
 // StartGlitchjackGame begins a Glitchjack game by dealing initial cards to all players.
 func (h *HandlerDependencies) StartGlitchjackGame(c *gin.Context) {
 	gameID := validators.SanitizeString(c.Param("gameId"), 50)
@@ -124,9 +188,12 @@ func (h *HandlerDependencies) StartGlitchjackGame(c *gin.Context) {
 		return
 	}
 	
+	// The opening deal is shown as the caller may see it, so the dealer's hole card stays hidden
+	viewer, _ := requestViewer(c, game)
+	view := game.ViewFor(viewer)
 	baseURL := config.GetBaseURL(c)
-	playersWithCards := convertPlayersWithImages(game.Players, baseURL)
-	dealerWithCards := convertDealerInfo(game.Dealer, baseURL)
+	playersWithCards := convertPlayersWithImages(view.Players, baseURL)
+	dealerWithCards := convertDealerInfo(view.Dealer, baseURL)
 	
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":         game.ID,
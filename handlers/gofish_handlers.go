@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/peteshima/cardgame-api/api"
+	"github.com/peteshima/cardgame-api/config"
+	"github.com/peteshima/cardgame-api/models"
+	"github.com/peteshima/cardgame-api/validators"
+)
+
+// CreateNewGoFishGame creates a new Go Fish game for up to 4 players.
+func (h *HandlerDependencies) CreateNewGoFishGame(c *gin.Context) {
+	h.createGoFishGame(c, 4)
+}
+
+// CreateNewGoFishGameWithPlayers creates a new Go Fish game for 2-6 players.
+func (h *HandlerDependencies) CreateNewGoFishGameWithPlayers(c *gin.Context) {
+	playersStr := validators.SanitizeString(c.Param("players"), 10)
+	maxPlayers, valid := validators.ValidateNumber(playersStr)
+	if !valid || maxPlayers < 2 || maxPlayers > 6 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid players parameter (must be 2-6)",
+		})
+		return
+	}
+
+	h.createGoFishGame(c, maxPlayers)
+}
+
+// createGoFishGame creates the game and writes the creation response.
+func (h *HandlerDependencies) createGoFishGame(c *gin.Context, maxPlayers int) {
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.GoFishService.CreateGoFishGame(maxPlayers), options)
+	if !ok {
+		return
+	}
+	h.updateGamesCreatedMetric(c, models.Standard, 1)
+
+	h.Logger.Info("Go Fish game created successfully",
+		zap.String("game_id", game.ID),
+		zap.Int("max_players", maxPlayers),
+		zap.String("client_ip", c.ClientIP()),
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"deck_name":       game.Deck.Name,
+		"message":         "New Go Fish game created",
+		"remaining_cards": game.Deck.RemainingCards(),
+		"max_players":     game.MaxPlayers,
+		"created":         game.Created,
+	})
+}
+
+// StartGoFishGame deals 7 cards each to 2-3 players or 5 cards each to 4-6 players.
+func (h *HandlerDependencies) StartGoFishGame(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.GoFishService.StartGoFishGame(gameID)
+	h.writeGoFishResponse(c, game, err, "Go Fish game started")
+}
+
+// GoFishAsk asks another player for all their cards of a rank the asking player holds.
+func (h *HandlerDependencies) GoFishAsk(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	playerID := validators.SanitizeString(c.Param("playerId"), 50)
+
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	if !validators.ValidatePlayerID(playerID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid player ID format",
+		})
+		return
+	}
+
+	var request api.GoFishAskRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	targetID := validators.SanitizeString(request.TargetID, 50)
+	if !validators.ValidatePlayerID(targetID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid target player ID format",
+		})
+		return
+	}
+
+	game, err := h.GoFishService.Ask(gameID, playerID, targetID, models.Rank(request.Rank))
+	message := "Go fish"
+	if err == nil && game != nil && game.GoFishState.LastTurn.Received > 0 {
+		message = "Cards handed over"
+	}
+	h.writeGoFishResponse(c, game, err, message)
+}
+
+// GoFishDraw draws a card for a player whose hand is empty or who has nobody left to ask.
+func (h *HandlerDependencies) GoFishDraw(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	playerID := validators.SanitizeString(c.Param("playerId"), 50)
+
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	if !validators.ValidatePlayerID(playerID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid player ID format",
+		})
+		return
+	}
+
+	game, err := h.GoFishService.Draw(gameID, playerID)
+	h.writeGoFishResponse(c, game, err, "Card drawn")
+}
+
+// GetGoFishState returns the table as the caller may see it: their own hand, everyone's hand
+// sizes and books, and the last turn.
+func (h *HandlerDependencies) GetGoFishState(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.GoFishService.GetGoFishGame(gameID)
+	if game != nil && err == nil {
+		if _, valid := requestViewer(c, game); !valid {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid player or admin token",
+			})
+			return
+		}
+	}
+	h.writeGoFishResponse(c, game, err, "")
+}
+
+// writeGoFishResponse writes the outcome of a go fish service call.
+func (h *HandlerDependencies) writeGoFishResponse(c *gin.Context, game *models.Game, err error, message string) {
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	viewer, _ := requestViewer(c, game)
+	response := goFishStateResponse(game, viewer, config.GetBaseURL(c))
+	if message != "" {
+		response["message"] = message
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// goFishStateResponse describes a Go Fish table from the viewer's point of view.
+func goFishStateResponse(game *models.Game, viewer models.Viewer, baseURL string) gin.H {
+	view := game.ViewFor(viewer)
+	state := view.GoFishState
+
+	players := make([]gin.H, 0, len(view.Players))
+	for i, player := range view.Players {
+		entry := gin.H{
+			"id":        player.ID,
+			"name":      player.Name,
+			"hand":      convertCardsWithImages(player.Hand, baseURL),
+			"hand_size": player.HandSize(),
+		}
+		if i < len(state.Seats) {
+			entry["books"] = state.Seats[i].Books
+		}
+		players = append(players, entry)
+	}
+
+	response := gin.H{
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"status":          game.Status.String(),
+		"phase":           state.Phase.String(),
+		"current_player":  game.CurrentPlayer,
+		"remaining_cards": game.Deck.RemainingCards(),
+		"players":         players,
+		"total_books":     state.BookCount(),
+		"last_turn":       state.LastTurn,
+		"viewer":          viewer,
+	}
+	if state.Phase == models.GoFishFinished {
+		response["winners"] = state.Winners
+	}
+	return response
+}
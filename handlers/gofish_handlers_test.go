@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoFishRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.GET("/game/new/gofish/:players", deps.CreateNewGoFishGameWithPlayers)
+	router.POST("/game/:gameId/gofish/start", deps.StartGoFishGame)
+	router.POST("/game/:gameId/gofish/ask/:playerId", deps.GoFishAsk)
+	router.POST("/game/:gameId/gofish/draw/:playerId", deps.GoFishDraw)
+	router.GET("/game/:gameId/gofish/state", deps.GetGoFishState)
+
+	request := func(method, path, body, token string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if token != "" {
+			req.Header.Set("X-Player-Token", token)
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, _ := request("GET", "/game/new/gofish/1", "", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	game := deps.GoFishService.CreateGoFishGame(4)
+	path := "/game/" + game.ID + "/gofish"
+	code, _ = request("GET", path+"/state", "", "")
+	assert.Equal(t, http.StatusBadRequest, code, "the game has not been started")
+
+	_, alice, aliceToken, _ := deps.GameService.JoinGame(game.ID, "Alice")
+	_, bob, _, _ := deps.GameService.JoinGame(game.ID, "Bob")
+	code, state := request("POST", path+"/start", "", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "playing", state["phase"])
+	assert.Equal(t, float64(38), state["remaining_cards"])
+
+	// Alice sees her own hand but only the size of Bob's
+	code, state = request("GET", path+"/state", "", aliceToken)
+	require.Equal(t, http.StatusOK, code)
+	players := state["players"].([]interface{})
+	aliceCard := players[0].(map[string]interface{})["hand"].([]interface{})[0].(map[string]interface{})
+	bobCard := players[1].(map[string]interface{})["hand"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(alice.Hand[0].Rank), aliceCard["rank"])
+	assert.Equal(t, float64(0), bobCard["rank"])
+	code, _ = request("GET", path+"/state", "", "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = request("POST", path+"/ask/"+alice.ID, `{"rank":1}`, "")
+	assert.Equal(t, http.StatusBadRequest, code, "the target is required")
+	code, _ = request("POST", path+"/ask/"+bob.ID, fmt.Sprintf(`{"target_id":%q,"rank":1}`, alice.ID), "")
+	assert.Equal(t, http.StatusBadRequest, code, "it is Alice's turn")
+	code, _ = request("POST", path+"/draw/"+alice.ID, "", "")
+	assert.Equal(t, http.StatusBadRequest, code, "Alice has someone to ask")
+
+	body := fmt.Sprintf(`{"target_id":%q,"rank":%d}`, bob.ID, alice.Hand[0].Rank)
+	code, state = request("POST", path+"/ask/"+alice.ID, body, aliceToken)
+	require.Equal(t, http.StatusOK, code)
+	turn := state["last_turn"].(map[string]interface{})
+	assert.Equal(t, bob.ID, turn["target_id"])
+	assert.Contains(t, []interface{}{"Go fish", "Cards handed over"}, state["message"])
+}
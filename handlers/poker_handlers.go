@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/peteshima/cardgame-api/api"
+	"github.com/peteshima/cardgame-api/config"
+	"github.com/peteshima/cardgame-api/models"
+	"github.com/peteshima/cardgame-api/validators"
+)
+
+// pokerActions maps the action path segment to the betting action it performs.
+var pokerActions = map[string]models.PokerAction{
+	"check":  models.PokerCheck,
+	"bet":    models.PokerBet,
+	"call":   models.PokerCall,
+	"raise":  models.PokerRaise,
+	"fold":   models.PokerFold,
+	"all-in": models.PokerAllIn,
+	"all_in": models.PokerAllIn,
+}
+
+// CreateNewPokerGame creates a new Texas Hold'em game for up to 6 players.
+func (h *HandlerDependencies) CreateNewPokerGame(c *gin.Context) {
+	h.createPokerGame(c, 6)
+}
+
+// CreateNewPokerGameWithPlayers creates a new Texas Hold'em game with the specified table size.
+func (h *HandlerDependencies) CreateNewPokerGameWithPlayers(c *gin.Context) {
+	playersStr := validators.SanitizeString(c.Param("players"), 10)
+	maxPlayers, valid := validators.ValidateNumber(playersStr)
+	if !valid || maxPlayers < 2 || maxPlayers > 10 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid players parameter (must be 2-10)",
+		})
+		return
+	}
+
+	h.createPokerGame(c, maxPlayers)
+}
+
+// createPokerGame creates the game and writes the creation response.
+func (h *HandlerDependencies) createPokerGame(c *gin.Context, maxPlayers int) {
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.PokerService.CreatePokerGame(maxPlayers), options)
+	if !ok {
+		return
+	}
+	h.updateGamesCreatedMetric(c, models.Standard, 1)
+
+	h.Logger.Info("Poker game created successfully",
+		zap.String("game_id", game.ID),
+		zap.Int("max_players", maxPlayers),
+		zap.String("client_ip", c.ClientIP()),
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"deck_name":       game.Deck.Name,
+		"message":         "New Texas Hold'em game created",
+		"remaining_cards": game.Deck.RemainingCards(),
+		"max_players":     game.MaxPlayers,
+		"created":         game.Created,
+	})
+}
+
+// StartPokerGame seats the players and deals the first hand. The stakes are optional;
+// any that are omitted use the defaults of 5/10 blinds and 1000 chips.
+func (h *HandlerDependencies) StartPokerGame(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	var request api.PokerStartRequest
+	if err := bindOptionalJSON(c, &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	stakes := models.DefaultPokerConfig()
+	if request.SmallBlind != 0 {
+		stakes.SmallBlind = request.SmallBlind
+	}
+	if request.BigBlind != 0 {
+		stakes.BigBlind = request.BigBlind
+	}
+	if request.StartingStack != 0 {
+		stakes.StartingStack = request.StartingStack
+	}
+
+	game, err := h.PokerService.StartPokerGame(gameID, stakes)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	viewer, _ := requestViewer(c, game)
+	response := pokerStateResponse(game, viewer, config.GetBaseURL(c))
+	response["message"] = "Texas Hold'em game started"
+	c.JSON(http.StatusOK, response)
+}
+
+// PokerAction applies a check, bet, call, raise, fold or all-in for a player.
+// Bets send the bet size and raises the total to raise to as "amount" in the body.
+func (h *HandlerDependencies) PokerAction(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	playerID := validators.SanitizeString(c.Param("playerId"), 50)
+
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	if !validators.ValidatePlayerID(playerID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid player ID format",
+		})
+		return
+	}
+
+	action, known := pokerActions[validators.SanitizeString(c.Param("action"), 10)]
+	if !known {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid poker action (must be check, bet, call, raise, fold or all-in)",
+		})
+		return
+	}
+
+	var request api.PokerActionRequest
+	if err := bindOptionalJSON(c, &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	game, err := h.PokerService.PlayerAction(gameID, playerID, action, request.Amount)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	viewer, _ := requestViewer(c, game)
+	response := pokerStateResponse(game, viewer, config.GetBaseURL(c))
+	response["action"] = action
+	response["message"] = "Action accepted"
+	if game.PokerState.Phase >= models.PokerShowdown {
+		response["message"] = "Hand complete"
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// NextPokerHand moves the button and deals the next hand after the previous one is settled.
+func (h *HandlerDependencies) NextPokerHand(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.PokerService.NextHand(gameID)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	viewer, _ := requestViewer(c, game)
+	response := pokerStateResponse(game, viewer, config.GetBaseURL(c))
+	response["message"] = "Next hand dealt"
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPokerState returns the table as the caller may see it: their own hole cards, the board,
+// stacks, bets, pots and, once a hand is settled, the showdown and results.
+func (h *HandlerDependencies) GetPokerState(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.PokerService.GetPokerGame(gameID)
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	viewer, valid := requestViewer(c, game)
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid player or admin token",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pokerStateResponse(game, viewer, config.GetBaseURL(c)))
+}
+
+// maxEvaluatedHands caps how many hands a single evaluation request may compare.
+const maxEvaluatedHands = 100
+
+// EvaluatePokerHands ranks poker hands of 5-7 cards, with optional shared board cards, and reports the winners.
+// It needs no game, so analytics clients can compare hands directly.
+func (h *HandlerDependencies) EvaluatePokerHands(c *gin.Context) {
+	var request api.PokerEvaluateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	if len(request.Hands) == 0 || len(request.Hands) > maxEvaluatedHands {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid hands (must be 1-100 hands)",
+		})
+		return
+	}
+
+	hands, winners, err := h.PokerService.EvaluateHands(request.Board, request.Hands)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hands":   hands,
+		"winners": winners,
+		"tie":     len(winners) > 1,
+	})
+}
+
+// pokerStateResponse describes a poker table from the viewer's point of view.
+func pokerStateResponse(game *models.Game, viewer models.Viewer, baseURL string) gin.H {
+	view := game.ViewFor(viewer)
+	state := view.PokerState
+
+	players := make([]gin.H, 0, len(view.Players))
+	for i, player := range view.Players {
+		entry := gin.H{
+			"id":        player.ID,
+			"name":      player.Name,
+			"hand":      convertCardsWithImages(player.Hand, baseURL),
+			"hand_size": player.HandSize(),
+		}
+		if i < len(state.Seats) {
+			seat := state.Seats[i]
+			entry["stack"] = seat.Stack
+			entry["bet"] = seat.Bet
+			entry["committed"] = seat.Committed
+			entry["folded"] = seat.Folded
+			entry["all_in"] = seat.AllIn
+			entry["last_action"] = seat.LastAction
+		}
+		players = append(players, entry)
+	}
+
+	response := gin.H{
+		"game_id":        game.ID,
+		"game_type":      game.GameType.String(),
+		"status":         game.Status.String(),
+		"phase":          state.Phase.String(),
+		"hand_number":    state.HandNumber,
+		"button":         state.Button,
+		"small_blind":    state.SmallBlind,
+		"big_blind":      state.BigBlind,
+		"community":      convertCardsWithImages(state.Community, baseURL),
+		"pot":            state.Pot,
+		"pots":           state.Pots,
+		"current_bet":    state.CurrentBet,
+		"min_raise":      state.MinRaise,
+		"current_player": game.CurrentPlayer,
+		"players":        players,
+		"viewer":         viewer,
+	}
+	if state.Phase < models.PokerShowdown {
+		response["to_call"] = state.ToCall(game.CurrentPlayer)
+	} else {
+		response["showdown"] = state.Showdown
+		response["results"] = state.Results
+	}
+	return response
+}
+
+// bindOptionalJSON binds a JSON body if one was sent, leaving the defaults in place otherwise.
+func bindOptionalJSON(c *gin.Context, obj interface{}) error {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return nil
+	}
+	if err := c.ShouldBindJSON(obj); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
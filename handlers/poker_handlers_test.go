@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPokerRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.GET("/game/new/poker/:players", deps.CreateNewPokerGameWithPlayers)
+	router.POST("/game/:gameId/poker/start", deps.StartPokerGame)
+	router.POST("/game/:gameId/poker/next-hand", deps.NextPokerHand)
+	router.GET("/game/:gameId/poker/state", deps.GetPokerState)
+	router.POST("/game/:gameId/poker/:action/:playerId", deps.PokerAction)
+
+	request := func(method, path, body, token string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if token != "" {
+			req.Header.Set("X-Player-Token", token)
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, _ := request("GET", "/game/new/poker/1", "", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	game := deps.PokerService.CreatePokerGame(6)
+	path := "/game/" + game.ID + "/poker"
+	code, _ = request("GET", path+"/state", "", "")
+	assert.Equal(t, http.StatusBadRequest, code, "no hand has been dealt yet")
+
+	_, alice, _, _ := deps.GameService.JoinGame(game.ID, "Alice")
+	_, bob, bobToken, _ := deps.GameService.JoinGame(game.ID, "Bob")
+
+	code, state := request("POST", path+"/start", `{"small_blind":1,"big_blind":2,"starting_stack":200}`, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "preflop", state["phase"])
+	assert.Equal(t, float64(3), state["pot"])
+	assert.Equal(t, float64(1), state["to_call"])
+
+	code, _ = request("POST", path+"/raise/"+alice.ID, `{"amount":3}`, "")
+	assert.Equal(t, http.StatusBadRequest, code, "a raise must be at least the big blind")
+	code, _ = request("POST", path+"/shove/"+alice.ID, "", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("POST", path+"/check/"+bob.ID, "", "")
+	assert.Equal(t, http.StatusBadRequest, code, "it is not Bob's turn")
+
+	code, state = request("POST", path+"/raise/"+alice.ID, `{"amount":6}`, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(8), state["pot"])
+	code, state = request("POST", path+"/call/"+bob.ID, "", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "flop", state["phase"])
+	assert.Len(t, state["community"], 3)
+
+	// Bob sees his own hole cards but not Alice's
+	code, state = request("GET", path+"/state", "", bobToken)
+	require.Equal(t, http.StatusOK, code)
+	players := state["players"].([]interface{})
+	aliceCard := players[0].(map[string]interface{})["hand"].([]interface{})[0].(map[string]interface{})
+	bobCard := players[1].(map[string]interface{})["hand"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(0), aliceCard["rank"])
+	assert.Equal(t, float64(bob.Hand[0].Rank), bobCard["rank"])
+
+	code, _ = request("POST", path+"/next-hand", "", "")
+	assert.Equal(t, http.StatusBadRequest, code, "the hand is still being played")
+
+	code, state = request("POST", path+"/fold/"+bob.ID, "", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "showdown", state["phase"])
+	assert.Equal(t, "Hand complete", state["message"])
+
+	code, state = request("POST", path+"/next-hand", "", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(2), state["hand_number"])
+}
+
+func TestEvaluatePokerHands(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.POST("/evaluate/poker", deps.EvaluatePokerHands)
+
+	evaluate := func(body string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/evaluate/poker", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	// Board K♣ 9♦ 4♠ 4♥ 2♣; A♥ Q♠ against 9♥ 3♠
+	code, response := evaluate(`{
+		"board": [{"rank":13,"suit":2},{"rank":9,"suit":1},{"rank":4,"suit":3},{"rank":4,"suit":0},{"rank":2,"suit":2}],
+		"hands": [[{"rank":1,"suit":0},{"rank":12,"suit":3}], [{"rank":9,"suit":0},{"rank":3,"suit":3}]]
+	}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{float64(1)}, response["winners"])
+	assert.Equal(t, false, response["tie"])
+	hands := response["hands"].([]interface{})
+	assert.Equal(t, "one_pair", hands[0].(map[string]interface{})["name"])
+	assert.Equal(t, "two_pair", hands[1].(map[string]interface{})["name"])
+	assert.Len(t, hands[1].(map[string]interface{})["cards"], 5)
+
+	code, _ = evaluate(`{"hands": []}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, response = evaluate(`{"hands": [[{"rank":1,"suit":0},{"rank":1,"suit":0},{"rank":2,"suit":0},{"rank":3,"suit":0},{"rank":4,"suit":0}]]}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, response["error"], "more than once")
+	code, _ = evaluate(`{"hands": [[{"rank":1,"suit":0},{"rank":2,"suit":0}]]}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
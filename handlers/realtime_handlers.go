@@ -0,0 +1,377 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/peteshima/cardgame-api/api"
+	"github.com/peteshima/cardgame-api/config"
+	"github.com/peteshima/cardgame-api/managers"
+	"github.com/peteshima/cardgame-api/models"
+	"github.com/peteshima/cardgame-api/validators"
+)
+
+const (
+	wsWriteTimeout   = 10 * time.Second
+	wsPongTimeout    = 60 * time.Second
+	wsPingInterval   = 30 * time.Second
+	wsMaxMessageSize = 4096
+)
+
+// sseHeartbeatInterval is how often an idle event stream sends a heartbeat to keep proxies from closing it.
+var sseHeartbeatInterval = 15 * time.Second
+
+// websocketUpgrader only accepts browser connections from the configured front-end origins.
+// Clients that send no Origin header (native apps, tests) are always allowed.
+var websocketUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, allowed := range config.GetAllowedOrigins() {
+			if strings.EqualFold(origin, allowed) {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+// GameWebSocket upgrades the connection and streams live updates for a game until it closes.
+// Clients receive the current state, redacted for their player token, on connect and may send
+// hit, stand and discard actions on the same socket.
+func (h *HandlerDependencies) GameWebSocket(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, exists := h.GameService.GetGame(gameID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	viewer, valid := requestViewer(c, game)
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid player or admin token",
+		})
+		return
+	}
+
+	conn, err := websocketUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written an error response
+		h.Logger.Warn("WebSocket upgrade failed",
+			zap.String("game_id", gameID),
+			zap.String("client_ip", c.ClientIP()),
+			zap.Error(err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	hub := h.GameManager.Hub()
+	sub := hub.Subscribe(game)
+	defer hub.Unsubscribe(sub)
+
+	h.Logger.Debug("WebSocket subscriber connected",
+		zap.String("game_id", gameID),
+		zap.Int("subscribers", hub.SubscriberCount(gameID)),
+		zap.String("client_ip", c.ClientIP()),
+	)
+
+	baseURL := config.GetBaseURL(c)
+	if !writeWebSocketJSON(conn, gin.H{"type": "connected", "game_id": gameID, "viewer": viewer, "state": gameStateResponse(game.ViewFor(viewer), baseURL)}) {
+		return
+	}
+
+	// Reads happen on their own goroutine; all writes stay on this one as gorilla requires
+	results := make(chan gin.H, 16)
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+	go h.readGameActions(conn, gameID, results, done, stop)
+
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case update, ok := <-sub.Updates():
+			if !ok {
+				conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "subscription ended"))
+				return
+			}
+			if !writeWebSocketJSON(conn, update) {
+				return
+			}
+		case result := <-results:
+			if !writeWebSocketJSON(conn, result) {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-done:
+			h.Logger.Debug("WebSocket subscriber disconnected",
+				zap.String("game_id", gameID),
+				zap.String("client_ip", c.ClientIP()),
+			)
+			return
+		}
+	}
+}
+
+// readGameActions decodes actions sent by a WebSocket client and applies them to the game.
+// The outcome of each action is handed back to the writer; done is closed when the client goes away
+// and stop is closed by the writer when it gives up on the connection.
+func (h *HandlerDependencies) readGameActions(conn *websocket.Conn, gameID string, results chan<- gin.H, done chan<- struct{}, stop <-chan struct{}) {
+	defer close(done)
+
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		var req api.GameActionRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			// A malformed message is reported back; anything else means the connection is gone
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				result := gin.H{"type": "action_result", "success": false, "error": "Invalid action format"}
+				select {
+				case results <- result:
+				case <-stop:
+					return
+				}
+				continue
+			}
+			return
+		}
+
+		result := gin.H{"type": "action_result", "action": req.Action, "player_id": req.PlayerID, "success": true}
+		if message := h.applyGameAction(gameID, req); message != "" {
+			result["success"] = false
+			result["error"] = message
+		}
+		select {
+		case results <- result:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// applyGameAction runs a hit, stand or discard through the matching game service.
+// It returns an empty string on success or a client-facing error message.
+func (h *HandlerDependencies) applyGameAction(gameID string, req api.GameActionRequest) string {
+	playerID := validators.SanitizeString(req.PlayerID, 50)
+	if !validators.ValidatePlayerID(playerID) {
+		return "Invalid player ID format"
+	}
+
+	game, exists := h.GameService.GetGame(gameID)
+	if !exists {
+		return "Game not found"
+	}
+
+	switch req.Action {
+	case "hit", "stand":
+		if game.GameType == models.Glitchjack {
+			var success bool
+			var message string
+			if req.Action == "hit" {
+				_, _, success, message = h.GlitchjackService.PlayerHit(gameID, playerID)
+			} else {
+				_, _, success, message = h.GlitchjackService.PlayerStand(gameID, playerID)
+			}
+			if !success {
+				return message
+			}
+			return ""
+		}
+
+		var err error
+		if req.Action == "hit" {
+			_, _, err = h.BlackjackService.PlayerHit(gameID, playerID)
+		} else {
+			_, _, err = h.BlackjackService.PlayerStand(gameID, playerID)
+		}
+		if err != nil {
+			return err.Error()
+		}
+		return ""
+	case "split", "double", "insurance", "surrender":
+		actions := map[string]func(string, string) (*models.Game, *models.Player, error){
+			"split":     h.BlackjackService.PlayerSplit,
+			"double":    h.BlackjackService.PlayerDouble,
+			"insurance": h.BlackjackService.PlayerInsure,
+			"surrender": h.BlackjackService.PlayerSurrender,
+		}
+		if _, _, err := actions[req.Action](gameID, playerID); err != nil {
+			return err.Error()
+		}
+		return ""
+	case "discard":
+		if game.GameType == models.Cribbage {
+			if _, _, err := h.CribbageService.CribbageDiscard(gameID, playerID, req.CardIndices); err != nil {
+				return err.Error()
+			}
+			return ""
+		}
+
+		pileID := req.PileID
+		if pileID == "" {
+			pileID = "main"
+		}
+		if !validators.ValidatePileID(pileID) {
+			return "Invalid pile ID format"
+		}
+		if _, _, _, _, success := h.GameService.DiscardCard(gameID, pileID, playerID, req.CardIndex); !success {
+			return "Failed to discard card"
+		}
+		return ""
+	default:
+		return "Unknown action"
+	}
+}
+
+// writeWebSocketJSON sends a JSON message with a write deadline, reporting whether it succeeded.
+func writeWebSocketJSON(conn *websocket.Conn, message interface{}) bool {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return conn.WriteJSON(message) == nil
+}
+
+// GameEventStream streams live updates for a game as Server-Sent Events until it closes.
+// Each event carries the hub's update ID, so a reconnecting client that sends Last-Event-ID
+// receives the updates it missed; otherwise the stream begins with a snapshot of the current state.
+func (h *HandlerDependencies) GameEventStream(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, exists := h.GameService.GetGame(gameID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	viewer, valid := requestViewer(c, game)
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid player or admin token",
+		})
+		return
+	}
+
+	// EventSource sends Last-Event-ID on reconnect; the query form helps clients that cannot set headers
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	hub := h.GameManager.Hub()
+	var sub *managers.Subscription
+	var missed []managers.GameUpdate
+	resumed := false
+	if lastEventID != "" {
+		if lastID, err := strconv.ParseUint(validators.SanitizeString(lastEventID, 20), 10, 64); err == nil {
+			sub, missed, resumed = hub.Resume(game, lastID)
+		}
+	}
+	if sub == nil {
+		sub = hub.Subscribe(game)
+	}
+	defer hub.Unsubscribe(sub)
+
+	h.Logger.Debug("Event stream subscriber connected",
+		zap.String("game_id", gameID),
+		zap.Bool("resumed", resumed),
+		zap.Int("missed_updates", len(missed)),
+		zap.String("client_ip", c.ClientIP()),
+	)
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Stop nginx from buffering the stream
+	c.Status(http.StatusOK)
+
+	if resumed {
+		for _, update := range missed {
+			writeGameUpdateEvent(c, update)
+		}
+	} else {
+		c.Render(-1, sse.Event{
+			Event: "snapshot",
+			Id:    strconv.FormatUint(sub.LastID, 10),
+			Data:  gameStateResponse(game.ViewFor(viewer), config.GetBaseURL(c)),
+		})
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case update, ok := <-sub.Updates():
+			if !ok {
+				return
+			}
+			writeGameUpdateEvent(c, update)
+			c.Writer.Flush()
+		case now := <-heartbeat.C:
+			c.Render(-1, sse.Event{
+				Event: "heartbeat",
+				Data:  gin.H{"time": now.UTC()},
+			})
+			c.Writer.Flush()
+		case <-c.Request.Context().Done():
+			h.Logger.Debug("Event stream subscriber disconnected",
+				zap.String("game_id", gameID),
+				zap.String("client_ip", c.ClientIP()),
+			)
+			return
+		}
+	}
+}
+
+// writeGameUpdateEvent encodes a hub update as a Server-Sent Event named after its type.
+func writeGameUpdateEvent(c *gin.Context, update managers.GameUpdate) {
+	c.Render(-1, sse.Event{
+		Event: string(update.Type),
+		Id:    strconv.FormatUint(update.ID, 10),
+		Data:  update,
+	})
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peteshima/cardgame-api/api"
+)
+
+// dialGameWebSocket serves the WebSocket route on a test server and connects to it.
+func dialGameWebSocket(t *testing.T, deps *HandlerDependencies, gameID string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	router := gin.New()
+	router.GET("/game/:gameId/ws", deps.GameWebSocket)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/game/" + gameID + "/ws"
+	return websocket.DefaultDialer.Dial(url, header)
+}
+
+// readUntil reads messages until one of the given type arrives.
+func readUntil(t *testing.T, conn *websocket.Conn, messageType string) map[string]interface{} {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var message map[string]interface{}
+		require.NoError(t, conn.ReadJSON(&message))
+		if message["type"] == messageType {
+			return message
+		}
+	}
+}
+
+func TestGameWebSocket(t *testing.T) {
+	deps := setupTestHandler()
+	game := deps.GameService.CreateGame(1)
+	_, alice, _ := deps.GameService.AddPlayerToGame(game.ID, "Alice")
+	_, err := deps.BlackjackService.StartBlackjackGame(game.ID)
+	require.NoError(t, err)
+
+	conn, _, err := dialGameWebSocket(t, deps, game.ID, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	connected := readUntil(t, conn, "connected")
+	assert.Equal(t, game.ID, connected["game_id"])
+	assert.Equal(t, "in_progress", connected["state"].(map[string]interface{})["status"])
+
+	// Changes made through the services are pushed to the socket
+	_, _, _ = deps.GameService.AddPlayerToGame(game.ID, "Bob")
+	joined := readUntil(t, conn, "player_joined")
+	assert.Equal(t, "Bob", joined["data"].(map[string]interface{})["name"])
+
+	// Actions sent over the socket are applied and acknowledged
+	require.NoError(t, conn.WriteJSON(api.GameActionRequest{Action: "hit", PlayerID: alice.ID}))
+	result := readUntil(t, conn, "action_result")
+	assert.Equal(t, true, result["success"])
+
+	require.NoError(t, conn.WriteJSON(api.GameActionRequest{Action: "fold", PlayerID: alice.ID}))
+	result = readUntil(t, conn, "action_result")
+	assert.Equal(t, false, result["success"])
+	assert.Equal(t, "Unknown action", result["error"])
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("not json")))
+	result = readUntil(t, conn, "action_result")
+	assert.Equal(t, "Invalid action format", result["error"])
+
+	// Deleting the game notifies subscribers and closes the socket
+	assert.True(t, deps.GameService.DeleteGame(game.ID))
+	closed := readUntil(t, conn, "game_closed")
+	assert.Equal(t, "deleted", closed["data"].(map[string]interface{})["reason"])
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+}
+
+func TestGameWebSocketRejectsBadRequests(t *testing.T) {
+	deps := setupTestHandler()
+
+	_, resp, err := dialGameWebSocket(t, deps, "invalid-id", nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	_, resp, err = dialGameWebSocket(t, deps, "550e8400-e29b-41d4-a716-446655440000", nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	game := deps.GameService.CreateGame(1)
+	_, resp, err = dialGameWebSocket(t, deps, game.ID, http.Header{"Origin": []string{"http://evil.example"}})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+// streamEvent is one parsed Server-Sent Event.
+type streamEvent struct {
+	id    string
+	event string
+	data  map[string]interface{}
+}
+
+// openGameStream connects to the SSE route on a test server and returns a reader for its events.
+func openGameStream(t *testing.T, deps *HandlerDependencies, gameID string, lastEventID string) (*http.Response, func() streamEvent) {
+	router := gin.New()
+	router.GET("/game/:gameId/stream", deps.GameEventStream)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequest("GET", server.URL+"/game/"+gameID+"/stream", nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	reader := bufio.NewReader(resp.Body)
+	next := func() streamEvent {
+		event := streamEvent{}
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case line == "":
+				return event
+			case strings.HasPrefix(line, "id:"):
+				event.id = strings.TrimPrefix(line, "id:")
+			case strings.HasPrefix(line, "event:"):
+				event.event = strings.TrimPrefix(line, "event:")
+			case strings.HasPrefix(line, "data:"):
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &event.data))
+			}
+		}
+	}
+	return resp, next
+}
+
+func TestGameEventStream(t *testing.T) {
+	deps := setupTestHandler()
+	game := deps.GameService.CreateGame(1)
+
+	resp, next := openGameStream(t, deps, game.ID, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+	snapshot := next()
+	assert.Equal(t, "snapshot", snapshot.event)
+	assert.Equal(t, "0", snapshot.id)
+	assert.Equal(t, game.ID, snapshot.data["game_id"])
+
+	deps.GameService.AddPlayerToGame(game.ID, "Alice")
+	joined := next()
+	assert.Equal(t, "player_joined", joined.event)
+	assert.Equal(t, "1", joined.id)
+	resp.Body.Close()
+
+	// Changes made while disconnected are replayed after Last-Event-ID
+	deps.GameService.AddPlayerToGame(game.ID, "Bob")
+	deps.GameService.ShuffleGameDeck(game.ID)
+
+	_, next = openGameStream(t, deps, game.ID, joined.id)
+	missed := next()
+	assert.Equal(t, "player_joined", missed.event)
+	assert.Equal(t, "2", missed.id)
+	assert.Equal(t, "Bob", missed.data["data"].(map[string]interface{})["name"])
+	missed = next()
+	assert.Equal(t, "game_action", missed.event)
+	assert.Equal(t, "3", missed.id)
+
+	// Deleting the game ends the stream after a final event
+	deps.GameService.DeleteGame(game.ID)
+	closed := next()
+	assert.Equal(t, "game_closed", closed.event)
+	assert.Equal(t, "4", closed.id)
+}
+
+func TestGameEventStreamHeartbeatAndFallback(t *testing.T) {
+	deps := setupTestHandler()
+	game := deps.GameService.CreateGame(1)
+
+	interval := sseHeartbeatInterval
+	sseHeartbeatInterval = 20 * time.Millisecond
+	defer func() { sseHeartbeatInterval = interval }()
+
+	// An unknown Last-Event-ID falls back to a fresh snapshot
+	_, next := openGameStream(t, deps, game.ID, "12345")
+	assert.Equal(t, "snapshot", next().event)
+	assert.Equal(t, "heartbeat", next().event)
+
+	resp, _ := openGameStream(t, deps, "550e8400-e29b-41d4-a716-446655440000", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, _ = openGameStream(t, deps, "invalid-id", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
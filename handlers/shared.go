@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,10 +12,12 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
+	"github.com/peteshima/cardgame-api/config"
 	"github.com/peteshima/cardgame-api/managers"
 	"github.com/peteshima/cardgame-api/middleware"
 	"github.com/peteshima/cardgame-api/models"
 	"github.com/peteshima/cardgame-api/services"
+	"github.com/peteshima/cardgame-api/validators"
 )
 
 // HandlerDependencies contains all the dependencies needed by handlers
@@ -22,8 +26,13 @@ type HandlerDependencies struct {
 	MetricsRegistry     *middleware.MetricsRegistry
 	GameService         *services.GameService
 	BlackjackService    *services.BlackjackService
+	BankrollService     *services.BankrollService
+	FairnessService     *services.FairnessService
 	CribbageService     *services.CribbageService
 	GlitchjackService   *services.GlitchjackService
+	PokerService        *services.PokerService
+	WarService          *services.WarService
+	GoFishService       *services.GoFishService
 	CustomDeckService   *services.CustomDeckService
 	GameManager         *managers.GameManager
 	CustomDeckManager   *managers.CustomDeckManager
@@ -43,8 +52,13 @@ func NewHandlerDependencies(
 		MetricsRegistry:     metricsRegistry,
 		GameService:         services.NewGameService(gameManager),
 		BlackjackService:    services.NewBlackjackService(gameManager),
+		BankrollService:     services.NewBankrollService(gameManager),
+		FairnessService:     services.NewFairnessService(gameManager),
 		CribbageService:     services.NewCribbageService(gameManager),
 		GlitchjackService:   services.NewGlitchjackService(gameManager),
+		PokerService:        services.NewPokerService(gameManager),
+		WarService:          services.NewWarService(gameManager),
+		GoFishService:       services.NewGoFishService(gameManager),
 		CustomDeckService:   services.NewCustomDeckService(customDeckManager),
 		GameManager:         gameManager,
 		CustomDeckManager:   customDeckManager,
@@ -214,4 +228,135 @@ func convertDiscardPiles(discardPiles map[string]*models.DiscardPile) []gin.H {
 		})
 	}
 	return discardInfo
-}
\ No newline at end of file
+}
+
+// gameStateResponse builds the full game state payload shared by live and replayed state requests.
+func gameStateResponse(game *models.Game, baseURL string) gin.H {
+	state := gin.H{
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"status":          game.Status.String(),
+		"current_player":  game.CurrentPlayer,
+		"deck_name":       game.Deck.Name,
+		"deck_type":       game.Deck.DeckType.String(),
+		"remaining_cards": game.Deck.RemainingCards(),
+		"max_players":     game.MaxPlayers,
+		"current_players": len(game.Players),
+		"players":         convertPlayersWithImages(game.Players, baseURL),
+		"dealer":          convertDealerInfo(game.Dealer, baseURL),
+		"discard_piles":   convertDiscardPiles(game.DiscardPiles),
+		"event_count":     len(game.Events),
+		"created":         game.Created,
+		"last_used":       game.LastUsed,
+	}
+	if game.GameType == models.Blackjack || game.GameType == models.Glitchjack {
+		state["blackjack_rules"] = game.Rules()
+		state["round"] = game.Round
+		state["shoe_penetration"] = game.ShoePenetration()
+		state["shoe"] = game.ShoeStatus()
+	}
+	return state
+}
+
+// requestViewer works out who a request is answered for from an admin API key, or its X-Admin-Token
+// or X-Player-Token header; ?token= is accepted for WebSocket and EventSource clients that cannot set headers.
+// Requests without a token are spectators. It returns false when a token matches nobody.
+func requestViewer(c *gin.Context, game *models.Game) (models.Viewer, bool) {
+	if key, authenticated := middleware.APIKeyFromContext(c); authenticated && key.Role == middleware.RoleAdmin {
+		return models.Viewer{Admin: true}, true
+	}
+
+	if adminToken := c.GetHeader("X-Admin-Token"); adminToken != "" {
+		expected := config.GetAdminToken()
+		if expected != "" && subtle.ConstantTimeCompare([]byte(adminToken), []byte(expected)) == 1 {
+			return models.Viewer{Admin: true}, true
+		}
+		return models.Spectator, false
+	}
+
+	token := c.GetHeader("X-Player-Token")
+	if token == "" {
+		token = c.Query("token")
+	}
+	if token == "" {
+		return models.Spectator, true
+	}
+
+	player := game.PlayerByToken(validators.SanitizeString(token, 128))
+	if player == nil {
+		return models.Spectator, false
+	}
+	return models.Viewer{PlayerID: player.ID}, true
+}
+
+// gameOptions are the optional query parameters every game creation endpoint takes.
+type gameOptions struct {
+	seed    *uint64
+	shuffle *models.ShuffleMode
+}
+
+// queryGameOptions reads the optional seed that makes a new game deterministic and the shuffle mode its
+// deck is shuffled with. It writes a 400 response and returns false when either is invalid.
+func queryGameOptions(c *gin.Context) (gameOptions, bool) {
+	var options gameOptions
+	if raw := validators.SanitizeString(c.Query("seed"), 20); raw != "" {
+		seed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || seed > models.MaxSeed {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid seed parameter (must be 0-" + strconv.FormatUint(models.MaxSeed, 10) + ")",
+			})
+			return gameOptions{}, false
+		}
+		options.seed = &seed
+	}
+
+	shuffle, ok := queryShuffleMode(c, "shuffle")
+	if !ok {
+		return gameOptions{}, false
+	}
+	options.shuffle = shuffle
+	return options, true
+}
+
+// queryShuffleMode reads an optional shuffle mode, such as "riffle:3", from the named query parameter.
+// It writes a 400 response and returns false when the mode is invalid.
+func queryShuffleMode(c *gin.Context, param string) (*models.ShuffleMode, bool) {
+	raw := validators.SanitizeString(c.Query(param), 200)
+	if raw == "" {
+		return nil, true
+	}
+	mode, err := models.ParseShuffleMode(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid " + param + " parameter: " + err.Error(),
+		})
+		return nil, false
+	}
+	return &mode, true
+}
+
+// applyGameOptions seeds a game that was just created and then sets its shuffle mode, so a seeded
+// game's first shuffle is drawn from the seed too.
+func (h *HandlerDependencies) applyGameOptions(game *models.Game, options gameOptions) (*models.Game, error) {
+	var err error
+	if options.seed != nil {
+		game, err = h.GameService.SeedGame(game.ID, *options.seed)
+	}
+	if err == nil && options.shuffle != nil {
+		game, err = h.GameService.SetShuffleMode(game.ID, *options.shuffle)
+	}
+	return game, err
+}
+
+// setupGame applies the options read by queryGameOptions to a game that was just created.
+// It writes a 400 response and returns false when they cannot be applied.
+func (h *HandlerDependencies) setupGame(c *gin.Context, game *models.Game, options gameOptions) (*models.Game, bool) {
+	game, err := h.applyGameOptions(game, options)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+	return game, true
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/peteshima/cardgame-api/api"
+	"github.com/peteshima/cardgame-api/config"
+	"github.com/peteshima/cardgame-api/models"
+	"github.com/peteshima/cardgame-api/validators"
+)
+
+// CreateNewWarGame creates a new two-player game of War.
+func (h *HandlerDependencies) CreateNewWarGame(c *gin.Context) {
+	h.createWarGame(c, 2)
+}
+
+// CreateNewWarGameWithPlayers creates a new game of War for 2-6 players.
+func (h *HandlerDependencies) CreateNewWarGameWithPlayers(c *gin.Context) {
+	playersStr := validators.SanitizeString(c.Param("players"), 10)
+	maxPlayers, valid := validators.ValidateNumber(playersStr)
+	if !valid || maxPlayers < 2 || maxPlayers > 6 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid players parameter (must be 2-6)",
+		})
+		return
+	}
+
+	h.createWarGame(c, maxPlayers)
+}
+
+// createWarGame creates the game and writes the creation response.
+func (h *HandlerDependencies) createWarGame(c *gin.Context, maxPlayers int) {
+	options, ok := queryGameOptions(c)
+	if !ok {
+		return
+	}
+	game, ok := h.setupGame(c, h.WarService.CreateWarGame(maxPlayers), options)
+	if !ok {
+		return
+	}
+	h.updateGamesCreatedMetric(c, models.Standard, 1)
+
+	h.Logger.Info("War game created successfully",
+		zap.String("game_id", game.ID),
+		zap.Int("max_players", maxPlayers),
+		zap.String("client_ip", c.ClientIP()),
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":         game.ID,
+		"game_type":       game.GameType.String(),
+		"deck_name":       game.Deck.Name,
+		"message":         "New War game created",
+		"remaining_cards": game.Deck.RemainingCards(),
+		"max_players":     game.MaxPlayers,
+		"created":         game.Created,
+	})
+}
+
+// StartWarGame deals the deck out face down between the players. The optional "max_rounds"
+// body field caps the game length; it defaults to 1000 battles.
+func (h *HandlerDependencies) StartWarGame(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	var request api.WarStartRequest
+	if err := bindOptionalJSON(c, &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	game, err := h.WarService.StartWarGame(gameID, request.MaxRounds)
+	h.writeWarResponse(c, game, err, "War game started")
+}
+
+// WarFlip has every player flip their top card and settles the battle, going to war on ties.
+func (h *HandlerDependencies) WarFlip(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.WarService.Flip(gameID)
+	h.writeWarResponse(c, game, err, "Battle played")
+}
+
+// WarAutoPlay plays the rest of the game in one call, mainly for demos.
+func (h *HandlerDependencies) WarAutoPlay(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.WarService.AutoPlay(gameID)
+	h.writeWarResponse(c, game, err, "Game played to the end")
+}
+
+// GetWarState returns the card counts, the last battle and, once the game is over, the winner.
+func (h *HandlerDependencies) GetWarState(c *gin.Context) {
+	gameID := validators.SanitizeString(c.Param("gameId"), 50)
+	if !validators.ValidateUUID(gameID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game ID format",
+		})
+		return
+	}
+
+	game, err := h.WarService.GetWarGame(gameID)
+	if game != nil && err == nil {
+		if _, valid := requestViewer(c, game); !valid {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid player or admin token",
+			})
+			return
+		}
+	}
+	h.writeWarResponse(c, game, err, "")
+}
+
+// writeWarResponse writes the outcome of a war service call.
+func (h *HandlerDependencies) writeWarResponse(c *gin.Context, game *models.Game, err error, message string) {
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	viewer, _ := requestViewer(c, game)
+	response := warStateResponse(game, viewer, config.GetBaseURL(c))
+	if message != "" {
+		response["message"] = message
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// warStateResponse describes a game of War from the viewer's point of view.
+// Stocks and won piles are face down, so players are shown by their card counts.
+func warStateResponse(game *models.Game, viewer models.Viewer, baseURL string) gin.H {
+	view := game.ViewFor(viewer)
+	state := view.WarState
+
+	players := make([]gin.H, 0, len(view.Players))
+	for i, player := range view.Players {
+		entry := gin.H{
+			"id":    player.ID,
+			"name":  player.Name,
+			"cards": game.WarCardCount(i),
+			"stock": len(player.Hand),
+		}
+		if i < len(state.Seats) {
+			entry["won_pile"] = len(state.Seats[i].WonPile)
+			entry["out"] = state.Seats[i].Out
+		}
+		players = append(players, entry)
+	}
+
+	response := gin.H{
+		"game_id":    game.ID,
+		"game_type":  game.GameType.String(),
+		"status":     game.Status.String(),
+		"phase":      state.Phase.String(),
+		"round":      state.Round,
+		"max_rounds": state.MaxRounds,
+		"players":    players,
+		"viewer":     viewer,
+	}
+	if battle := state.LastBattle; battle != nil {
+		plays := make([]gin.H, 0, len(battle.Plays))
+		for _, play := range battle.Plays {
+			plays = append(plays, gin.H{
+				"player_id": play.PlayerID,
+				"cards":     convertCardsWithImages(play.Cards, baseURL),
+			})
+		}
+		response["last_battle"] = gin.H{
+			"round":  battle.Round,
+			"plays":  plays,
+			"wars":   battle.Wars,
+			"winner": battle.Winner,
+			"cards":  battle.Cards,
+		}
+	}
+	if state.Phase == models.WarFinished {
+		response["winner"] = state.Winner
+		response["draw"] = state.Winner == ""
+	}
+	return response
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarRoutes(t *testing.T) {
+	deps := setupTestHandler()
+	router := gin.New()
+	router.GET("/game/new/war/:players", deps.CreateNewWarGameWithPlayers)
+	router.POST("/game/:gameId/war/start", deps.StartWarGame)
+	router.POST("/game/:gameId/war/flip", deps.WarFlip)
+	router.POST("/game/:gameId/war/auto-play", deps.WarAutoPlay)
+	router.GET("/game/:gameId/war/state", deps.GetWarState)
+
+	request := func(method, path, body string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	code, _ := request("GET", "/game/new/war/7", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("POST", "/game/not-a-uuid/war/flip", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	game := deps.WarService.CreateWarGame(2)
+	path := "/game/" + game.ID + "/war"
+	code, _ = request("POST", path+"/flip", "")
+	assert.Equal(t, http.StatusBadRequest, code, "the game has not been started")
+
+	deps.GameService.JoinGame(game.ID, "Alice")
+	deps.GameService.JoinGame(game.ID, "Bob")
+	code, state := request("POST", path+"/start", `{"max_rounds":300}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "playing", state["phase"])
+	assert.Equal(t, float64(300), state["max_rounds"])
+	players := state["players"].([]interface{})
+	assert.Equal(t, float64(26), players[0].(map[string]interface{})["cards"])
+
+	code, state = request("POST", path+"/flip", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(1), state["round"])
+	assert.NotNil(t, state["last_battle"])
+
+	code, state = request("POST", path+"/auto-play", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "finished", state["phase"])
+	assert.Contains(t, state, "winner")
+	assert.LessOrEqual(t, state["round"], float64(300))
+
+	code, _ = request("POST", path+"/flip", "")
+	assert.Equal(t, http.StatusBadRequest, code, "the game is over")
+	code, state = request("GET", path+"/state", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "finished", state["phase"])
+}
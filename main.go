@@ -45,11 +45,62 @@ func main() {
 	// Initialize metrics
 	_, metricsRegistry := config.InitMetrics(logger)
 
+	// Initialize persistence
+	storeBackend := config.GetStoreBackend()
+	store, err := managers.OpenStore(storeBackend, config.GetStorePath())
+	if err != nil {
+		logger.Fatal("Failed to open store", zap.String("backend", storeBackend), zap.Error(err))
+	}
+	defer store.Close()
+
 	// Initialize managers
-	gameManager := managers.NewGameManager()
-	customDeckManager := managers.NewCustomDeckManager()
+	gameManager, err := managers.NewGameManagerWithStore(store)
+	if err != nil {
+		logger.Fatal("Failed to load games from store", zap.Error(err))
+	}
+	customDeckManager, err := managers.NewCustomDeckManagerWithStore(store)
+	if err != nil {
+		logger.Fatal("Failed to load custom decks from store", zap.Error(err))
+	}
 
-	logger.Info("Managers initialized successfully")
+	storeErrorHandler := func(err error) {
+		logger.Error("Failed to persist state", zap.String("backend", storeBackend), zap.Error(err))
+	}
+	gameManager.SetStoreErrorHandler(storeErrorHandler)
+	customDeckManager.SetStoreErrorHandler(storeErrorHandler)
+
+	logger.Info("Managers initialized successfully",
+		zap.String("store_backend", storeBackend),
+		zap.Int("games_loaded", gameManager.GameCount()),
+		zap.Int("custom_decks_loaded", len(customDeckManager.ListDecks())),
+	)
+
+	// Expire idle games in the background; this also closes their live subscriptions
+	gameMaxAge := config.GetGameMaxAge()
+	cleanupInterval := config.GetGameCleanupInterval()
+	go func() {
+		ticker := time.NewTicker(cleanupInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if deleted := gameManager.CleanupOldGames(gameMaxAge); deleted > 0 {
+				logger.Info("Expired idle games",
+					zap.Int("deleted", deleted),
+					zap.Duration("max_age", gameMaxAge),
+				)
+			}
+		}
+	}()
+
+	// Load API keys; with none configured the API stays open for local development
+	apiKeys, err := config.LoadAPIKeys()
+	if err != nil {
+		logger.Fatal("Failed to load API keys", zap.Error(err))
+	}
+	if apiKeys.Enabled() {
+		logger.Info("API key authentication enabled", zap.Int("keys", apiKeys.Len()))
+	} else {
+		logger.Warn("No API keys configured, authentication is disabled")
+	}
 
 	// Create handler dependencies
 	deps := handlers.NewHandlerDependencies(
@@ -66,12 +117,13 @@ func main() {
 	// Add custom middleware
 	r.Use(middleware.LogMiddleware(logger, metricsRegistry))
 	r.Use(gin.Recovery())
+	r.Use(middleware.APIKeyMiddleware(apiKeys))
 	
 	// Configure CORS middleware
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3001", "http://glitchjack.com"},
+		AllowOrigins:     config.GetAllowedOrigins(),
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", "X-Player-Token", "X-Admin-Token", "Last-Event-ID"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
@@ -118,56 +170,117 @@ func main() {
 		})
 	})
 
+	// Route groups declare the least-privileged API key role that may call them.
+	// Players read state and play their own hands, table hosts run tables and admins manage every game.
+	player := r.Group("", middleware.RequireRole(apiKeys, middleware.RolePlayer))
+	host := r.Group("", middleware.RequireRole(apiKeys, middleware.RoleTableHost))
+	admin := r.Group("", middleware.RequireRole(apiKeys, middleware.RoleAdmin))
+
 	// Game management routes
 	r.GET("/deck-types", deps.ListDeckTypes)
-	r.GET("/game/new", deps.CreateNewGame)
-	r.GET("/game/new/:decks", deps.CreateNewGameWithDecks)
-	r.GET("/game/new/:decks/:type", deps.CreateNewGameWithType)
-	r.GET("/game/new/:decks/:type/:players", deps.CreateNewGameWithPlayers)
-	r.GET("/game/:gameId/shuffle", deps.ShuffleDeck)
-	r.GET("/game/:gameId", deps.GetGameInfo)
-	r.GET("/game/:gameId/state", deps.GetGameState)
-	r.POST("/game/:gameId/players", deps.AddPlayer)
-	r.DELETE("/game/:gameId/players/:playerId", deps.RemovePlayer)
-	r.GET("/games", deps.ListGames)
-	r.DELETE("/game/:gameId", deps.DeleteGame)
+	host.GET("/game/new", deps.CreateNewGame)
+	host.GET("/game/new/:decks", deps.CreateNewGameWithDecks)
+	host.GET("/game/new/:decks/:type", deps.CreateNewGameWithType)
+	host.GET("/game/new/:decks/:type/:players", deps.CreateNewGameWithPlayers)
+	host.GET("/game/:gameId/shuffle", deps.ShuffleDeck)
+	player.GET("/game/:gameId", deps.GetGameInfo)
+	player.GET("/game/:gameId/state", deps.GetGameState)
+	player.GET("/game/:gameId/events", deps.GetGameEvents)
+	player.GET("/game/:gameId/ws", deps.GameWebSocket)
+	player.GET("/game/:gameId/stream", deps.GameEventStream)
+	player.POST("/game/:gameId/players", deps.AddPlayer)
+	host.DELETE("/game/:gameId/players/:playerId", deps.RemovePlayer)
+	admin.GET("/games", deps.ListGames)
+	admin.DELETE("/game/:gameId", deps.DeleteGame)
 
 	// Card dealing routes
-	r.GET("/game/:gameId/deal", deps.DealCard)
-	r.GET("/game/:gameId/deal/:count", deps.DealCards)
-	r.GET("/game/:gameId/deal/player/:playerId", deps.DealToPlayer)
-	r.GET("/game/:gameId/deal/player/:playerId/:faceUp", deps.DealToPlayerFaceUp)
-	r.POST("/game/:gameId/discard/:pileId", deps.DiscardToCard)
+	host.GET("/game/:gameId/deal", deps.DealCard)
+	host.GET("/game/:gameId/deal/:count", deps.DealCards)
+	host.GET("/game/:gameId/deal/player/:playerId", deps.DealToPlayer)
+	host.GET("/game/:gameId/deal/player/:playerId/:faceUp", deps.DealToPlayerFaceUp)
+	player.POST("/game/:gameId/discard/:pileId", deps.DiscardToCard)
 
 	// Deck reset routes
-	r.GET("/game/:gameId/reset", deps.ResetDeck)
-	r.GET("/game/:gameId/reset/:decks", deps.ResetDeckWithDecks)
-	r.GET("/game/:gameId/reset/:decks/:type", deps.ResetDeckWithType)
+	host.GET("/game/:gameId/reset", deps.ResetDeck)
+	host.GET("/game/:gameId/reset/:decks", deps.ResetDeckWithDecks)
+	host.GET("/game/:gameId/reset/:decks/:type", deps.ResetDeckWithType)
 
 	// Blackjack routes
-	r.POST("/game/:gameId/start", deps.StartBlackjackGame)
-	r.POST("/game/:gameId/hit/:playerId", deps.PlayerHit)
-	r.POST("/game/:gameId/stand/:playerId", deps.PlayerStand)
-	r.GET("/game/:gameId/results", deps.GetGameResults)
+	host.POST("/game/new/blackjack", deps.CreateBlackjackTable)
+	host.POST("/game/:gameId/start", deps.StartBlackjackGame)
+	host.POST("/game/:gameId/next-round", deps.NextRound)
+	player.POST("/game/:gameId/hit/:playerId", deps.PlayerHit)
+	player.POST("/game/:gameId/stand/:playerId", deps.PlayerStand)
+	player.POST("/game/:gameId/split/:playerId", deps.PlayerSplit)
+	player.POST("/game/:gameId/double/:playerId", deps.PlayerDouble)
+	player.POST("/game/:gameId/insurance/:playerId", deps.PlayerInsure)
+	player.POST("/game/:gameId/surrender/:playerId", deps.PlayerSurrender)
+	player.GET("/game/:gameId/results", deps.GetGameResults)
 	
 	// Glitchjack routes
-	r.GET("/game/new/glitchjack", deps.CreateNewGlitchjackGame)
-	r.GET("/game/new/glitchjack/:decks", deps.CreateNewGlitchjackGameWithDecks)
-	r.GET("/game/new/glitchjack/:decks/:players", deps.CreateNewGlitchjackGameWithPlayers)
-	r.POST("/game/:gameId/glitchjack/start", deps.StartGlitchjackGame)
-	r.POST("/game/:gameId/glitchjack/hit/:playerId", deps.GlitchjackHit)
-	r.POST("/game/:gameId/glitchjack/stand/:playerId", deps.GlitchjackStand)
-	r.GET("/game/:gameId/glitchjack/results", deps.GetGlitchjackResults)
+	host.GET("/game/new/glitchjack", deps.CreateNewGlitchjackGame)
+	host.POST("/game/new/glitchjack", deps.CreateGlitchjackTable)
+	host.GET("/game/new/glitchjack/:decks", deps.CreateNewGlitchjackGameWithDecks)
+	host.GET("/game/new/glitchjack/:decks/:players", deps.CreateNewGlitchjackGameWithPlayers)
+	host.POST("/game/:gameId/glitchjack/start", deps.StartGlitchjackGame)
+	player.POST("/game/:gameId/glitchjack/hit/:playerId", deps.GlitchjackHit)
+	player.POST("/game/:gameId/glitchjack/stand/:playerId", deps.GlitchjackStand)
+	player.GET("/game/:gameId/glitchjack/results", deps.GetGlitchjackResults)
+	
+	// Chip and betting routes for blackjack and Glitchjack tables
+	host.POST("/game/:gameId/buy-in/:playerId", deps.PlayerBuyIn)
+	player.POST("/game/:gameId/bet/:playerId", deps.PlaceBet)
+	player.GET("/game/:gameId/bankrolls", deps.GetBankrolls)
+	
+	// Provably fair routes for blackjack and Glitchjack tables
+	player.GET("/game/:gameId/fairness", deps.GetFairness)
+	player.POST("/game/:gameId/fairness/client-seed", deps.SetClientSeed)
+	r.POST("/fairness/verify", deps.VerifyShuffle)
 	
 	// Cribbage routes
-	r.GET("/game/new/cribbage", deps.CreateNewCribbageGame)
-	r.POST("/game/:gameId/cribbage/start", deps.StartCribbageGame)
-	r.POST("/game/:gameId/cribbage/discard/:playerId", deps.CribbageDiscard)
+	host.GET("/game/new/cribbage", deps.CreateNewCribbageGame)
+	host.GET("/game/new/cribbage/:players", deps.CreateNewCribbageGameWithPlayers)
+	host.POST("/game/new/cribbage", deps.CreateCribbageTable)
+	host.POST("/game/:gameId/cribbage/start", deps.StartCribbageGame)
+	player.POST("/game/:gameId/cribbage/discard/:playerId", deps.CribbageDiscard)
+	player.POST("/game/:gameId/cribbage/play/:playerId", deps.CribbagePlay)
+	player.POST("/game/:gameId/cribbage/go/:playerId", deps.CribbageGo)
+	player.POST("/game/:gameId/cribbage/show", deps.CribbageShow)
+	player.POST("/game/:gameId/cribbage/claim/:playerId", deps.CribbageClaim)
+	player.POST("/game/:gameId/cribbage/muggins/:playerId", deps.CribbageMuggins)
+	host.POST("/game/:gameId/cribbage/next-hand", deps.CribbageNextHand)
+	host.POST("/game/:gameId/cribbage/next-game", deps.CribbageNextGame)
+	player.GET("/game/:gameId/cribbage/state", deps.GetCribbageState)
+	
+	// Texas Hold'em routes
+	host.GET("/game/new/poker", deps.CreateNewPokerGame)
+	host.GET("/game/new/poker/:players", deps.CreateNewPokerGameWithPlayers)
+	host.POST("/game/:gameId/poker/start", deps.StartPokerGame)
+	host.POST("/game/:gameId/poker/next-hand", deps.NextPokerHand)
+	player.GET("/game/:gameId/poker/state", deps.GetPokerState)
+	player.POST("/game/:gameId/poker/:action/:playerId", deps.PokerAction)
+	player.POST("/evaluate/poker", deps.EvaluatePokerHands)
+	
+	// War routes
+	host.GET("/game/new/war", deps.CreateNewWarGame)
+	host.GET("/game/new/war/:players", deps.CreateNewWarGameWithPlayers)
+	host.POST("/game/:gameId/war/start", deps.StartWarGame)
+	player.POST("/game/:gameId/war/flip", deps.WarFlip)
+	player.POST("/game/:gameId/war/auto-play", deps.WarAutoPlay)
+	player.GET("/game/:gameId/war/state", deps.GetWarState)
+	
+	// Go Fish routes
+	host.GET("/game/new/gofish", deps.CreateNewGoFishGame)
+	host.GET("/game/new/gofish/:players", deps.CreateNewGoFishGameWithPlayers)
+	host.POST("/game/:gameId/gofish/start", deps.StartGoFishGame)
+	player.POST("/game/:gameId/gofish/ask/:playerId", deps.GoFishAsk)
+	player.POST("/game/:gameId/gofish/draw/:playerId", deps.GoFishDraw)
+	player.GET("/game/:gameId/gofish/state", deps.GetGoFishState)
 	
 	// Custom deck routes
-	r.POST("/custom-decks", deps.CreateCustomDeck)
-	r.GET("/custom-decks", deps.ListCustomDecks)
-	r.GET("/custom-decks/:deckId", deps.GetCustomDeck)
+	host.POST("/custom-decks", deps.CreateCustomDeck)
+	player.GET("/custom-decks", deps.ListCustomDecks)
+	player.GET("/custom-decks/:deckId", deps.GetCustomDeck)
 
 	// Get port from environment variable, default to 8080
 	port := config.GetPort()
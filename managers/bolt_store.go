@@ -0,0 +1,114 @@
+package managers
+
+import (
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/peteshima/cardgame-api/models"
+)
+
+var (
+	gamesBucket       = []byte("games")
+	customDecksBucket = []byte("custom_decks")
+)
+
+// BoltStore persists games and custom decks in a single embedded bbolt database file.
+// Each object is stored as JSON under its ID so the file survives restarts and redeploys.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (or creates) the database file at path and prepares its buckets.
+// The file is locked for the lifetime of the store, so only one process may use it at a time.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{gamesBucket, customDecksBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (bs *BoltStore) SaveGame(game *models.Game) error {
+	return bs.put(gamesBucket, game.ID, game)
+}
+
+func (bs *BoltStore) DeleteGame(gameID string) error {
+	return bs.delete(gamesBucket, gameID)
+}
+
+func (bs *BoltStore) LoadGames() ([]*models.Game, error) {
+	games := []*models.Game{}
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(gamesBucket).ForEach(func(_, value []byte) error {
+			var game models.Game
+			if err := json.Unmarshal(value, &game); err != nil {
+				return err
+			}
+			games = append(games, &game)
+			return nil
+		})
+	})
+	return games, err
+}
+
+func (bs *BoltStore) SaveCustomDeck(deck *models.CustomDeck) error {
+	return bs.put(customDecksBucket, deck.ID, deck)
+}
+
+func (bs *BoltStore) DeleteCustomDeck(deckID string) error {
+	return bs.delete(customDecksBucket, deckID)
+}
+
+func (bs *BoltStore) LoadCustomDecks() ([]*models.CustomDeck, error) {
+	decks := []*models.CustomDeck{}
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(customDecksBucket).ForEach(func(_, value []byte) error {
+			var deck models.CustomDeck
+			if err := json.Unmarshal(value, &deck); err != nil {
+				return err
+			}
+			decks = append(decks, &deck)
+			return nil
+		})
+	})
+	return decks, err
+}
+
+func (bs *BoltStore) Close() error {
+	return bs.db.Close()
+}
+
+// put encodes value as JSON and writes it under key in the given bucket.
+func (bs *BoltStore) put(bucket []byte, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), data)
+	})
+}
+
+// delete removes key from the given bucket; missing keys are not an error.
+func (bs *BoltStore) delete(bucket []byte, key string) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete([]byte(key))
+	})
+}
@@ -7,22 +7,51 @@ import (
 )
 
 type CustomDeckManager struct {
-	decks map[string]*models.CustomDeck
-	mutex sync.RWMutex
+	decks        map[string]*models.CustomDeck
+	store        Store
+	onStoreError func(error)
+	mutex        sync.RWMutex
 }
 
 func NewCustomDeckManager() *CustomDeckManager {
 	return &CustomDeckManager{
 		decks: make(map[string]*models.CustomDeck),
+		store: NewMemoryStore(),
 	}
 }
 
+// NewCustomDeckManagerWithStore creates a custom deck manager backed by the given store.
+// Decks already persisted in the store are loaded so they survive restarts.
+func NewCustomDeckManagerWithStore(store Store) (*CustomDeckManager, error) {
+	decks, err := store.LoadCustomDecks()
+	if err != nil {
+		return nil, err
+	}
+
+	cdm := &CustomDeckManager{
+		decks: make(map[string]*models.CustomDeck, len(decks)),
+		store: store,
+	}
+	for _, deck := range decks {
+		cdm.decks[deck.ID] = deck
+	}
+	return cdm, nil
+}
+
+// SetStoreErrorHandler registers a callback invoked whenever a write to the store fails.
+func (cdm *CustomDeckManager) SetStoreErrorHandler(handler func(error)) {
+	cdm.mutex.Lock()
+	defer cdm.mutex.Unlock()
+	cdm.onStoreError = handler
+}
+
 func (cdm *CustomDeckManager) CreateDeck(name string) *models.CustomDeck {
 	cdm.mutex.Lock()
 	defer cdm.mutex.Unlock()
 	
 	deck := models.NewCustomDeckTemplate(name)
 	cdm.decks[deck.ID] = deck
+	cdm.reportStoreError(cdm.store.SaveCustomDeck(deck))
 	return deck
 }
 
@@ -37,6 +66,17 @@ func (cdm *CustomDeckManager) GetDeck(deckID string) (*models.CustomDeck, bool)
 	return deck, exists
 }
 
+// SaveDeck writes the current state of a custom deck through to the store.
+func (cdm *CustomDeckManager) SaveDeck(deck *models.CustomDeck) {
+	cdm.mutex.RLock()
+	defer cdm.mutex.RUnlock()
+
+	if _, exists := cdm.decks[deck.ID]; !exists {
+		return
+	}
+	cdm.reportStoreError(cdm.store.SaveCustomDeck(deck))
+}
+
 func (cdm *CustomDeckManager) DeleteDeck(deckID string) bool {
 	cdm.mutex.Lock()
 	defer cdm.mutex.Unlock()
@@ -44,6 +84,7 @@ func (cdm *CustomDeckManager) DeleteDeck(deckID string) bool {
 	_, exists := cdm.decks[deckID]
 	if exists {
 		delete(cdm.decks, deckID)
+		cdm.reportStoreError(cdm.store.DeleteCustomDeck(deckID))
 	}
 	return exists
 }
@@ -57,4 +98,11 @@ func (cdm *CustomDeckManager) ListDecks() []*models.CustomDeck {
 		decks = append(decks, deck)
 	}
 	return decks
-}
\ No newline at end of file
+}
+
+// reportStoreError forwards a failed store write to the registered handler, if any.
+func (cdm *CustomDeckManager) reportStoreError(err error) {
+	if err != nil && cdm.onStoreError != nil {
+		cdm.onStoreError(err)
+	}
+}
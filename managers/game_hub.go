@@ -0,0 +1,344 @@
+package managers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// GameUpdateType identifies the kind of change pushed to game subscribers.
+type GameUpdateType string
+
+const (
+	UpdatePlayerJoined  GameUpdateType = "player_joined"
+	UpdatePlayerRemoved GameUpdateType = "player_removed"
+	UpdateCardDealt     GameUpdateType = "card_dealt"
+	UpdateGameAction    GameUpdateType = "game_action"
+	UpdateTurnChanged   GameUpdateType = "turn_changed"
+	UpdateDealerPlayed  GameUpdateType = "dealer_played"
+	UpdatePhaseChanged  GameUpdateType = "phase_changed"
+	UpdateStatusChanged GameUpdateType = "status_changed"
+	UpdateGameClosed    GameUpdateType = "game_closed"
+)
+
+const (
+	// subscriberBuffer is how many updates a subscriber may fall behind before it is dropped.
+	subscriberBuffer = 64
+	// historySize is how many recent updates per game are kept so reconnecting clients can resume.
+	historySize = 256
+)
+
+// GameUpdate is a single change notification delivered to everyone watching a game.
+// Updates describe what happened; clients fetch the full state when they need it.
+type GameUpdate struct {
+	ID        uint64                 `json:"id"` // Hub-wide, strictly increasing update ID
+	GameID    string                 `json:"game_id"`
+	Type      GameUpdateType         `json:"type"`
+	Seq       int                    `json:"seq,omitempty"` // Event log sequence number that caused the update
+	PlayerID  string                 `json:"player_id,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// Subscription receives the updates for one game until it is unsubscribed or the game closes.
+// The channel is closed when the subscription ends, after a final game_closed update if the game went away.
+type Subscription struct {
+	GameID  string
+	LastID  uint64 // ID of the newest update issued before the subscription started
+	updates chan GameUpdate
+}
+
+// Updates returns the channel on which updates for the subscribed game are delivered.
+func (s *Subscription) Updates() <-chan GameUpdate {
+	return s.updates
+}
+
+// gameCursor remembers what subscribers of a game have already been told.
+type gameCursor struct {
+	seq           int
+	status        models.GameStatus
+	currentPlayer int
+	phase         string
+}
+
+// gameFeed is the hub's record of a watched game: its cursor and recent update history.
+// Feeds live from the first subscription until the game is closed, so clients can resume across reconnects.
+type gameFeed struct {
+	cursor  *gameCursor
+	history []GameUpdate
+	floor   uint64 // Updates with IDs above this are all still in history
+}
+
+// GameHub is an in-process publish/subscribe hub for live game updates.
+// Services publish a game after mutating it and the hub turns new event log entries
+// and turn, phase and status transitions into updates for every subscriber of that game.
+type GameHub struct {
+	subscribers map[string]map[*Subscription]struct{}
+	feeds       map[string]*gameFeed
+	lastID      uint64
+	mutex       sync.Mutex
+}
+
+// NewGameHub creates an empty hub with no subscribers.
+func NewGameHub() *GameHub {
+	return &GameHub{
+		subscribers: make(map[string]map[*Subscription]struct{}),
+		feeds:       make(map[string]*gameFeed),
+	}
+}
+
+// Subscribe registers interest in a game and starts tracking its changes from its current state.
+func (h *GameHub) Subscribe(game *models.Game) *Subscription {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.subscribe(game)
+}
+
+// Resume subscribes to a game and returns the updates issued after lastID that the client missed.
+// complete is false when the hub no longer holds every missed update, in which case the client
+// should discard its local state and start again from a fresh snapshot.
+func (h *GameHub) Resume(game *models.Game, lastID uint64) (sub *Subscription, missed []GameUpdate, complete bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	feed, tracked := h.feeds[game.ID]
+	complete = tracked && lastID >= feed.floor && lastID <= h.lastID
+	missed = []GameUpdate{}
+	if complete {
+		for _, update := range feed.history {
+			if update.ID > lastID {
+				missed = append(missed, update)
+			}
+		}
+	}
+	return h.subscribe(game), missed, complete
+}
+
+// subscribe registers a subscription, starting a feed for the game if needed.
+// Callers must hold the hub mutex.
+func (h *GameHub) subscribe(game *models.Game) *Subscription {
+	sub := &Subscription{
+		GameID:  game.ID,
+		LastID:  h.lastID,
+		updates: make(chan GameUpdate, subscriberBuffer),
+	}
+	if h.subscribers[game.ID] == nil {
+		h.subscribers[game.ID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[game.ID][sub] = struct{}{}
+	if _, tracked := h.feeds[game.ID]; !tracked {
+		h.feeds[game.ID] = &gameFeed{cursor: newGameCursor(game), floor: h.lastID}
+	}
+	return sub
+}
+
+// Unsubscribe stops delivering updates to a subscription and closes its channel.
+// It is safe to call more than once.
+func (h *GameHub) Unsubscribe(sub *Subscription) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.remove(sub)
+}
+
+// PublishGame pushes every change made to the game since it was last published.
+// Games nobody has watched are skipped, so publishing is cheap for idle tables.
+func (h *GameHub) PublishGame(game *models.Game) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	feed, tracked := h.feeds[game.ID]
+	if !tracked {
+		return
+	}
+
+	for _, update := range feed.cursor.advance(game) {
+		h.broadcast(feed, update)
+	}
+}
+
+// CloseGame sends a final game_closed update with the given reason and ends every subscription.
+// GameManager calls this when a game is deleted or expires.
+func (h *GameHub) CloseGame(gameID string, reason string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	feed, tracked := h.feeds[gameID]
+	if !tracked {
+		return
+	}
+
+	h.broadcast(feed, GameUpdate{
+		GameID:    gameID,
+		Type:      UpdateGameClosed,
+		Data:      map[string]interface{}{"reason": reason},
+		Timestamp: time.Now(),
+	})
+	for sub := range h.subscribers[gameID] {
+		h.remove(sub)
+	}
+	delete(h.feeds, gameID)
+}
+
+// SubscriberCount returns the number of live subscriptions for a game.
+func (h *GameHub) SubscriberCount(gameID string) int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.subscribers[gameID])
+}
+
+// broadcast assigns the next update ID, records the update in the game's history and
+// delivers it to every subscriber without blocking. Subscribers whose buffers are full are
+// dropped so one slow client cannot stall a table. Callers must hold the hub mutex.
+func (h *GameHub) broadcast(feed *gameFeed, update GameUpdate) {
+	h.lastID++
+	update.ID = h.lastID
+
+	feed.history = append(feed.history, update)
+	if len(feed.history) > historySize {
+		feed.floor = feed.history[0].ID
+		feed.history = feed.history[1:]
+	}
+
+	for sub := range h.subscribers[update.GameID] {
+		select {
+		case sub.updates <- update:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// remove detaches a subscription and closes its channel. Callers must hold the hub mutex.
+func (h *GameHub) remove(sub *Subscription) {
+	subs, exists := h.subscribers[sub.GameID]
+	if !exists {
+		return
+	}
+	if _, exists := subs[sub]; !exists {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.updates)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.GameID)
+	}
+}
+
+// newGameCursor captures the current position of a game so only later changes are published.
+func newGameCursor(game *models.Game) *gameCursor {
+	return &gameCursor{
+		seq:           len(game.Events),
+		status:        game.Status,
+		currentPlayer: game.CurrentPlayer,
+		phase:         gamePhase(game),
+	}
+}
+
+// advance returns the updates describing how the game changed since the cursor and moves the cursor forward.
+func (c *gameCursor) advance(game *models.Game) []GameUpdate {
+	now := time.Now()
+	updates := []GameUpdate{}
+
+	for _, event := range game.EventsSince(c.seq) {
+		updates = append(updates, eventUpdate(game.ID, event))
+	}
+
+	if game.CurrentPlayer != c.currentPlayer && game.Status == models.GameInProgress {
+		update := GameUpdate{
+			GameID:    game.ID,
+			Type:      UpdateTurnChanged,
+			Data:      map[string]interface{}{"current_player": game.CurrentPlayer},
+			Timestamp: now,
+		}
+		if game.CurrentPlayer >= 0 && game.CurrentPlayer < len(game.Players) {
+			update.PlayerID = game.Players[game.CurrentPlayer].ID
+		}
+		updates = append(updates, update)
+	}
+
+	if phase := gamePhase(game); phase != c.phase && phase != "" {
+		updates = append(updates, GameUpdate{
+			GameID:    game.ID,
+			Type:      UpdatePhaseChanged,
+			Data:      map[string]interface{}{"phase": phase},
+			Timestamp: now,
+		})
+	}
+
+	if game.Status != c.status {
+		if game.Status == models.GameFinished && (game.GameType == models.Blackjack || game.GameType == models.Glitchjack) {
+			value, _ := game.Dealer.BlackjackHandValue()
+			updates = append(updates, GameUpdate{
+				GameID:    game.ID,
+				Type:      UpdateDealerPlayed,
+				PlayerID:  game.Dealer.ID,
+				Data:      map[string]interface{}{"dealer_value": value, "dealer_cards": len(game.Dealer.Hand)},
+				Timestamp: now,
+			})
+		}
+		updates = append(updates, GameUpdate{
+			GameID:    game.ID,
+			Type:      UpdateStatusChanged,
+			Data:      map[string]interface{}{"status": game.Status.String()},
+			Timestamp: now,
+		})
+	}
+
+	*c = *newGameCursor(game)
+	return updates
+}
+
+// eventUpdate describes a single event log entry without exposing the identity of any card.
+func eventUpdate(gameID string, event models.GameEvent) GameUpdate {
+	update := GameUpdate{
+		GameID:    gameID,
+		Type:      UpdateGameAction,
+		Seq:       event.Seq,
+		PlayerID:  event.PlayerID,
+		Data:      map[string]interface{}{"action": string(event.Type)},
+		Timestamp: event.Timestamp,
+	}
+
+	switch event.Type {
+	case models.EventPlayerAdded:
+		update.Type = UpdatePlayerJoined
+		update.Data["name"] = event.Name
+	case models.EventPlayerRemoved:
+		update.Type = UpdatePlayerRemoved
+	case models.EventCardDealt, models.EventCardDrawn:
+		update.Type = UpdateCardDealt
+		update.Data["face_up"] = event.Type == models.EventCardDrawn || event.FaceUp
+	case models.EventPokerAction:
+		update.Data["poker_action"] = event.Action
+		if event.Amount > 0 {
+			update.Data["amount"] = event.Amount
+		}
+	case models.EventChipsBought, models.EventBetPlaced:
+		update.Data["amount"] = event.Amount
+	case models.EventGoFishAsk:
+		update.Data["target_id"] = event.TargetID
+		update.Data["rank"] = event.Rank
+		update.Data["cards_received"] = len(event.Cards)
+	}
+	if len(event.Dealt) > 0 {
+		update.Data["cards_dealt"] = len(event.Dealt)
+	}
+	return update
+}
+
+// gamePhase returns the cribbage, poker, war or go fish phase name, or "" for games without phases.
+func gamePhase(game *models.Game) string {
+	switch {
+	case game.CribbageState != nil:
+		return game.CribbageState.Phase.String()
+	case game.PokerState != nil:
+		return game.PokerState.Phase.String()
+	case game.WarState != nil:
+		return game.WarState.Phase.String()
+	case game.GoFishState != nil:
+		return game.GoFishState.Phase.String()
+	}
+	return ""
+}
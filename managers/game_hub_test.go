@@ -0,0 +1,203 @@
+package managers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// drain collects every update currently buffered on a subscription.
+func drain(sub *Subscription) []GameUpdate {
+	updates := []GameUpdate{}
+	for {
+		select {
+		case update, ok := <-sub.Updates():
+			if !ok {
+				return updates
+			}
+			updates = append(updates, update)
+		default:
+			return updates
+		}
+	}
+}
+
+func updateTypes(updates []GameUpdate) []GameUpdateType {
+	types := make([]GameUpdateType, len(updates))
+	for i, update := range updates {
+		types[i] = update.Type
+	}
+	return types
+}
+
+func TestGameHubPublishesChanges(t *testing.T) {
+	hub := NewGameHub()
+	game := models.NewGame(1)
+	alice := game.AddPlayer("Alice")
+
+	first := hub.Subscribe(game)
+	second := hub.Subscribe(game)
+	assert.Equal(t, 2, hub.SubscriberCount(game.ID))
+
+	// Changes made before subscribing are not replayed
+	hub.PublishGame(game)
+	assert.Empty(t, drain(first))
+
+	bob := game.AddPlayer("Bob")
+	game.DealToPlayer(bob.ID, false)
+	hub.PublishGame(game)
+
+	updates := drain(first)
+	assert.Equal(t, []GameUpdateType{UpdatePlayerJoined, UpdateCardDealt}, updateTypes(updates))
+	assert.Equal(t, bob.ID, updates[0].PlayerID)
+	assert.Equal(t, "Bob", updates[0].Data["name"])
+	assert.Equal(t, 3, updates[0].Seq)
+	assert.Equal(t, false, updates[1].Data["face_up"])
+	assert.Equal(t, 1, updates[1].Data["cards_dealt"])
+	assert.Len(t, drain(second), 2)
+
+	require.NoError(t, game.StartBlackjackGame())
+	require.NoError(t, game.PlayerStand(alice.ID))
+	hub.PublishGame(game)
+	assert.Equal(t, []GameUpdateType{UpdateGameAction, UpdateGameAction, UpdateTurnChanged, UpdateStatusChanged}, updateTypes(drain(first)))
+
+	require.NoError(t, game.PlayerStand(bob.ID))
+	hub.PublishGame(game)
+	updates = drain(first)
+	assert.Equal(t, []GameUpdateType{UpdateGameAction, UpdateDealerPlayed, UpdateStatusChanged}, updateTypes(updates))
+	assert.Equal(t, "player_stood", updates[0].Data["action"])
+	assert.Equal(t, "finished", updates[2].Data["status"])
+}
+
+func TestGameHubTurnAndPhaseChanges(t *testing.T) {
+	hub := NewGameHub()
+	game := models.NewGameWithType(1, models.Standard, models.Cribbage, 2)
+	game.AddPlayer("Alice")
+	game.AddPlayer("Bob")
+	sub := hub.Subscribe(game)
+
+	require.NoError(t, game.StartCribbageGame())
+	hub.PublishGame(game)
+	updates := drain(sub)
+	assert.Equal(t, []GameUpdateType{UpdateGameAction, UpdateTurnChanged, UpdatePhaseChanged, UpdateStatusChanged}, updateTypes(updates))
+	assert.Equal(t, 1, updates[1].Data["current_player"])
+	assert.Equal(t, game.Players[1].ID, updates[1].PlayerID)
+	assert.Equal(t, "discard", updates[2].Data["phase"])
+}
+
+func TestGameHubCloseAndUnsubscribe(t *testing.T) {
+	hub := NewGameHub()
+	game := models.NewGame(1)
+	sub := hub.Subscribe(game)
+	other := hub.Subscribe(game)
+
+	hub.Unsubscribe(other)
+	hub.Unsubscribe(other)
+	_, open := <-other.Updates()
+	assert.False(t, open)
+	assert.Equal(t, 1, hub.SubscriberCount(game.ID))
+
+	hub.CloseGame(game.ID, "deleted")
+	updates := drain(sub)
+	require.Len(t, updates, 1)
+	assert.Equal(t, UpdateGameClosed, updates[0].Type)
+	assert.Equal(t, "deleted", updates[0].Data["reason"])
+	_, open = <-sub.Updates()
+	assert.False(t, open)
+	assert.Equal(t, 0, hub.SubscriberCount(game.ID))
+
+	// Unsubscribing after the game closed is a no-op
+	hub.Unsubscribe(sub)
+}
+
+func TestGameHubDropsSlowSubscribers(t *testing.T) {
+	hub := NewGameHub()
+	game := models.NewGameWithType(1, models.Standard, models.Blackjack, subscriberBuffer+10)
+	slow := hub.Subscribe(game)
+
+	for i := 0; i < subscriberBuffer+1; i++ {
+		game.AddPlayer("Player")
+		hub.PublishGame(game)
+	}
+
+	assert.Len(t, drain(slow), subscriberBuffer)
+	_, open := <-slow.Updates()
+	assert.False(t, open)
+	assert.Equal(t, 0, hub.SubscriberCount(game.ID))
+}
+
+func TestGameManagerClosesSubscriptions(t *testing.T) {
+	gm := NewGameManager()
+	deleted := gm.CreateGame(1)
+	expired := gm.CreateGame(1)
+	expired.LastUsed = time.Now().Add(-2 * time.Hour)
+
+	deletedSub := gm.Hub().Subscribe(deleted)
+	expiredSub := gm.Hub().Subscribe(expired)
+
+	assert.True(t, gm.DeleteGame(deleted.ID))
+	assert.Equal(t, 1, gm.CleanupOldGames(time.Hour))
+
+	updates := drain(deletedSub)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "deleted", updates[0].Data["reason"])
+
+	updates = drain(expiredSub)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "expired", updates[0].Data["reason"])
+}
+
+func TestGameHubResume(t *testing.T) {
+	hub := NewGameHub()
+	game := models.NewGame(1)
+
+	first := hub.Subscribe(game)
+	assert.Equal(t, uint64(0), first.LastID)
+	game.AddPlayer("Alice")
+	hub.PublishGame(game)
+	seen := drain(first)
+	require.Len(t, seen, 1)
+	assert.Equal(t, uint64(1), seen[0].ID)
+	hub.Unsubscribe(first)
+
+	// Updates published while nobody is connected are kept for resuming clients
+	game.AddPlayer("Bob")
+	game.AddPlayer("Carol")
+	hub.PublishGame(game)
+
+	sub, missed, complete := hub.Resume(game, seen[0].ID)
+	assert.True(t, complete)
+	require.Len(t, missed, 2)
+	assert.Equal(t, []uint64{2, 3}, []uint64{missed[0].ID, missed[1].ID})
+	assert.Equal(t, "Carol", missed[1].Data["name"])
+	assert.Equal(t, uint64(3), sub.LastID)
+	hub.Unsubscribe(sub)
+
+	// IDs from the future or from a game the hub never tracked cannot be resumed
+	_, _, complete = hub.Resume(game, 99)
+	assert.False(t, complete)
+	_, missed, complete = hub.Resume(models.NewGame(1), 1)
+	assert.False(t, complete)
+	assert.Empty(t, missed)
+}
+
+func TestGameHubResumeAfterHistoryTrimmed(t *testing.T) {
+	hub := NewGameHub()
+	game := models.NewGameWithType(1, models.Standard, models.Blackjack, historySize+10)
+	hub.Unsubscribe(hub.Subscribe(game))
+
+	for i := 0; i < historySize+2; i++ {
+		game.AddPlayer("Player")
+		hub.PublishGame(game)
+	}
+
+	_, _, complete := hub.Resume(game, 1)
+	assert.False(t, complete)
+	_, missed, complete := hub.Resume(game, 2)
+	assert.True(t, complete)
+	assert.Len(t, missed, historySize)
+}
@@ -9,9 +9,13 @@ import (
 
 // GameManager provides thread-safe management of multiple concurrent card games.
 // It uses read-write mutexes to allow concurrent read access while ensuring write safety.
+// Live games are kept in memory and every change is written through to the backing Store.
 type GameManager struct {
-	games map[string]*models.Game
-	mutex sync.RWMutex
+	games        map[string]*models.Game
+	store        Store
+	hub          *GameHub
+	onStoreError func(error)
+	mutex        sync.RWMutex
 }
 
 // NewGameManager creates a new game manager with an empty game collection.
@@ -19,9 +23,43 @@ type GameManager struct {
 func NewGameManager() *GameManager {
 	return &GameManager{
 		games: make(map[string]*models.Game),
+		store: NewMemoryStore(),
+		hub:   NewGameHub(),
 	}
 }
 
+// NewGameManagerWithStore creates a game manager backed by the given store.
+// Any games already persisted in the store are loaded so they survive restarts.
+func NewGameManagerWithStore(store Store) (*GameManager, error) {
+	games, err := store.LoadGames()
+	if err != nil {
+		return nil, err
+	}
+
+	gm := &GameManager{
+		games: make(map[string]*models.Game, len(games)),
+		store: store,
+		hub:   NewGameHub(),
+	}
+	for _, game := range games {
+		gm.games[game.ID] = game
+	}
+	return gm, nil
+}
+
+// SetStoreErrorHandler registers a callback invoked whenever a write to the store fails.
+// Store failures never abort game operations, so this is how they are surfaced to logs.
+func (gm *GameManager) SetStoreErrorHandler(handler func(error)) {
+	gm.mutex.Lock()
+	defer gm.mutex.Unlock()
+	gm.onStoreError = handler
+}
+
+// Hub returns the pub/sub hub used to push live updates for this manager's games.
+func (gm *GameManager) Hub() *GameHub {
+	return gm.hub
+}
+
 func (gm *GameManager) CreateGame(numDecks int) *models.Game {
 	return gm.CreateCustomGame(numDecks, models.Standard)
 }
@@ -36,6 +74,7 @@ func (gm *GameManager) CreateGameWithType(numDecks int, deckType models.DeckType
 	
 	game := models.NewGameWithType(numDecks, deckType, gameType, maxPlayers)
 	gm.games[game.ID] = game
+	gm.reportStoreError(gm.store.SaveGame(game))
 	return game
 }
 
@@ -50,6 +89,18 @@ func (gm *GameManager) GetGame(gameID string) (*models.Game, bool) {
 	return game, exists
 }
 
+// SaveGame writes the current state of a game through to the store.
+// Services call this after mutating a game so persistent backends stay current.
+func (gm *GameManager) SaveGame(game *models.Game) {
+	gm.mutex.RLock()
+	defer gm.mutex.RUnlock()
+
+	if _, exists := gm.games[game.ID]; !exists {
+		return
+	}
+	gm.reportStoreError(gm.store.SaveGame(game))
+}
+
 func (gm *GameManager) DeleteGame(gameID string) bool {
 	gm.mutex.Lock()
 	defer gm.mutex.Unlock()
@@ -57,6 +108,8 @@ func (gm *GameManager) DeleteGame(gameID string) bool {
 	_, exists := gm.games[gameID]
 	if exists {
 		delete(gm.games, gameID)
+		gm.reportStoreError(gm.store.DeleteGame(gameID))
+		gm.hub.CloseGame(gameID, "deleted")
 	}
 	return exists
 }
@@ -86,6 +139,8 @@ func (gm *GameManager) CleanupOldGames(maxAge time.Duration) int {
 	for id, game := range gm.games {
 		if game.LastUsed.Before(cutoff) {
 			delete(gm.games, id)
+			gm.reportStoreError(gm.store.DeleteGame(id))
+			gm.hub.CloseGame(id, "expired")
 			deleted++
 		}
 	}
@@ -99,4 +154,12 @@ func (gm *GameManager) GameCount() int {
 	gm.mutex.RLock()
 	defer gm.mutex.RUnlock()
 	return len(gm.games)
-}
\ No newline at end of file
+}
+
+// reportStoreError forwards a failed store write to the registered handler, if any.
+// Callers must hold the manager mutex.
+func (gm *GameManager) reportStoreError(err error) {
+	if err != nil && gm.onStoreError != nil {
+		gm.onStoreError(err)
+	}
+}
@@ -0,0 +1,114 @@
+package managers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// Store persists games and custom decks so they can outlive the process.
+// Managers keep live objects in memory and write every change through to a Store.
+type Store interface {
+	SaveGame(game *models.Game) error
+	DeleteGame(gameID string) error
+	LoadGames() ([]*models.Game, error)
+	SaveCustomDeck(deck *models.CustomDeck) error
+	DeleteCustomDeck(deckID string) error
+	LoadCustomDecks() ([]*models.CustomDeck, error)
+	Close() error
+}
+
+// Supported store backends for OpenStore.
+const (
+	StoreBackendMemory = "memory"
+	StoreBackendBolt   = "bolt"
+)
+
+// OpenStore creates the store selected by backend name.
+// The path is only used by on-disk backends and names the database file.
+func OpenStore(backend string, path string) (Store, error) {
+	switch strings.ToLower(backend) {
+	case "", StoreBackendMemory:
+		return NewMemoryStore(), nil
+	case StoreBackendBolt:
+		return NewBoltStore(path)
+	default:
+		return nil, fmt.Errorf("unknown store backend: %s", backend)
+	}
+}
+
+// MemoryStore keeps games and custom decks in process memory.
+// It is the default backend and loses all data when the process exits.
+type MemoryStore struct {
+	games map[string]*models.Game
+	decks map[string]*models.CustomDeck
+	mutex sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		games: make(map[string]*models.Game),
+		decks: make(map[string]*models.CustomDeck),
+	}
+}
+
+func (ms *MemoryStore) SaveGame(game *models.Game) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	ms.games[game.ID] = game
+	return nil
+}
+
+func (ms *MemoryStore) DeleteGame(gameID string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	delete(ms.games, gameID)
+	return nil
+}
+
+func (ms *MemoryStore) LoadGames() ([]*models.Game, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	games := make([]*models.Game, 0, len(ms.games))
+	for _, game := range ms.games {
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+func (ms *MemoryStore) SaveCustomDeck(deck *models.CustomDeck) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	ms.decks[deck.ID] = deck
+	return nil
+}
+
+func (ms *MemoryStore) DeleteCustomDeck(deckID string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	delete(ms.decks, deckID)
+	return nil
+}
+
+func (ms *MemoryStore) LoadCustomDecks() ([]*models.CustomDeck, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	decks := make([]*models.CustomDeck, 0, len(ms.decks))
+	for _, deck := range ms.decks {
+		decks = append(decks, deck)
+	}
+	return decks, nil
+}
+
+func (ms *MemoryStore) Close() error {
+	return nil
+}
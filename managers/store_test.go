@@ -0,0 +1,194 @@
+package managers
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/peteshima/cardgame-api/models"
+)
+
+func openTestBoltStore(t *testing.T, path string) *BoltStore {
+	store, err := NewBoltStore(path)
+	require.NoError(t, err)
+	return store
+}
+
+func TestOpenStore(t *testing.T) {
+	store, err := OpenStore("memory", "")
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryStore{}, store)
+
+	store, err = OpenStore("", "")
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryStore{}, store)
+
+	path := filepath.Join(t.TempDir(), "games.db")
+	store, err = OpenStore("BOLT", path)
+	assert.NoError(t, err)
+	assert.IsType(t, &BoltStore{}, store)
+	assert.NoError(t, store.Close())
+
+	_, err = OpenStore("postgres", "")
+	assert.Error(t, err)
+}
+
+func TestStoreBackendsRoundTrip(t *testing.T) {
+	backends := map[string]func(t *testing.T) Store{
+		"memory": func(t *testing.T) Store { return NewMemoryStore() },
+		"bolt": func(t *testing.T) Store {
+			return openTestBoltStore(t, filepath.Join(t.TempDir(), "games.db"))
+		},
+	}
+
+	for name, open := range backends {
+		t.Run(name, func(t *testing.T) {
+			store := open(t)
+			defer store.Close()
+
+			game := models.NewGameWithType(2, models.Spanish21, models.Cribbage, 2)
+			game.AddPlayer("Alice")
+			require.NoError(t, store.SaveGame(game))
+
+			deck := models.NewCustomDeckTemplate("Tarot")
+			deck.AddCard("The Fool", 0, "major", map[string]string{"arcana": "major"})
+			require.NoError(t, store.SaveCustomDeck(deck))
+
+			games, err := store.LoadGames()
+			require.NoError(t, err)
+			require.Len(t, games, 1)
+			assert.Equal(t, game.ID, games[0].ID)
+			assert.Equal(t, models.Cribbage, games[0].GameType)
+			assert.Equal(t, models.Spanish21, games[0].Deck.DeckType)
+			assert.Equal(t, 96, games[0].Deck.RemainingCards())
+			assert.Equal(t, game.Deck.Cards, games[0].Deck.Cards)
+			require.Len(t, games[0].Players, 1)
+			assert.Equal(t, "Alice", games[0].Players[0].Name)
+
+			decks, err := store.LoadCustomDecks()
+			require.NoError(t, err)
+			require.Len(t, decks, 1)
+			assert.Equal(t, "Tarot", decks[0].Name)
+			assert.Equal(t, 1, decks[0].CardCount())
+
+			require.NoError(t, store.DeleteGame(game.ID))
+			require.NoError(t, store.DeleteCustomDeck(deck.ID))
+
+			games, err = store.LoadGames()
+			require.NoError(t, err)
+			assert.Empty(t, games)
+			decks, err = store.LoadCustomDecks()
+			require.NoError(t, err)
+			assert.Empty(t, decks)
+		})
+	}
+}
+
+func TestGameManagerSurvivesRestartWithBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.db")
+
+	store := openTestBoltStore(t, path)
+	gm, err := NewGameManagerWithStore(store)
+	require.NoError(t, err)
+
+	game := gm.CreateGameWithType(1, models.Standard, models.Blackjack, 4)
+	player := game.AddPlayer("Alice")
+	require.NoError(t, game.StartBlackjackGame())
+	gm.SaveGame(game)
+
+	stale := gm.CreateGame(1)
+	stale.LastUsed = time.Now().Add(-2 * time.Hour)
+	gm.SaveGame(stale)
+
+	deleted := gm.CreateGame(1)
+	assert.True(t, gm.DeleteGame(deleted.ID))
+	require.NoError(t, store.Close())
+
+	// Reopen the same file as a fresh process would
+	store = openTestBoltStore(t, path)
+	defer store.Close()
+	gm, err = NewGameManagerWithStore(store)
+	require.NoError(t, err)
+	assert.Equal(t, 2, gm.GameCount())
+
+	restored, exists := gm.GetGame(game.ID)
+	require.True(t, exists)
+	assert.Equal(t, models.GameInProgress, restored.Status)
+	assert.Equal(t, 4, restored.MaxPlayers)
+	assert.Equal(t, game.Deck.Cards, restored.Deck.Cards)
+	restoredPlayer := restored.GetPlayer(player.ID)
+	require.NotNil(t, restoredPlayer)
+	assert.Equal(t, player.Hand, restoredPlayer.Hand)
+	assert.Equal(t, game.Dealer.Hand, restored.Dealer.Hand)
+
+	_, exists = gm.GetGame(deleted.ID)
+	assert.False(t, exists)
+
+	assert.Equal(t, 1, gm.CleanupOldGames(time.Hour))
+	games, err := store.LoadGames()
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+	assert.Equal(t, game.ID, games[0].ID)
+}
+
+func TestCustomDeckManagerSurvivesRestartWithBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decks.db")
+
+	store := openTestBoltStore(t, path)
+	cdm, err := NewCustomDeckManagerWithStore(store)
+	require.NoError(t, err)
+
+	deck := cdm.CreateDeck("Uno")
+	deck.AddCard("Red Seven", 7, "red", nil)
+	deck.AddCard("Wild", "wild", "", nil)
+	cdm.SaveDeck(deck)
+	require.NoError(t, store.Close())
+
+	store = openTestBoltStore(t, path)
+	defer store.Close()
+	cdm, err = NewCustomDeckManagerWithStore(store)
+	require.NoError(t, err)
+
+	restored, exists := cdm.GetDeck(deck.ID)
+	require.True(t, exists)
+	assert.Equal(t, "Uno", restored.Name)
+	assert.Equal(t, 2, restored.NextIndex)
+	assert.True(t, restored.GetCard(0).GameCompatible)
+	rank, ok := restored.GetCard(0).GetNumericRank()
+	assert.True(t, ok)
+	assert.Equal(t, 7, rank)
+	assert.False(t, restored.GetCard(1).GameCompatible)
+}
+
+type failingStore struct {
+	*MemoryStore
+}
+
+func (fs failingStore) SaveGame(game *models.Game) error {
+	return errors.New("disk full")
+}
+
+func TestGameManagerReportsStoreErrors(t *testing.T) {
+	gm, err := NewGameManagerWithStore(failingStore{NewMemoryStore()})
+	require.NoError(t, err)
+
+	var reported []error
+	gm.SetStoreErrorHandler(func(err error) {
+		reported = append(reported, err)
+	})
+
+	game := gm.CreateGame(1)
+	assert.NotNil(t, game)
+	gm.SaveGame(game)
+
+	assert.Len(t, reported, 2)
+	assert.EqualError(t, reported[0], "disk full")
+
+	// The game stays available in memory even though the write failed
+	_, exists := gm.GetGame(game.ID)
+	assert.True(t, exists)
+}
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Role is the level of access granted to an API key.
+// Roles are ordered: admin can do everything a table host can, and a table host everything a player can.
+type Role string
+
+const (
+	RolePlayer    Role = "player"     // Join tables, play hands and read game state
+	RoleTableHost Role = "table-host" // Create, deal, reset and start games
+	RoleAdmin     Role = "admin"      // Delete and list every game, and see every card
+)
+
+// apiKeyContextKey is the gin context key under which the authenticated API key is stored.
+const apiKeyContextKey = "api_key"
+
+// roleRank orders roles so a higher role satisfies any lower requirement.
+var roleRank = map[Role]int{
+	RolePlayer:    1,
+	RoleTableHost: 2,
+	RoleAdmin:     3,
+}
+
+// ParseRole converts a role name to a Role, reporting whether it is known.
+func ParseRole(name string) (Role, bool) {
+	role := Role(strings.ToLower(strings.TrimSpace(name)))
+	_, known := roleRank[role]
+	return role, known
+}
+
+// Allows reports whether the role grants at least the required level of access.
+func (r Role) Allows(required Role) bool {
+	return roleRank[r] >= roleRank[required]
+}
+
+// APIKey identifies a client. Only the SHA-256 hash of the secret is ever held in memory or on disk.
+type APIKey struct {
+	ID   string `json:"id"`
+	Role Role   `json:"role"`
+	Hash string `json:"-"`
+}
+
+// KeyRing holds the configured API keys indexed by hash.
+// An empty key ring disables authentication so local development needs no setup.
+type KeyRing struct {
+	keys map[string]APIKey
+}
+
+// NewKeyRing creates a key ring from hashed keys, rejecting duplicate IDs or hashes.
+func NewKeyRing(keys []APIKey) (*KeyRing, error) {
+	ring := &KeyRing{keys: make(map[string]APIKey)}
+	ids := make(map[string]bool)
+	for _, key := range keys {
+		if ids[key.ID] {
+			return nil, fmt.Errorf("duplicate API key id %q", key.ID)
+		}
+		if _, exists := ring.keys[key.Hash]; exists {
+			return nil, fmt.Errorf("API key %q reuses another key's secret", key.ID)
+		}
+		ids[key.ID] = true
+		ring.keys[key.Hash] = key
+	}
+	return ring, nil
+}
+
+// Enabled reports whether any keys are configured and requests must therefore authenticate.
+func (k *KeyRing) Enabled() bool {
+	return k != nil && len(k.keys) > 0
+}
+
+// Len returns the number of configured keys.
+func (k *KeyRing) Len() int {
+	if k == nil {
+		return 0
+	}
+	return len(k.keys)
+}
+
+// Lookup returns the key whose hash matches the presented secret.
+func (k *KeyRing) Lookup(secret string) (APIKey, bool) {
+	if !k.Enabled() || secret == "" {
+		return APIKey{}, false
+	}
+	key, found := k.keys[HashAPIKey(secret)]
+	return key, found
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 digest stored in place of an API key secret.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseAPIKey parses an "id:role:sha256hex" entry as found in the API key file or API_KEYS.
+func ParseAPIKey(entry string) (APIKey, error) {
+	parts := strings.Split(strings.TrimSpace(entry), ":")
+	if len(parts) != 3 {
+		return APIKey{}, fmt.Errorf("API key entry must be id:role:sha256")
+	}
+
+	id := strings.TrimSpace(parts[0])
+	if id == "" {
+		return APIKey{}, fmt.Errorf("API key entry is missing an id")
+	}
+	role, known := ParseRole(parts[1])
+	if !known {
+		return APIKey{}, fmt.Errorf("API key %q has unknown role %q", id, parts[1])
+	}
+	hash := strings.ToLower(strings.TrimSpace(parts[2]))
+	if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+		return APIKey{}, fmt.Errorf("API key %q must store a hex SHA-256 hash, not the key itself", id)
+	}
+
+	return APIKey{ID: id, Role: role, Hash: hash}, nil
+}
+
+// APIKeyMiddleware authenticates requests that present an API key as "Authorization: Bearer <key>",
+// X-API-Key, or ?api_key= (for WebSocket and EventSource clients) and records the key on the context.
+// Requests with an unknown key are rejected; requests without one continue and are checked by RequireRole.
+func APIKeyMiddleware(keys *KeyRing) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !keys.Enabled() {
+			c.Next()
+			return
+		}
+
+		secret := presentedAPIKey(c)
+		if secret == "" {
+			c.Next()
+			return
+		}
+
+		key, found := keys.Lookup(secret)
+		if !found {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid API key",
+			})
+			return
+		}
+
+		c.Set(apiKeyContextKey, key)
+		c.Next()
+	}
+}
+
+// RequireRole rejects requests whose API key does not grant the role. It is attached to route groups in main.go.
+// When no keys are configured every request is allowed.
+func RequireRole(keys *KeyRing, role Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !keys.Enabled() {
+			c.Next()
+			return
+		}
+
+		key, authenticated := APIKeyFromContext(c)
+		if !authenticated {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "API key required",
+			})
+			return
+		}
+		if !key.Role.Allows(role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":         "API key does not have the required role",
+				"required_role": role,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// APIKeyFromContext returns the API key that authenticated the request, if any.
+func APIKeyFromContext(c *gin.Context) (APIKey, bool) {
+	value, exists := c.Get(apiKeyContextKey)
+	if !exists {
+		return APIKey{}, false
+	}
+	key, ok := value.(APIKey)
+	return key, ok
+}
+
+// presentedAPIKey extracts the API key secret from the request, if one was sent.
+func presentedAPIKey(c *gin.Context) string {
+	if authorization := c.GetHeader("Authorization"); authorization != "" {
+		if scheme, secret, found := strings.Cut(authorization, " "); found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(secret)
+		}
+	}
+	if secret := c.GetHeader("X-API-Key"); secret != "" {
+		return strings.TrimSpace(secret)
+	}
+	return c.Query("api_key")
+}
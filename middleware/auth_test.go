@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func testKeyRing(t *testing.T) *KeyRing {
+	keys, err := NewKeyRing([]APIKey{
+		{ID: "ops", Role: RoleAdmin, Hash: HashAPIKey("admin-secret")},
+		{ID: "dealer-bot", Role: RoleTableHost, Hash: HashAPIKey("host-secret")},
+		{ID: "web-client", Role: RolePlayer, Hash: HashAPIKey("player-secret")},
+	})
+	require.NoError(t, err)
+	return keys
+}
+
+func TestParseAPIKey(t *testing.T) {
+	hash := HashAPIKey("secret")
+
+	key, err := ParseAPIKey(" ci:Table-Host:" + hash + " ")
+	require.NoError(t, err)
+	assert.Equal(t, APIKey{ID: "ci", Role: RoleTableHost, Hash: hash}, key)
+
+	_, err = ParseAPIKey("ci:table-host")
+	assert.Error(t, err)
+	_, err = ParseAPIKey("ci:superuser:" + hash)
+	assert.Error(t, err)
+	_, err = ParseAPIKey(":admin:" + hash)
+	assert.Error(t, err)
+
+	// Plaintext keys are refused so secrets are never stored at rest
+	_, err = ParseAPIKey("ci:admin:secret")
+	assert.Error(t, err)
+}
+
+func TestNewKeyRingRejectsDuplicates(t *testing.T) {
+	_, err := NewKeyRing([]APIKey{
+		{ID: "a", Role: RolePlayer, Hash: HashAPIKey("one")},
+		{ID: "a", Role: RolePlayer, Hash: HashAPIKey("two")},
+	})
+	assert.Error(t, err)
+
+	_, err = NewKeyRing([]APIKey{
+		{ID: "a", Role: RolePlayer, Hash: HashAPIKey("one")},
+		{ID: "b", Role: RoleAdmin, Hash: HashAPIKey("one")},
+	})
+	assert.Error(t, err)
+}
+
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, RoleAdmin.Allows(RoleTableHost))
+	assert.True(t, RoleTableHost.Allows(RolePlayer))
+	assert.True(t, RolePlayer.Allows(RolePlayer))
+	assert.False(t, RolePlayer.Allows(RoleTableHost))
+	assert.False(t, RoleTableHost.Allows(RoleAdmin))
+	assert.False(t, Role("guest").Allows(RolePlayer))
+}
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	keys := testKeyRing(t)
+
+	router := gin.New()
+	router.Use(APIKeyMiddleware(keys))
+	router.GET("/state", RequireRole(keys, RolePlayer), func(c *gin.Context) {
+		key, _ := APIKeyFromContext(c)
+		c.JSON(http.StatusOK, gin.H{"key": key.ID})
+	})
+	router.DELETE("/game", RequireRole(keys, RoleAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(method, path string, header, value string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, request("GET", "/state", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, request("GET", "/state", "X-API-Key", "wrong").Code)
+
+	w := request("GET", "/state", "Authorization", "Bearer player-secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "web-client")
+	assert.Equal(t, http.StatusOK, request("GET", "/state?api_key=host-secret", "", "").Code)
+
+	assert.Equal(t, http.StatusForbidden, request("DELETE", "/game", "X-API-Key", "host-secret").Code)
+	assert.Equal(t, http.StatusOK, request("DELETE", "/game", "X-API-Key", "admin-secret").Code)
+}
+
+func TestRequireRoleWithoutKeysIsOpen(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	keys, err := NewKeyRing(nil)
+	require.NoError(t, err)
+	assert.False(t, keys.Enabled())
+
+	router := gin.New()
+	router.Use(APIKeyMiddleware(keys))
+	router.DELETE("/game", RequireRole(keys, RoleAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/game", nil)
+	req.Header.Set("X-API-Key", "anything")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestLogMiddlewareRecordsKeyIdentity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, recorded := observer.New(zapcore.InfoLevel)
+	registry, err := NewMetricsRegistry(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+	keys := testKeyRing(t)
+
+	router := gin.New()
+	router.Use(LogMiddleware(zap.New(core), registry))
+	router.Use(APIKeyMiddleware(keys))
+	router.GET("/state", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/state?api_key=host-secret&token=player-token&since=3", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	completed := recorded.FilterMessage("Request completed").All()
+	require.Len(t, completed, 1)
+	fields := completed[0].ContextMap()
+	assert.Equal(t, "dealer-bot", fields["api_key_id"])
+	assert.Equal(t, "table-host", fields["api_key_role"])
+
+	for _, entry := range recorded.All() {
+		query := entry.ContextMap()["query"].(string)
+		assert.NotContains(t, query, "host-secret")
+		assert.NotContains(t, query, "player-token")
+		assert.Contains(t, query, "since=3")
+	}
+}
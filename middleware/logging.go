@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -29,7 +30,7 @@ func LogMiddleware(logger *zap.Logger, metricsRegistry *MetricsRegistry) gin.Han
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
+		query := redactQuery(c.Request.URL.RawQuery)
 		userAgent := c.Request.UserAgent()
 		clientIP := c.ClientIP()
 		method := c.Request.Method
@@ -67,6 +68,16 @@ func LogMiddleware(logger *zap.Logger, metricsRegistry *MetricsRegistry) gin.Han
 			attrs = append(attrs, attribute.String("game_id", gameID))
 		}
 
+		// The key is known only after authentication has run further down the chain
+		keyID, keyRole := "", ""
+		if key, authenticated := APIKeyFromContext(c); authenticated {
+			keyID, keyRole = key.ID, string(key.Role)
+			attrs = append(attrs,
+				attribute.String("api_key_id", keyID),
+				attribute.String("api_key_role", keyRole),
+			)
+		}
+
 		metricsRegistry.HttpRequestsTotal.Add(c.Request.Context(), 1, metric.WithAttributes(attrs...))
 		metricsRegistry.HttpRequestDuration.Record(c.Request.Context(), latency.Seconds(), metric.WithAttributes(attrs...))
 
@@ -89,9 +100,36 @@ func LogMiddleware(logger *zap.Logger, metricsRegistry *MetricsRegistry) gin.Han
 			zap.String("user_agent", userAgent),
 			zap.String("client_ip", clientIP),
 			zap.String("game_id", gameID),
+			zap.String("api_key_id", keyID),
+			zap.String("api_key_role", keyRole),
 			zap.Int("status_code", status),
 			zap.Duration("latency", latency),
 			zap.String("latency_human", latency.String()),
 		)
 	}
-}
\ No newline at end of file
+}
+
+// redactedQueryParams are query parameters that carry credentials and must never be logged.
+var redactedQueryParams = []string{"api_key", "token"}
+
+// redactQuery masks credential values in a raw query string before it is logged.
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "[unparseable query]"
+	}
+	redacted := false
+	for _, param := range redactedQueryParams {
+		if values.Has(param) {
+			values.Set(param, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return rawQuery
+	}
+	return values.Encode()
+}
@@ -0,0 +1,136 @@
+package models
+
+import "fmt"
+
+// maxBuyIn caps a single purchase of chips.
+const maxBuyIn = 1000000
+
+// Ledger entry types, one for every way chips move between a player and the table.
+const (
+	LedgerBuyIn           = "buy_in"
+	LedgerBet             = "bet"
+	LedgerBetReturned     = "bet_returned"
+	LedgerSplit           = "split"
+	LedgerDouble          = "double"
+	LedgerInsurance       = "insurance"
+	LedgerPayout          = "payout"
+	LedgerInsurancePayout = "insurance_payout"
+)
+
+// LedgerEntry records a single movement of a player's chips so their bankroll can be audited.
+type LedgerEntry struct {
+	Seq     int    `json:"seq"` // Event that moved the chips
+	Type    string `json:"type"`
+	Hand    int    `json:"hand,omitempty"` // Hand the chips were staked on or won by
+	Amount  int    `json:"amount"`         // Positive when chips come to the player, negative when staked
+	Balance int    `json:"balance"`        // Bankroll after the entry
+}
+
+// PlayerBuyIn adds chips to a player's bankroll. Chips can be bought at any time.
+func (g *Game) PlayerBuyIn(playerID string, amount int) error {
+	player := g.GetPlayer(playerID)
+	if player == nil || player == g.Dealer {
+		return fmt.Errorf("player not found")
+	}
+	if amount < 1 || amount > maxBuyIn {
+		return fmt.Errorf("buy-in must be 1-%d chips", maxBuyIn)
+	}
+
+	defer g.record(GameEvent{Type: EventChipsBought, PlayerID: playerID, Amount: amount})
+	g.moveChips(player, LedgerBuyIn, 0, amount)
+	return nil
+}
+
+// PlaceBet stakes chips on the next blackjack or Glitchjack deal, within the table limits.
+// A new bet replaces any earlier one, which is returned first; a bet of 0 withdraws it.
+func (g *Game) PlaceBet(playerID string, amount int) error {
+	player := g.GetPlayer(playerID)
+	if player == nil || player == g.Dealer {
+		return fmt.Errorf("player not found")
+	}
+	if g.GameType != Blackjack && g.GameType != Glitchjack {
+		return fmt.Errorf("bets are only taken at blackjack and glitchjack tables")
+	}
+	if g.Status != GameWaiting {
+		return fmt.Errorf("bets can only be placed before the deal")
+	}
+	rules := g.Rules()
+	if amount != 0 && (amount < rules.MinBet || amount > rules.MaxBet) {
+		return fmt.Errorf("bet must be between %d and %d", rules.MinBet, rules.MaxBet)
+	}
+	if amount > player.Bankroll+player.Bet {
+		return fmt.Errorf("not enough chips to bet %d", amount)
+	}
+
+	defer g.record(GameEvent{Type: EventBetPlaced, PlayerID: playerID, Amount: amount})
+	if player.Bet > 0 {
+		g.moveChips(player, LedgerBetReturned, 0, player.Bet)
+	}
+	player.Bet = amount
+	if amount > 0 {
+		g.moveChips(player, LedgerBet, 0, -amount)
+	}
+	return nil
+}
+
+// moveChips adjusts a player's bankroll and records the movement against the event about to be logged.
+func (g *Game) moveChips(player *Player, kind string, hand, amount int) {
+	player.Bankroll += amount
+	player.Ledger = append(player.Ledger, LedgerEntry{
+		Seq:     len(g.Events) + 1,
+		Type:    kind,
+		Hand:    hand,
+		Amount:  amount,
+		Balance: player.Bankroll,
+	})
+}
+
+// stake takes an extra wager for a split, double or insurance, failing if the player cannot cover it.
+func (g *Game) stake(player *Player, kind string, hand, amount int) error {
+	if amount > player.Bankroll {
+		return fmt.Errorf("not enough chips to cover the %s", kind)
+	}
+	if amount > 0 {
+		g.moveChips(player, kind, hand, -amount)
+	}
+	return nil
+}
+
+// settleBlackjack pays out every hand and insurance bet once a blackjack or Glitchjack game is finished.
+func (g *Game) settleBlackjack() {
+	results := g.GetHandResults()
+	for _, player := range g.Players {
+		result := results[player.ID]
+		if result == nil {
+			continue
+		}
+		for _, hand := range result.Hands {
+			if hand.Payout > 0 {
+				g.moveChips(player, LedgerPayout, hand.Hand, hand.Payout)
+			}
+		}
+		if result.InsurancePayout > 0 {
+			g.moveChips(player, LedgerInsurancePayout, 0, result.InsurancePayout)
+		}
+	}
+}
+
+// handPayout returns the chips a settled hand returns to the player, stake included.
+func (r BlackjackRules) handPayout(result string, bet int) int {
+	switch result {
+	case "blackjack":
+		win, stake, err := r.Payout()
+		if err != nil {
+			return 2 * bet
+		}
+		return bet + bet*win/stake
+	case "even_money", "win":
+		return 2 * bet
+	case "push":
+		return bet
+	case "surrender":
+		return bet / 2
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBettingBlackjackGame seats the named players with 1000 chips each and the ranks on top of the deck,
+// dealt as in newStackedBlackjackGame once bets are placed.
+func newBettingBlackjackGame(t *testing.T, top []Rank, names ...string) *Game {
+	game := NewGame(1)
+	for _, name := range names {
+		player := game.AddPlayer(name)
+		require.NotNil(t, player)
+		require.NoError(t, game.PlayerBuyIn(player.ID, 1000))
+	}
+	stackDeck(game, top)
+	return game
+}
+
+func TestPlaceBetLimits(t *testing.T) {
+	game := newBettingBlackjackGame(t, nil, "Alice")
+	alice := game.Players[0]
+
+	assert.Error(t, game.PlayerBuyIn(alice.ID, 0))
+	assert.Error(t, game.PlaceBet("nobody", 50))
+	assert.EqualError(t, game.PlaceBet(alice.ID, 5), "bet must be between 10 and 500")
+	assert.Error(t, game.PlaceBet(alice.ID, 501))
+
+	require.NoError(t, game.PlaceBet(alice.ID, 100))
+	require.NoError(t, game.PlaceBet(alice.ID, 200), "a new bet replaces the old one")
+	assert.Equal(t, 200, alice.Bet)
+	assert.Equal(t, 800, alice.Bankroll)
+
+	types := []string{}
+	for _, entry := range alice.Ledger {
+		types = append(types, entry.Type)
+	}
+	assert.Equal(t, []string{LedgerBuyIn, LedgerBet, LedgerBetReturned, LedgerBet}, types)
+	assert.Equal(t, 800, alice.Ledger[len(alice.Ledger)-1].Balance)
+
+	poor := game.AddPlayer("Bob")
+	require.NoError(t, game.PlayerBuyIn(poor.ID, 20))
+	assert.EqualError(t, game.PlaceBet(poor.ID, 50), "not enough chips to bet 50")
+
+	require.NoError(t, game.StartBlackjackGame())
+	assert.Error(t, game.PlaceBet(alice.ID, 100), "bets close at the deal")
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, alice.Ledger, replayed.Players[0].Ledger)
+	assert.Error(t, NewGameWithType(1, Standard, War, 2).PlaceBet("", 10))
+}
+
+func TestSettleBlackjackPayouts(t *testing.T) {
+	// Alice is dealt a blackjack, Bob nineteen and Carol sixteen against a dealer eighteen
+	game := newBettingBlackjackGame(t, []Rank{Ace, Ten, Nine, Ten, King, Nine, Six, Eight}, "Alice", "Bob", "Carol")
+	alice, bob, carol := game.Players[0], game.Players[1], game.Players[2]
+	for _, player := range game.Players {
+		require.NoError(t, game.PlaceBet(player.ID, 100))
+	}
+	require.NoError(t, game.StartBlackjackGame())
+	assert.Equal(t, 100, alice.Hands[0].Bet)
+
+	require.NoError(t, game.PlayerStand(alice.ID))
+	require.NoError(t, game.PlayerStand(bob.ID))
+	require.NoError(t, game.PlayerStand(carol.ID))
+	require.Equal(t, GameFinished, game.Status)
+
+	assert.Equal(t, 1150, alice.Bankroll, "blackjack pays 3:2")
+	assert.Equal(t, 1100, bob.Bankroll, "a win pays 1:1")
+	assert.Equal(t, 900, carol.Bankroll)
+	results := game.GetHandResults()
+	assert.Equal(t, 250, results[alice.ID].Hands[0].Payout)
+	assert.Equal(t, LedgerPayout, alice.Ledger[len(alice.Ledger)-1].Type)
+}
+
+func TestSplitDoubleAndInsuranceStakes(t *testing.T) {
+	// Alice splits eights against a dealer seven, doubling the second hand on a Three
+	game := newBettingBlackjackGame(t, []Rank{Eight, Ten, Eight, Seven, Three, Ten, Two}, "Alice")
+	alice := game.Players[0]
+	require.NoError(t, game.PlaceBet(alice.ID, 100))
+	require.NoError(t, game.StartBlackjackGame())
+
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	require.NoError(t, game.PlayerStand(alice.ID))
+	require.NoError(t, game.PlayerDouble(alice.ID))
+	assert.Equal(t, []int{100, 200}, []int{alice.Hands[0].Bet, alice.Hands[1].Bet})
+	require.Equal(t, GameFinished, game.Status)
+
+	// Eleven loses to seventeen, then the doubled twenty wins
+	results := game.GetHandResults()[alice.ID]
+	assert.Equal(t, 0, results.Hands[0].Payout)
+	assert.Equal(t, 400, results.Hands[1].Payout)
+	assert.Equal(t, 1100, alice.Bankroll)
+
+	// Insurance costs half the bet and pays 2:1 against a dealer blackjack
+	game = newBettingBlackjackGame(t, []Rank{Ten, King, Nine, Ace}, "Alice")
+	alice = game.Players[0]
+	require.NoError(t, game.PlaceBet(alice.ID, 100))
+	require.NoError(t, game.StartBlackjackGame())
+	require.NoError(t, game.PlayerInsure(alice.ID))
+	assert.Equal(t, 850, alice.Bankroll)
+	require.NoError(t, game.PlayerStand(alice.ID))
+	assert.Equal(t, 150, game.GetHandResults()[alice.ID].InsurancePayout)
+	assert.Equal(t, 1000, alice.Bankroll, "insurance covers the lost hand")
+
+	// A player who cannot cover a double keeps the hand as it is
+	game = NewGame(1)
+	alice = game.AddPlayer("Alice")
+	require.NoError(t, game.PlayerBuyIn(alice.ID, 150))
+	require.NoError(t, game.PlaceBet(alice.ID, 100))
+	require.NoError(t, game.StartBlackjackGame())
+	assert.EqualError(t, game.PlayerDouble(alice.ID), "not enough chips to cover the double")
+	assert.Len(t, alice.Hand, 2)
+	assert.Equal(t, 100, alice.Hands[0].Bet)
+}
@@ -1,6 +1,9 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // StartBlackjackGame begins a blackjack game by dealing initial cards to all players.
 // Each player and dealer receives 2 cards, with dealer's second card face down.
@@ -9,14 +12,16 @@ func (g *Game) StartBlackjackGame() error {
 		return fmt.Errorf("no players in game")
 	}
 	
+	g.openShoe()
 	g.Status = GameInProgress
 	g.CurrentPlayer = 0
+	defer g.record(GameEvent{Type: EventBlackjackStarted})
 	
 	// Deal initial two cards to each player and dealer
 	for i := 0; i < 2; i++ {
 		// Deal to players
 		for _, player := range g.Players {
-			card := g.DealToPlayer(player.ID, true) // Face up for players
+			card := g.dealToPlayer(player.ID, true) // Face up for players
 			if card == nil {
 				return fmt.Errorf("not enough cards in deck")
 			}
@@ -24,107 +29,493 @@ func (g *Game) StartBlackjackGame() error {
 		
 		// Deal to dealer (first card face down, second face up)
 		faceUp := i == 1
-		card := g.DealToPlayer("dealer", faceUp)
+		card := g.dealToPlayer("dealer", faceUp)
 		if card == nil {
 			return fmt.Errorf("not enough cards in deck")
 		}
 	}
 	
+	// Every player starts with a single hand, which splitting can turn into several
+	for _, player := range g.Players {
+		player.Hands = []*BlackjackHand{{Cards: player.Hand, Bet: player.Bet}}
+		player.ActiveHand = 0
+		player.Insured = false
+		player.InsuranceBet = 0
+	}
+	
 	return nil
 }
 
+// PlayerHit deals one card to the hand the player has in play. A busted hand stays in play
+// until the player stands, which moves on to their next split hand or the next player.
 func (g *Game) PlayerHit(playerID string) error {
-	player := g.GetPlayer(playerID)
-	if player == nil {
-		return fmt.Errorf("player not found")
+	player, hand, err := g.blackjackTurn(playerID)
+	if err != nil {
+		return err
 	}
-	
-	if g.Status != GameInProgress {
-		return fmt.Errorf("game is not in progress")
+	if hand.splitAces() {
+		return fmt.Errorf("split aces take one card only")
+	}
+	if value, _ := hand.Value(); value > 21 {
+		return fmt.Errorf("hand is busted")
+	}
+	if hand.Doubled {
+		return fmt.Errorf("a doubled hand can only re-double or stand")
+	}
+	if err := g.peekForBlackjack(); err != nil {
+		return err
 	}
 	
-	card := g.DealToPlayer(playerID, true)
-	if card == nil {
+	if g.dealToHand(player, hand) == nil {
 		return fmt.Errorf("no cards remaining in deck")
 	}
+	g.record(GameEvent{Type: EventPlayerHit, PlayerID: playerID})
 	
 	return nil
 }
 
+// PlayerStand finishes the hand the player has in play and moves on to their next split hand,
+// the next player, or the dealer once every player has finished.
 func (g *Game) PlayerStand(playerID string) error {
-	player := g.GetPlayer(playerID)
-	if player == nil {
-		return fmt.Errorf("player not found")
+	player, _, err := g.blackjackTurn(playerID)
+	if err != nil {
+		return err
 	}
-	
-	if g.Status != GameInProgress {
-		return fmt.Errorf("game is not in progress")
+	if err := g.peekForBlackjack(); err != nil {
+		return err
 	}
 	
-	// Move to next player or dealer
-	g.CurrentPlayer++
-	if g.CurrentPlayer >= len(g.Players) {
-		// All players finished, play dealer
-		return g.PlayDealer()
-	}
+	g.finishBlackjackHand(player)
+	g.record(GameEvent{Type: EventPlayerStood, PlayerID: playerID})
 	
 	return nil
 }
 
-// PlayDealer executes the dealer's turn according to standard blackjack rules.
-// Dealer hits on 16 and below, stands on 17 and above, then finishes the game.
+// PlayDealer executes the dealer's turn according to the table rules.
+// Dealer hits on 16 and below and stands on 17 and above, hitting soft 17 under H17, then finishes the game.
 func (g *Game) PlayDealer() error {
+	g.playDealer()
+	g.record(GameEvent{Type: EventDealerPlayed})
+	return nil
+}
+
+// playDealer runs the dealer's turn without recording an event, so a stand that
+// triggers the dealer is logged as a single action.
+func (g *Game) playDealer() {
 	// Reveal dealer's hole card
 	if len(g.Dealer.Hand) > 0 {
 		g.Dealer.Hand[0].FaceUp = true
 	}
 	
 	// Dealer hits on 16 or less, stands on 17 or more
-	for {
-		value, _ := g.Dealer.BlackjackHandValue()
-		if value >= 17 {
-			break
-		}
-		
-		card := g.DealToPlayer("dealer", true)
+	rules := g.Rules()
+	for rules.dealerDraws(g.Dealer.Hand) {
+		card := g.dealToPlayer("dealer", true)
 		if card == nil {
 			break
 		}
 	}
 	
 	g.Status = GameFinished
+	g.settleBlackjack()
+}
+
+// maxBlackjackHands is the most hands any table lets a player split into, counting re-splits.
+const maxBlackjackHands = 4
+
+// BlackjackHand is one of a player's blackjack hands. Players start with one and gain another each time they split.
+type BlackjackHand struct {
+	Cards       []*Card `json:"cards"`
+	Bet         int     `json:"bet,omitempty"`   // Chips staked on the hand, doubled along with it
+	Split       bool    `json:"split,omitempty"` // Made by a split, so two cards totalling 21 are not a blackjack
+	Doubled     bool    `json:"doubled,omitempty"`
+	Redoubles   int     `json:"redoubles,omitempty"` // Further doubles after the first, Spanish 21 only
+	Surrendered bool    `json:"surrendered,omitempty"`
+	Stood       bool    `json:"stood,omitempty"` // Finished, whether by standing, doubling, surrendering or splitting aces
+}
+
+// Value returns the hand's best total and whether it is a natural blackjack.
+func (h *BlackjackHand) Value() (int, bool) {
+	total, _ := blackjackTotal(h.Cards)
+	return total, total == 21 && len(h.Cards) == 2 && !h.Split
+}
+
+// splitAces reports whether the hand was made by splitting aces, which take one card only.
+func (h *BlackjackHand) splitAces() bool {
+	return h.Split && len(h.Cards) > 0 && h.Cards[0].Rank == Ace
+}
+
+// BlackjackHandResult is the outcome of a single hand once the dealer has played.
+type BlackjackHandResult struct {
+	Hand    int    `json:"hand"`
+	Value   int    `json:"value"`
+	Result  string `json:"result"` // blackjack, even_money, win, push, lose, bust or surrender
+	Doubled bool   `json:"doubled,omitempty"`
+	Bonus   string `json:"bonus,omitempty"` // Spanish 21 bonus the hand was paid, such as five_card_21 or 777_suited
+	Bet     int    `json:"bet,omitempty"`
+	Payout  int    `json:"payout,omitempty"` // Chips returned to the player, stake included
+}
+
+// BlackjackResult collects a player's hand outcomes and how any insurance bet fared.
+type BlackjackResult struct {
+	PlayerID  string                `json:"player_id"`
+	Hands     []BlackjackHandResult `json:"hands"`
+	Insurance string                `json:"insurance,omitempty"` // win or lose when the player took insurance rather than even money
+	// InsurancePayout is what a winning insurance bet returns at 2:1, stake included
+	InsurancePayout int `json:"insurance_payout,omitempty"`
+}
+
+// PlayerSplit splits a pair into two hands of one card each, as many times as the table allows, and
+// deals the hand in play its second card; the new hand gets its second card when it comes into play.
+// Split aces take one card each and stand unless the table allows re-splitting a second ace.
+func (g *Game) PlayerSplit(playerID string) error {
+	player, hand, err := g.blackjackTurn(playerID)
+	if err != nil {
+		return err
+	}
+	if len(hand.Cards) != 2 || hand.Cards[0].Rank != hand.Cards[1].Rank {
+		return fmt.Errorf("only a pair can be split")
+	}
+	rules := g.Rules()
+	if len(player.Hands) > rules.MaxSplits {
+		return fmt.Errorf("no more splits are allowed at this table")
+	}
+	if hand.splitAces() && !rules.ResplitAces {
+		return fmt.Errorf("split aces cannot be split again")
+	}
+	if err := g.peekForBlackjack(); err != nil {
+		return err
+	}
+	if g.Deck.IsEmpty() {
+		return fmt.Errorf("no cards remaining in deck")
+	}
+	if err := g.stake(player, LedgerSplit, player.ActiveHand+1, hand.Bet); err != nil {
+		return err
+	}
+
+	defer g.record(GameEvent{Type: EventPlayerSplit, PlayerID: playerID})
+
+	split := &BlackjackHand{Cards: []*Card{hand.Cards[1]}, Bet: hand.Bet, Split: true}
+	hand.Cards = []*Card{hand.Cards[0]}
+	hand.Split = true
+	player.Hand = hand.Cards
+	rest := append([]*BlackjackHand{split}, player.Hands[player.ActiveHand+1:]...)
+	player.Hands = append(player.Hands[:player.ActiveHand+1], rest...)
+
+	g.dealToHand(player, hand)
+	if hand.splitAces() && !g.canResplit(player, hand) {
+		g.finishBlackjackHand(player)
+	}
+	return nil
+}
+
+// PlayerDouble doubles down on a two-card hand: the hand takes exactly one more card and stands.
+// Spanish 21 hands may double on any number of cards and re-double instead of standing.
+func (g *Game) PlayerDouble(playerID string) error {
+	player, hand, err := g.blackjackTurn(playerID)
+	if err != nil {
+		return err
+	}
+	spanish21 := g.spanish21()
+	if len(hand.Cards) != 2 && !spanish21 {
+		return fmt.Errorf("can only double down on the first two cards of a hand")
+	}
+	if value, _ := hand.Value(); value > 21 {
+		return fmt.Errorf("hand is busted")
+	}
+	if hand.Redoubles >= maxSpanish21Redoubles {
+		return fmt.Errorf("a hand can be re-doubled at most %d times", maxSpanish21Redoubles)
+	}
+	if hand.splitAces() {
+		return fmt.Errorf("split aces take one card only")
+	}
+	if hand.Split && !g.Rules().DoubleAfterSplit {
+		return fmt.Errorf("doubling after a split is not allowed at this table")
+	}
+	if err := g.peekForBlackjack(); err != nil {
+		return err
+	}
+	if g.Deck.IsEmpty() {
+		return fmt.Errorf("no cards remaining in deck")
+	}
+	if err := g.stake(player, LedgerDouble, player.ActiveHand, hand.Bet); err != nil {
+		return err
+	}
+
+	defer g.record(GameEvent{Type: EventPlayerDoubled, PlayerID: playerID})
+	if hand.Doubled {
+		hand.Redoubles++
+	}
+	hand.Bet *= 2
+	hand.Doubled = true
+	g.dealToHand(player, hand)
+
+	// Spanish 21 hands may re-double until they reach 21, bust or run out of re-doubles
+	if value, _ := hand.Value(); !spanish21 || value >= 21 || hand.Redoubles >= maxSpanish21Redoubles {
+		g.finishBlackjackHand(player)
+	}
+	return nil
+}
+
+// PlayerInsure takes insurance against a dealer blackjack when the dealer shows an Ace, staking half
+// the hand's bet. Any player may insure before acting on their hand; insuring a blackjack takes even
+// money instead, which needs no extra stake.
+func (g *Game) PlayerInsure(playerID string) error {
+	player := g.GetPlayer(playerID)
+	if player == nil || player == g.Dealer {
+		return fmt.Errorf("player not found")
+	}
+	if g.Status != GameInProgress {
+		return fmt.Errorf("game is not in progress")
+	}
+	if g.Dealer == nil || len(g.Dealer.Hand) < 2 || g.Dealer.Hand[1].Rank != Ace {
+		return fmt.Errorf("insurance is only offered when the dealer shows an ace")
+	}
+	if player.Insured {
+		return fmt.Errorf("player is already insured")
+	}
+	if !player.untouchedBlackjackHand() {
+		return fmt.Errorf("insurance must be taken before acting on the hand")
+	}
+	hand := player.blackjackHands()[0]
+	if _, blackjack := hand.Value(); !blackjack {
+		if err := g.stake(player, LedgerInsurance, 0, hand.Bet/2); err != nil {
+			return err
+		}
+		player.InsuranceBet = hand.Bet / 2
+	}
+
+	defer g.record(GameEvent{Type: EventPlayerInsured, PlayerID: playerID})
+	player.Insured = true
+	return nil
+}
+
+// PlayerSurrender gives up the hand as the player's first decision, forfeiting half the bet.
+// Under late surrender the dealer peeks first and a surrendered hand still loses in full to a
+// dealer blackjack; early surrender comes before the peek and always saves half.
+func (g *Game) PlayerSurrender(playerID string) error {
+	player, hand, err := g.blackjackTurn(playerID)
+	if err != nil {
+		return err
+	}
+	rules := g.Rules()
+	if rules.Surrender == SurrenderNone {
+		return fmt.Errorf("surrender is not allowed at this table")
+	}
+	if !player.untouchedBlackjackHand() {
+		return fmt.Errorf("surrender is only allowed as the first decision on a hand")
+	}
+	if rules.Surrender == SurrenderLate {
+		if err := g.peekForBlackjack(); err != nil {
+			return err
+		}
+	}
+
+	defer g.record(GameEvent{Type: EventPlayerSurrendered, PlayerID: playerID})
+	hand.Surrendered = true
+	g.finishBlackjackHand(player)
 	return nil
 }
 
 // GetGameResult calculates the final outcome for each player in a finished blackjack game.
-// Returns a map of player IDs to results: "blackjack", "win", "push", "bust", or "lose".
+// Returns a map of player IDs to results: "blackjack", "even_money", "win", "push", "bust",
+// "surrender", or "lose". A player who split gets each hand's result in order, comma-separated.
 func (g *Game) GetGameResult() map[string]string {
 	if g.Status != GameFinished {
 		return map[string]string{"status": "game not finished"}
 	}
-	
+
 	results := make(map[string]string)
-	dealerValue, dealerBlackjack := g.Dealer.BlackjackHandValue()
-	dealerBusted := g.Dealer.IsBusted()
-	
+	for id, result := range g.GetHandResults() {
+		outcomes := make([]string, len(result.Hands))
+		for i, hand := range result.Hands {
+			outcomes[i] = hand.Result
+		}
+		results[id] = strings.Join(outcomes, ",")
+	}
+	return results
+}
+
+// GetHandResults returns every player's per-hand outcomes and insurance result, keyed by player ID.
+// Returns nil until the game is finished. A dealer blackjack beats every hand but another blackjack
+// and early surrenders.
+func (g *Game) GetHandResults() map[string]*BlackjackResult {
+	if g.Status != GameFinished || g.Dealer == nil {
+		return nil
+	}
+
+	dealer := BlackjackHand{Cards: g.Dealer.Hand}
+	dealerValue, dealerBlackjack := dealer.Value()
+	rules := g.Rules()
+	earlySurrender := rules.Surrender == SurrenderEarly
+	spanish21 := g.spanish21()
+
+	results := make(map[string]*BlackjackResult)
 	for _, player := range g.Players {
-		playerValue, playerBlackjack := player.BlackjackHandValue()
-		playerBusted := player.IsBusted()
-		
-		if playerBusted {
-			results[player.ID] = "bust"
-		} else if playerBlackjack && !dealerBlackjack {
-			results[player.ID] = "blackjack"
-		} else if dealerBusted {
-			results[player.ID] = "win"
-		} else if playerValue > dealerValue {
-			results[player.ID] = "win"
-		} else if playerValue == dealerValue {
-			results[player.ID] = "push"
-		} else {
-			results[player.ID] = "lose"
+		result := &BlackjackResult{PlayerID: player.ID, Hands: []BlackjackHandResult{}}
+		hands := player.blackjackHands()
+		if _, blackjack := hands[0].Value(); player.Insured && !(blackjack && len(hands) == 1) {
+			result.Insurance = "lose"
+			if dealerBlackjack {
+				result.Insurance = "win"
+				result.InsurancePayout = 3 * player.InsuranceBet
+			}
 		}
+
+		for i, hand := range hands {
+			value, blackjack := hand.Value()
+			outcome := "lose"
+			switch {
+			case hand.Surrendered && dealerBlackjack && !earlySurrender:
+				outcome = "lose"
+			case hand.Surrendered:
+				outcome = "surrender"
+			case value > 21:
+				outcome = "bust"
+			case blackjack && player.Insured:
+				outcome = "even_money"
+			case blackjack && dealerBlackjack && !spanish21:
+				outcome = "push"
+			case blackjack:
+				outcome = "blackjack"
+			case dealerBlackjack:
+				outcome = "lose"
+			case spanish21 && value == 21:
+				outcome = "win" // A player 21 beats any dealer 21 but a blackjack in Spanish 21
+			case dealerValue > 21 || value > dealerValue:
+				outcome = "win"
+			case value == dealerValue:
+				outcome = "push"
+			}
+			handResult := BlackjackHandResult{
+				Hand:    i,
+				Value:   value,
+				Result:  outcome,
+				Doubled: hand.Doubled,
+				Bet:     hand.Bet,
+				Payout:  rules.handPayout(outcome, hand.Bet),
+			}
+			if spanish21 && outcome == "win" {
+				if bonus, win, stake := spanish21Bonus(hand); bonus != "" {
+					handResult.Bonus = bonus
+					handResult.Payout = hand.Bet + hand.Bet*win/stake
+				}
+			}
+			result.Hands = append(result.Hands, handResult)
+		}
+		results[player.ID] = result
 	}
-	
 	return results
-}
\ No newline at end of file
+}
+
+// blackjackTurn checks the game is in progress and it is the player's turn, returning the player
+// and the hand they have in play.
+func (g *Game) blackjackTurn(playerID string) (*Player, *BlackjackHand, error) {
+	player := g.GetPlayer(playerID)
+	if player == nil || player == g.Dealer {
+		return nil, nil, fmt.Errorf("player not found")
+	}
+	if g.Status != GameInProgress {
+		return nil, nil, fmt.Errorf("game is not in progress")
+	}
+	if g.CurrentPlayer >= len(g.Players) || g.Players[g.CurrentPlayer] != player {
+		return nil, nil, fmt.Errorf("not your turn")
+	}
+
+	// Players who joined after the deal, or whose cards were set directly, play their hand as is
+	if len(player.Hands) == 0 {
+		player.Hands = player.blackjackHands()
+		player.ActiveHand = 0
+	}
+	return player, player.Hands[player.ActiveHand], nil
+}
+
+// dealToHand deals a face-up card to one of the player's hands, keeping Hand in step with the hand in play.
+func (g *Game) dealToHand(player *Player, hand *BlackjackHand) *Card {
+	card := g.drawCard()
+	if card == nil {
+		return nil
+	}
+	card.FaceUp = true
+	hand.Cards = append(hand.Cards, card)
+	player.Hand = player.Hands[player.ActiveHand].Cards
+	return card
+}
+
+// finishBlackjackHand stands the hand in play and brings the player's next split hand into play,
+// dealing its second card, or passes the turn on and plays the dealer once every player is done.
+func (g *Game) finishBlackjackHand(player *Player) {
+	player.Hands[player.ActiveHand].Stood = true
+	if player.ActiveHand+1 < len(player.Hands) {
+		player.ActiveHand++
+		hand := player.Hands[player.ActiveHand]
+		player.Hand = hand.Cards
+		g.dealToHand(player, hand)
+		if hand.splitAces() && !g.canResplit(player, hand) {
+			g.finishBlackjackHand(player)
+		}
+		return
+	}
+
+	g.CurrentPlayer++
+	if g.CurrentPlayer >= len(g.Players) {
+		g.playDealer()
+	}
+}
+
+// canResplit reports whether a split ace hand that drew another ace may be split again rather than standing.
+func (g *Game) canResplit(player *Player, hand *BlackjackHand) bool {
+	rules := g.Rules()
+	return rules.ResplitAces && len(player.Hands) <= rules.MaxSplits &&
+		len(hand.Cards) == 2 && hand.Cards[1].Rank == Ace
+}
+
+// peekForBlackjack has the dealer check a ten or ace up card for blackjack at tables that peek,
+// before the first decision that would put more money out. Insurance is offered before the peek.
+// A dealer blackjack reveals the hole card and ends the game, recorded as its own event.
+func (g *Game) peekForBlackjack() error {
+	if !g.Rules().DealerPeek || len(g.Dealer.Hand) < 2 || g.Dealer.Hand[1].BlackjackValue() < 10 {
+		return nil
+	}
+	dealer := BlackjackHand{Cards: g.Dealer.Hand}
+	if _, blackjack := dealer.Value(); !blackjack {
+		return nil
+	}
+
+	defer g.record(GameEvent{Type: EventDealerPeeked})
+	g.Dealer.Hand[0].FaceUp = true
+	g.CurrentPlayer = len(g.Players)
+	g.Status = GameFinished
+	g.settleBlackjack()
+	return fmt.Errorf("dealer has blackjack")
+}
+
+// blackjackHands returns the player's hands, treating the cards in Hand as a single hand staked with Bet if none were dealt.
+func (p *Player) blackjackHands() []*BlackjackHand {
+	if len(p.Hands) == 0 {
+		return []*BlackjackHand{{Cards: p.Hand, Bet: p.Bet}}
+	}
+	return p.Hands
+}
+
+// tableCards returns every card the player holds: those in each split hand and any in Hand that are
+// not, such as cards dealt to a player who joined after the deal.
+func (p *Player) tableCards() []*Card {
+	cards := []*Card{}
+	seen := map[*Card]bool{}
+	for _, hand := range append(p.Hands, &BlackjackHand{Cards: p.Hand}) {
+		for _, card := range hand.Cards {
+			if !seen[card] {
+				seen[card] = true
+				cards = append(cards, card)
+			}
+		}
+	}
+	return cards
+}
+
+// untouchedBlackjackHand reports whether the player has not yet hit, split, doubled or stood.
+func (p *Player) untouchedBlackjackHand() bool {
+	hands := p.blackjackHands()
+	return len(hands) == 1 && len(hands[0].Cards) == 2 && !hands[0].Stood
+}
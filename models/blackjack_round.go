@@ -0,0 +1,66 @@
+package models
+
+import "fmt"
+
+// NextRound clears a finished blackjack or Glitchjack round so the table can bet and deal again
+// from the same shoe. Every card on the table goes to the main discard pile, and once the cut card
+// has come out the discards and burn cards are shuffled back into the shoe and new burn cards are dealt.
+func (g *Game) NextRound() error {
+	return g.nextBlackjackRound(nil, "")
+}
+
+// nextBlackjackRound starts the next round, reshuffling into the given deck when replaying one.
+// A provably fair game deals each round from a fresh deck, committed to the given server seed or a new one,
+// rather than from a shoe: once a round's seed is revealed, the rest of a shoe dealt from it would be known.
+func (g *Game) nextBlackjackRound(deck *Deck, serverSeed string) error {
+	if g.GameType != Blackjack && g.GameType != Glitchjack {
+		return fmt.Errorf("rounds only apply to blackjack and glitchjack games")
+	}
+	if g.Status != GameFinished {
+		return fmt.Errorf("the current round has not finished")
+	}
+
+	event := GameEvent{Type: EventNextRound}
+	defer func() { g.record(event) }()
+
+	pile := g.DiscardPiles["main"]
+	for _, player := range append([]*Player{g.Dealer}, g.Players...) {
+		for _, card := range player.tableCards() {
+			card.FaceUp = true
+			pile.AddCard(card)
+		}
+		player.ClearHand()
+		player.Standing = false
+		player.Busted = false
+		player.Hands = nil
+		player.ActiveHand = 0
+		player.Insured = false
+		player.InsuranceBet = 0
+		player.Bet = 0
+	}
+
+	if g.Fair != nil {
+		if serverSeed == "" {
+			serverSeed = newServerSeed()
+		}
+		pile.Clear()
+		g.rotateFairSeeds(serverSeed)
+		event.Deck = g.Deck.snapshot()
+		event.Fair = g.Fair.snapshot()
+	} else if g.Shoe == nil || g.Shoe.CutCardReached {
+		if deck != nil {
+			g.Deck.restore(deck)
+		} else {
+			g.collectShoe()
+			g.shuffle()
+		}
+		pile.Clear()
+		event.Deck = g.Deck.snapshot()
+		g.fillShoe()
+	}
+
+	g.Round++
+	g.Status = GameWaiting
+	g.CurrentPlayer = 0
+	return nil
+}
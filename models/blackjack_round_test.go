@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playBlackjackRound deals a round and has every player stand on their first two cards.
+func playBlackjackRound(t *testing.T, game *Game) {
+	require.NoError(t, game.StartBlackjackGame())
+	for game.Status == GameInProgress {
+		require.NoError(t, game.PlayerStand(game.Players[game.CurrentPlayer].ID))
+	}
+}
+
+func TestNextRoundKeepsTheShoe(t *testing.T) {
+	game := NewGame(1)
+	alice := game.AddPlayer("Alice")
+	require.NoError(t, game.PlayerBuyIn(alice.ID, 100))
+	require.NoError(t, game.PlaceBet(alice.ID, 10))
+	assert.EqualError(t, game.NextRound(), "the current round has not finished")
+
+	playBlackjackRound(t, game)
+	assert.Equal(t, 1, game.Round)
+	remaining := game.Deck.RemainingCards()
+	dealt := len(alice.Hand) + len(game.Dealer.Hand)
+	assert.InDelta(t, float64(52-remaining)/52, game.ShoePenetration(), 0.001)
+
+	require.NoError(t, game.NextRound())
+	assert.Equal(t, 2, game.Round)
+	assert.Equal(t, GameWaiting, game.Status)
+	assert.Equal(t, remaining, game.Deck.RemainingCards(), "the shoe is not reshuffled before the cut card")
+	assert.Len(t, game.DiscardPiles["main"].Cards, dealt)
+	assert.Empty(t, alice.Hand)
+	assert.Empty(t, game.Dealer.Hand)
+	assert.Nil(t, alice.Hands)
+	assert.Zero(t, alice.Bet, "bets are placed afresh each round")
+	assert.NotZero(t, alice.Bankroll)
+
+	require.NoError(t, game.PlaceBet(alice.ID, 10))
+	playBlackjackRound(t, game)
+	assert.Equal(t, remaining-len(alice.Hand)-len(game.Dealer.Hand), game.Deck.RemainingCards())
+	assert.Error(t, NewGameWithType(1, Standard, War, 2).NextRound())
+}
+
+func TestNextRoundReshufflesAtTheCutCard(t *testing.T) {
+	rules := DefaultBlackjackRules()
+	rules.Penetration = 0.25
+	game := NewGame(1)
+	require.NoError(t, game.SetBlackjackRules(rules))
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+
+	reshuffled := false
+	for round := 0; round < 5 && !reshuffled; round++ {
+		playBlackjackRound(t, game)
+		require.NoError(t, game.NextRound())
+		reshuffled = game.Events[len(game.Events)-1].Deck != nil
+	}
+	require.True(t, reshuffled, "three players deal a quarter of a deck within a few rounds")
+	assert.Equal(t, 52, game.Deck.RemainingCards())
+	assert.Equal(t, 52, game.Shoe.Size)
+	assert.Empty(t, game.DiscardPiles["main"].Cards)
+	assert.Zero(t, game.ShoePenetration())
+
+	playBlackjackRound(t, game)
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.Round, replayed.Round)
+	assert.Equal(t, game.Deck.Cards, replayed.Deck.Cards)
+
+	rules.Penetration = 1
+	assert.Error(t, rules.Validate())
+}
+
+// shoeCardCount counts the cards in the deck, the main discard pile and the burn cards.
+func shoeCardCount(game *Game) int {
+	count := game.Deck.RemainingCards() + len(game.DiscardPiles["main"].Cards)
+	if game.Shoe != nil {
+		count += len(game.Shoe.Burned)
+	}
+	return count
+}
+
+func TestNextRoundCollectsEverySplitHand(t *testing.T) {
+	game := newRulesBlackjackGame(t, DefaultBlackjackRules(), []Rank{Eight, Ten, Eight, Seven, Three, Four}, "Alice")
+	total := game.Deck.RemainingCards() + 4
+	alice := game.Players[0]
+
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	require.NoError(t, game.PlayerStand(alice.ID))
+	require.NoError(t, game.PlayerStand(alice.ID))
+	require.Equal(t, GameFinished, game.Status)
+	require.Len(t, alice.Hands, 2)
+
+	require.NoError(t, game.NextRound())
+	assert.Equal(t, total, shoeCardCount(game), "cards from both split hands reach the discard pile")
+	assert.Len(t, game.DiscardPiles["main"].Cards, total-game.Deck.RemainingCards())
+}
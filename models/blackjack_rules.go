@@ -0,0 +1,189 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SurrenderRule is when, if ever, a blackjack player may give up half their bet.
+type SurrenderRule string
+
+const (
+	SurrenderNone  SurrenderRule = "none"
+	SurrenderLate  SurrenderRule = "late"  // Only once the dealer has checked for blackjack
+	SurrenderEarly SurrenderRule = "early" // Before the dealer checks, so it also saves half against a dealer blackjack
+)
+
+// Blackjack table presets accepted by BlackjackRulesPreset.
+const (
+	PresetVegasStrip   = "vegas_strip"
+	PresetAtlanticCity = "atlantic_city"
+	PresetEuropean     = "european"
+)
+
+// BlackjackRules are the house rules a blackjack or Glitchjack table is dealt under.
+type BlackjackRules struct {
+	Decks            int           `json:"decks"`
+	DealerHitsSoft17 bool          `json:"dealer_hits_soft_17"` // H17 when true, S17 otherwise
+	BlackjackPayout  string        `json:"blackjack_payout"`    // Odds a natural pays, such as "3:2" or "6:5"
+	DealerPeek       bool          `json:"dealer_peek"`         // Dealer checks for blackjack before play; false deals European no-hole-card style
+	DoubleAfterSplit bool          `json:"double_after_split"`
+	MaxSplits        int           `json:"max_splits"` // 0 disables splitting; 3 allows four hands
+	ResplitAces      bool          `json:"resplit_aces"`
+	Surrender        SurrenderRule `json:"surrender"`
+	MinBet           int           `json:"min_bet"`
+	MaxBet           int           `json:"max_bet"`
+	Penetration      float64       `json:"penetration"` // Share of the shoe dealt before the cut card comes out and it is reshuffled
+	BurnCards        int           `json:"burn_cards"`  // Cards dealt face down to the discards after every shuffle
+}
+
+// DefaultBlackjackRules returns the rules games get when none are chosen: a single deck, dealer
+// stands on soft 17, 3:2 blackjacks, no peek, doubling after splits, up to four hands, late surrender,
+// bets of 10 to 500, a cut card three quarters of the way into the shoe and no burn card.
+func DefaultBlackjackRules() BlackjackRules {
+	return BlackjackRules{
+		Decks:            1,
+		BlackjackPayout:  "3:2",
+		DoubleAfterSplit: true,
+		MaxSplits:        3,
+		Surrender:        SurrenderLate,
+		MinBet:           10,
+		MaxBet:           500,
+		Penetration:      0.75,
+	}
+}
+
+// BlackjackRulesPreset returns the rules for a named table preset.
+func BlackjackRulesPreset(name string) (BlackjackRules, error) {
+	switch name {
+	case PresetVegasStrip:
+		return BlackjackRules{
+			Decks:            4,
+			BlackjackPayout:  "3:2",
+			DealerPeek:       true,
+			DoubleAfterSplit: true,
+			MaxSplits:        3,
+			Surrender:        SurrenderNone,
+			MinBet:           25,
+			MaxBet:           5000,
+			Penetration:      0.75,
+		}, nil
+	case PresetAtlanticCity:
+		return BlackjackRules{
+			Decks:            8,
+			BlackjackPayout:  "3:2",
+			DealerPeek:       true,
+			DoubleAfterSplit: true,
+			MaxSplits:        3,
+			Surrender:        SurrenderLate,
+			MinBet:           15,
+			MaxBet:           2000,
+			Penetration:      0.8,
+		}, nil
+	case PresetEuropean:
+		return BlackjackRules{
+			Decks:           6,
+			BlackjackPayout: "3:2",
+			MaxSplits:       1,
+			Surrender:       SurrenderNone,
+			MinBet:          10,
+			MaxBet:          1000,
+			Penetration:     0.7,
+		}, nil
+	default:
+		return BlackjackRules{}, fmt.Errorf("unknown blackjack preset %q", name)
+	}
+}
+
+// Validate reports the first rule that is out of range.
+func (r BlackjackRules) Validate() error {
+	if r.Decks < 1 || r.Decks > 100 {
+		return fmt.Errorf("decks must be 1-100")
+	}
+	if _, _, err := r.Payout(); err != nil {
+		return err
+	}
+	if r.MaxSplits < 0 || r.MaxSplits > maxBlackjackHands-1 {
+		return fmt.Errorf("max splits must be 0-%d", maxBlackjackHands-1)
+	}
+	switch r.Surrender {
+	case SurrenderNone, SurrenderLate, SurrenderEarly:
+	default:
+		return fmt.Errorf("surrender must be none, late or early")
+	}
+	if r.MinBet < 1 || r.MaxBet < r.MinBet {
+		return fmt.Errorf("bets must have a minimum of at least 1 and a maximum no lower than it")
+	}
+	if r.Penetration < 0.25 || r.Penetration > 0.95 {
+		return fmt.Errorf("penetration must be 0.25-0.95")
+	}
+	if r.BurnCards < 0 || r.BurnCards > maxBurnCards {
+		return fmt.Errorf("burn cards must be 0-%d", maxBurnCards)
+	}
+	return nil
+}
+
+// Payout returns what a natural blackjack wins for the stake, so "3:2" is 3 and 2.
+func (r BlackjackRules) Payout() (int, int, error) {
+	win, stake, found := strings.Cut(r.BlackjackPayout, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("blackjack payout must look like 3:2")
+	}
+	w, err := strconv.Atoi(win)
+	if err != nil || w < 1 {
+		return 0, 0, fmt.Errorf("blackjack payout must look like 3:2")
+	}
+	s, err := strconv.Atoi(stake)
+	if err != nil || s < 1 {
+		return 0, 0, fmt.Errorf("blackjack payout must look like 3:2")
+	}
+	return w, s, nil
+}
+
+// SetBlackjackRules attaches table rules to a blackjack or Glitchjack game before it is dealt.
+func (g *Game) SetBlackjackRules(rules BlackjackRules) error {
+	if g.GameType != Blackjack && g.GameType != Glitchjack {
+		return fmt.Errorf("table rules only apply to blackjack and glitchjack games")
+	}
+	if g.Status != GameWaiting {
+		return fmt.Errorf("table rules cannot change once the game has started")
+	}
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+
+	defer g.record(GameEvent{Type: EventBlackjackRulesSet, Rules: &rules})
+	g.BlackjackRules = &rules
+	return nil
+}
+
+// Rules returns the table rules the game is dealt under, falling back to DefaultBlackjackRules.
+func (g *Game) Rules() BlackjackRules {
+	if g.BlackjackRules == nil {
+		return DefaultBlackjackRules()
+	}
+	return *g.BlackjackRules
+}
+
+// dealerDraws reports whether the dealer takes another card, hitting soft 17 under H17 rules.
+func (r BlackjackRules) dealerDraws(cards []*Card) bool {
+	total, soft := blackjackTotal(cards)
+	return total < 17 || (total == 17 && soft && r.DealerHitsSoft17)
+}
+
+// blackjackTotal returns the best total for the cards and whether an ace is still counted as 11.
+func blackjackTotal(cards []*Card) (int, bool) {
+	total, aces := 0, 0
+	for _, card := range cards {
+		total += card.BlackjackValue()
+		if card.Rank == Ace {
+			aces++
+		}
+	}
+	for aces > 0 && total > 21 {
+		total -= 10
+		aces--
+	}
+	return total, aces > 0
+}
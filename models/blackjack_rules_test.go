@@ -0,0 +1,140 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRulesBlackjackGame starts a blackjack game under the given rules with the ranks on top of the deck,
+// dealt as in newStackedBlackjackGame.
+func newRulesBlackjackGame(t *testing.T, rules BlackjackRules, top []Rank, names ...string) *Game {
+	game := NewGame(1)
+	require.NoError(t, game.SetBlackjackRules(rules))
+	for _, name := range names {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+	stackDeck(game, top)
+	require.NoError(t, game.StartBlackjackGame())
+	return game
+}
+
+func TestBlackjackRulesPresetsAndValidation(t *testing.T) {
+	for _, name := range []string{PresetVegasStrip, PresetAtlanticCity, PresetEuropean} {
+		rules, err := BlackjackRulesPreset(name)
+		require.NoError(t, err, name)
+		assert.NoError(t, rules.Validate(), name)
+	}
+	_, err := BlackjackRulesPreset("monte_carlo")
+	assert.Error(t, err)
+
+	rules := DefaultBlackjackRules()
+	require.NoError(t, rules.Validate())
+	win, stake, err := rules.Payout()
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, []int{win, stake})
+
+	for _, broken := range []func(*BlackjackRules){
+		func(r *BlackjackRules) { r.Decks = 0 },
+		func(r *BlackjackRules) { r.BlackjackPayout = "6-5" },
+		func(r *BlackjackRules) { r.BlackjackPayout = "0:1" },
+		func(r *BlackjackRules) { r.MaxSplits = maxBlackjackHands },
+		func(r *BlackjackRules) { r.Surrender = "sometimes" },
+	} {
+		rules := DefaultBlackjackRules()
+		broken(&rules)
+		assert.Error(t, rules.Validate())
+	}
+}
+
+func TestSetBlackjackRules(t *testing.T) {
+	game := NewGame(1)
+	rules, _ := BlackjackRulesPreset(PresetAtlanticCity)
+	require.NoError(t, game.SetBlackjackRules(rules))
+	assert.Equal(t, rules, game.Rules())
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, rules, replayed.Rules())
+
+	require.NotNil(t, game.AddPlayer("Alice"))
+	require.NoError(t, game.StartBlackjackGame())
+	assert.Error(t, game.SetBlackjackRules(rules), "rules are fixed once dealt")
+	assert.Error(t, NewGameWithType(1, Standard, War, 2).SetBlackjackRules(rules))
+	assert.Equal(t, DefaultBlackjackRules(), NewGame(1).Rules())
+}
+
+func TestDealerHitsSoft17(t *testing.T) {
+	// The dealer has Ace-Six, then a Two waits on the deck
+	rules := DefaultBlackjackRules()
+	game := newRulesBlackjackGame(t, rules, []Rank{Ten, Ace, Nine, Six, Two}, "Alice")
+	require.NoError(t, game.PlayerStand(game.Players[0].ID))
+	assert.Len(t, game.Dealer.Hand, 2, "S17 stands on soft 17")
+
+	rules.DealerHitsSoft17 = true
+	game = newRulesBlackjackGame(t, rules, []Rank{Ten, Ace, Nine, Six, Two}, "Alice")
+	require.NoError(t, game.PlayerStand(game.Players[0].ID))
+	require.Len(t, game.Dealer.Hand, 3, "H17 hits soft 17")
+	assert.Equal(t, "push", game.GetGameResult()[game.Players[0].ID])
+}
+
+func TestDealerPeekEndsGameBeforePlay(t *testing.T) {
+	rules, _ := BlackjackRulesPreset(PresetVegasStrip)
+	game := newRulesBlackjackGame(t, rules, []Rank{Eight, King, Eight, Ace}, "Alice")
+	alice := game.Players[0]
+
+	// Insurance comes before the peek; splitting the eights does not
+	require.NoError(t, game.PlayerInsure(alice.ID))
+	assert.EqualError(t, game.PlayerSplit(alice.ID), "dealer has blackjack")
+	assert.Equal(t, GameFinished, game.Status)
+	assert.True(t, game.Dealer.Hand[0].FaceUp)
+	assert.Equal(t, EventDealerPeeked, game.Events[len(game.Events)-1].Type)
+	assert.Len(t, alice.Hands, 1, "the split never happened")
+	assert.Equal(t, "win", game.GetHandResults()[alice.ID].Insurance)
+
+	// Without a peek the eights are split and both hands lose to the blackjack
+	game = newRulesBlackjackGame(t, DefaultBlackjackRules(), []Rank{Eight, King, Eight, Ace, Three, Four}, "Alice")
+	require.NoError(t, game.PlayerSplit(game.Players[0].ID))
+	require.NoError(t, game.PlayerStand(game.Players[0].ID))
+	require.NoError(t, game.PlayerStand(game.Players[0].ID))
+	assert.Equal(t, "lose,lose", game.GetGameResult()[game.Players[0].ID])
+}
+
+func TestSplitAndDoubleRestrictions(t *testing.T) {
+	rules, _ := BlackjackRulesPreset(PresetEuropean)
+	game := newRulesBlackjackGame(t, rules, []Rank{Eight, Ten, Eight, Seven, Eight, Three}, "Alice")
+	alice := game.Players[0]
+
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	assert.EqualError(t, game.PlayerSplit(alice.ID), "no more splits are allowed at this table")
+	assert.Error(t, game.PlayerDouble(alice.ID), "no doubling after a split")
+	assert.Error(t, game.PlayerSurrender(alice.ID), "no surrender at this table")
+}
+
+func TestResplitAces(t *testing.T) {
+	rules := DefaultBlackjackRules()
+	game := newRulesBlackjackGame(t, rules, []Rank{Ace, Ten, Ace, Seven, Ace, Five, Four}, "Alice")
+	require.NoError(t, game.PlayerSplit(game.Players[0].ID))
+	assert.Equal(t, GameFinished, game.Status, "split aces stand when re-splitting is not allowed")
+
+	rules.ResplitAces = true
+	game = newRulesBlackjackGame(t, rules, []Rank{Ace, Ten, Ace, Seven, Ace, Five, Four, Nine}, "Alice")
+	alice := game.Players[0]
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	assert.Equal(t, GameInProgress, game.Status, "ace-ace may be split again")
+	assert.Error(t, game.PlayerHit(alice.ID), "split aces take one card only")
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	assert.Len(t, alice.Hands, 3)
+	assert.Equal(t, GameFinished, game.Status, "each ace took one card and stood")
+}
+
+func TestEarlySurrenderBeatsDealerBlackjack(t *testing.T) {
+	rules := DefaultBlackjackRules()
+	rules.DealerPeek = true
+	rules.Surrender = SurrenderEarly
+	game := newRulesBlackjackGame(t, rules, []Rank{Ten, King, Six, Ace}, "Alice")
+
+	require.NoError(t, game.PlayerSurrender(game.Players[0].ID))
+	assert.Equal(t, "surrender", game.GetGameResult()[game.Players[0].ID])
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStartBlackjackGame(t *testing.T) {
@@ -228,4 +229,102 @@ func TestGetGameResult(t *testing.T) {
 	game.Status = GameInProgress
 	results = game.GetGameResult()
 	assert.Equal(t, "game not finished", results["status"])
-}
\ No newline at end of file
+}
+
+// newStackedBlackjackGame starts a blackjack game for the named players with the given ranks on top of the deck.
+// Cards are dealt one to each player then the dealer's hole card, then again with the dealer's up card.
+func newStackedBlackjackGame(t *testing.T, top []Rank, names ...string) *Game {
+	game := NewGame(1)
+	for _, name := range names {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+	stackDeck(game, top)
+	require.NoError(t, game.StartBlackjackGame())
+	return game
+}
+
+func TestPlayerSplitAndDouble(t *testing.T) {
+	game := newStackedBlackjackGame(t, []Rank{Eight, Ten, Eight, Seven, Three, Ten, Two}, "Alice")
+	alice := game.Players[0]
+
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	require.Len(t, alice.Hands, 2)
+	assert.Equal(t, alice.Hands[0].Cards, alice.Hand, "hand mirrors the hand in play")
+	assert.Len(t, alice.Hands[1].Cards, 1, "the second hand is dealt when it comes into play")
+
+	// Doubling 8-3 takes a Ten and moves on to the second hand, which gets its Two
+	require.NoError(t, game.PlayerDouble(alice.ID))
+	assert.True(t, alice.Hands[0].Doubled)
+	assert.True(t, alice.Hands[0].Stood)
+	assert.Equal(t, 1, alice.ActiveHand)
+	assert.Equal(t, Two, alice.Hand[1].Rank)
+	assert.Error(t, game.PlayerSplit(alice.ID), "8-2 is not a pair")
+	require.NoError(t, game.PlayerHit(alice.ID))
+	assert.Error(t, game.PlayerDouble(alice.ID), "three cards cannot double")
+	assert.Error(t, game.PlayerSurrender(alice.ID), "split hands cannot surrender")
+
+	require.NoError(t, game.PlayerStand(alice.ID))
+	assert.Equal(t, GameFinished, game.Status)
+	result := game.GetHandResults()[alice.ID]
+	require.Len(t, result.Hands, 2)
+	assert.Equal(t, BlackjackHandResult{Hand: 0, Value: 21, Result: "win", Doubled: true}, result.Hands[0])
+	assert.Contains(t, game.GetGameResult()[alice.ID], "win,")
+}
+
+func TestSplitAcesAndResplitLimit(t *testing.T) {
+	game := newStackedBlackjackGame(t, []Rank{Ace, Ten, Ace, Seven, Five, King}, "Alice")
+	alice := game.Players[0]
+
+	// Each ace takes one card and stands, and ace-king after a split is 21 but not a blackjack
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	assert.Equal(t, GameFinished, game.Status)
+	result := game.GetHandResults()[alice.ID]
+	assert.Equal(t, "lose", result.Hands[0].Result)
+	assert.Equal(t, "win", result.Hands[1].Result)
+	assert.Equal(t, "lose,win", game.GetGameResult()[alice.ID])
+
+	game = newStackedBlackjackGame(t, []Rank{Eight, Ten, Eight, Seven, Eight, Eight, Eight}, "Alice")
+	alice = game.Players[0]
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	require.NoError(t, game.PlayerSplit(alice.ID))
+	assert.Len(t, alice.Hands, maxBlackjackHands)
+	assert.Error(t, game.PlayerSplit(alice.ID))
+}
+
+func TestInsuranceAndEvenMoney(t *testing.T) {
+	game := newStackedBlackjackGame(t, []Rank{Ace, Nine, King, King, Nine, Ace}, "Alice", "Bob")
+	alice, bob := game.Players[0], game.Players[1]
+
+	require.NoError(t, game.PlayerInsure(alice.ID))
+	assert.Error(t, game.PlayerInsure(alice.ID), "already insured")
+	require.NoError(t, game.PlayerInsure(bob.ID), "any player may insure before acting")
+	assert.Error(t, game.PlayerHit(bob.ID), "not Bob's turn")
+
+	require.NoError(t, game.PlayerStand(alice.ID))
+	require.NoError(t, game.PlayerStand(bob.ID))
+	results := game.GetHandResults()
+	assert.Equal(t, "even_money", results[alice.ID].Hands[0].Result)
+	assert.Empty(t, results[alice.ID].Insurance, "even money replaces the insurance bet")
+	assert.Equal(t, "lose", results[bob.ID].Hands[0].Result, "a dealer blackjack beats eighteen")
+	assert.Equal(t, "win", results[bob.ID].Insurance)
+
+	game = newStackedBlackjackGame(t, []Rank{Nine, Ten, Nine, Seven}, "Alice")
+	assert.Error(t, game.PlayerInsure(game.Players[0].ID), "dealer shows a seven")
+}
+
+func TestLateSurrender(t *testing.T) {
+	game := newStackedBlackjackGame(t, []Rank{Ten, Ten, Six, Seven}, "Alice")
+	require.NoError(t, game.PlayerSurrender(game.Players[0].ID))
+	assert.Equal(t, GameFinished, game.Status)
+	assert.Equal(t, "surrender", game.GetGameResult()[game.Players[0].ID])
+
+	// Against a dealer blackjack the surrendered hand loses in full
+	game = newStackedBlackjackGame(t, []Rank{Ten, King, Six, Ace}, "Alice")
+	require.NoError(t, game.PlayerSurrender(game.Players[0].ID))
+	assert.Equal(t, "lose", game.GetGameResult()[game.Players[0].ID])
+
+	game = newStackedBlackjackGame(t, []Rank{Two, Ten, Three, Seven, Four}, "Alice")
+	require.NoError(t, game.PlayerHit(game.Players[0].ID))
+	assert.Error(t, game.PlayerSurrender(game.Players[0].ID))
+}
@@ -2,9 +2,8 @@ package models
 
 import (
 	"fmt"
-	"math/rand"
+	"math/rand/v2"
 	"strings"
-	"time"
 )
 
 // DeckType represents the different types of card decks supported by the API.
@@ -304,8 +303,12 @@ func ToCardWithImagesPtr(c *Card, baseURL string) CardWithImages {
 // GenerateDeckName creates a random, family-friendly name for new decks.
 // It combines a random adjective with a random noun to ensure memorable, unique names.
 func GenerateDeckName() string {
-	rand.Seed(time.Now().UnixNano())
-	adjective := SafeAdjectives[rand.Intn(len(SafeAdjectives))]
-	noun := SafeNouns[rand.Intn(len(SafeNouns))]
+	return generateDeckName(secureRand)
+}
+
+// generateDeckName picks a deck name with the given randomness, so seeded games get the same name every time.
+func generateDeckName(rng *rand.Rand) string {
+	adjective := SafeAdjectives[rng.IntN(len(SafeAdjectives))]
+	noun := SafeNouns[rng.IntN(len(SafeNouns))]
 	return adjective + " " + noun
 }
\ No newline at end of file
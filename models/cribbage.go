@@ -37,18 +37,28 @@ func (cp CribbagePhase) String() string {
 
 // CribbageState holds all game state specific to cribbage gameplay.
 // This includes phase tracking, scoring, and the crib collection.
+
 type CribbageState struct {
-	Phase         CribbagePhase `json:"phase"`
-	Dealer        int           `json:"dealer"`
-	Crib          []*Card       `json:"crib"`
-	Starter       *Card         `json:"starter"`
-	PlayedCards   []*Card       `json:"played_cards"`
-	PlayTotal     int           `json:"play_total"`
-	PlayCount     int           `json:"play_count"`
-	PlayerScores  []int         `json:"player_scores"`
-	GameScore     int           `json:"game_score"` // Target score (usually 121)
-	CurrentGo     bool          `json:"current_go"`
-	LastToPlay    int           `json:"last_to_play"`
+	Phase        CribbagePhase     `json:"phase"`
+	Dealer       int               `json:"dealer"`
+	Crib         []*Card           `json:"crib"`
+	Starter      *Card             `json:"starter"`
+	PlayedCards  []*Card           `json:"played_cards"`
+	PlayTotal    int               `json:"play_total"`
+	PlayCount    int               `json:"play_count"`
+	PlayerScores []int             `json:"player_scores"`
+	GameScore    int               `json:"game_score"` // Target score (usually 121)
+	CurrentGo    bool              `json:"current_go"`
+	LastToPlay   int               `json:"last_to_play"`
+	TeamScores   []int             `json:"team_scores,omitempty"` // Partnership scores when four play: seats 0 and 2, then seats 1 and 3
+	Winners      []string          `json:"winners,omitempty"`     // The winning player, or both partners, once the game is over
+	Pegged       [][]*Card         `json:"pegged,omitempty"`      // Cards each seat has played this hand, counted again at the show
+	LastPeg      *CribbagePeg      `json:"last_peg,omitempty"`    // Itemised points from the last card played, go or cut
+	Show         []CribbageShowing `json:"show,omitempty"`        // Hands and crib as counted at the last show
+	Board        []CribbagePegs    `json:"board"`                 // Front and back pegs of each side
+	PegHistory   []CribbagePegMove `json:"peg_history"`           // Every move of a front peg this game
+	Match        *CribbageMatch    `json:"match,omitempty"`
+	Claims       []CribbageClaim   `json:"claims,omitempty"` // Scores claimed at the last show in a muggins game
 }
 
 // ScoreCribbageHand calculates the cribbage score for the player's hand plus starter card.
@@ -58,25 +68,19 @@ func (p *Player) ScoreCribbageHand(starter *Card) int {
 		return 0
 	}
 	
-	// Create combined hand with starter card
-	allCards := make([]*Card, len(p.Hand))
-	copy(allCards, p.Hand)
-	if starter != nil {
-		allCards = append(allCards, starter)
-	}
-	
-	return scoreCribbageCards(allCards)
+	return CountCribbageHand(p.Hand, starter).Total
 }
 
-// StartCribbageGame initializes a new cribbage game with 2 players.
-// Deals 6 cards to each player and sets up the cribbage game state.
+// StartCribbageGame initializes a new cribbage game for 2 to 4 players with the first player dealing.
+// Two players are dealt 6 cards each, three or four players 5 each; four play as partners across the table.
 func (g *Game) StartCribbageGame() error {
-	if len(g.Players) != 2 {
-		return fmt.Errorf("cribbage requires exactly 2 players")
+	if len(g.Players) < 2 || len(g.Players) > 4 {
+		return fmt.Errorf("cribbage requires 2 to 4 players")
 	}
 	
 	g.GameType = Cribbage
 	g.Status = GameInProgress
+	defer g.record(GameEvent{Type: EventCribbageStarted})
 	g.CribbageState = &CribbageState{
 		Phase:        CribbageDeal,
 		Dealer:       0,
@@ -89,19 +93,46 @@ func (g *Game) StartCribbageGame() error {
 		CurrentGo:    false,
 		LastToPlay:   -1,
 	}
+	if len(g.Players) == 4 {
+		g.CribbageState.TeamScores = make([]int, 2)
+	}
+	g.CribbageState.Board = make([]CribbagePegs, g.cribbageSides())
+	g.CribbageState.PegHistory = []CribbagePegMove{}
+	g.CribbageState.Match = &CribbageMatch{
+		BestOf: g.CurrentCribbageRules().BestOf,
+		Points: make([]int, g.cribbageSides()),
+		Games:  []CribbageGameResult{},
+	}
 	
-	// Deal 6 cards to each player
-	for i := 0; i < 6; i++ {
+	return g.dealCribbageHand()
+}
+
+// dealCribbageHand deals each player's hand and opens the discard phase, starting left of the dealer.
+// With three players one card goes from the deck to the crib, since each player only discards one.
+func (g *Game) dealCribbageHand() error {
+	handSize := 4 + g.cribbageDiscards()
+	for i := 0; i < handSize; i++ {
 		for _, player := range g.Players {
-			card := g.DealToPlayer(player.ID, true)
+			card := g.dealToPlayer(player.ID, true)
 			if card == nil {
 				return fmt.Errorf("not enough cards in deck")
 			}
 		}
 	}
 	
+	if len(g.Players) == 3 {
+		card := g.drawCard()
+		if card == nil {
+			return fmt.Errorf("not enough cards in deck")
+		}
+		g.CribbageState.Crib = append(g.CribbageState.Crib, card)
+	}
+	
 	g.CribbageState.Phase = CribbageDiscard
-	g.CurrentPlayer = (g.CribbageState.Dealer + 1) % len(g.Players) // Non-dealer goes first
+	g.CribbageState.LastPeg = nil
+	g.CribbageState.Show = nil
+	g.CribbageState.Claims = nil
+	g.CurrentPlayer = (g.CribbageState.Dealer + 1) % len(g.Players) // Player left of the dealer goes first
 	
 	return nil
 }
@@ -109,170 +140,57 @@ func (g *Game) StartCribbageGame() error {
 // scoreCribbageCards calculates the total cribbage score for a collection of cards.
 // This implements all cribbage scoring rules: fifteens, pairs, runs, flush, and nobs.
 func scoreCribbageCards(cards []*Card) int {
-	if len(cards) == 0 {
-		return 0
-	}
-	
-	score := 0
-	
-	// Score fifteens (2 points each)
-	score += scoreFifteens(cards)
-	
-	// Score pairs (2 points each)
-	score += scorePairs(cards)
-	
-	// Score runs (1 point per card)
-	score += scoreRuns(cards)
-	
-	// Score flush (1 point per card if all same suit)
-	score += scoreFlush(cards)
-	
-	// Score nobs (1 point if jack matches starter suit)
-	score += scoreNobs(cards)
-	
-	return score
+	return countCribbageCards(cards).Total
 }
 
 func scoreFifteens(cards []*Card) int {
-	count := 0
-	n := len(cards)
-	
-	// Check all possible combinations of cards
-	for i := 1; i < (1 << n); i++ {
-		sum := 0
-		for j := 0; j < n; j++ {
-			if i&(1<<j) != 0 {
-				sum += cards[j].CribbageValue()
-			}
-		}
-		if sum == 15 {
-			count++
-		}
-	}
-	
-	return count * 2
+	var score CribbageScore
+	score.addFifteens(cards)
+	return score.Total
 }
 
 func scorePairs(cards []*Card) int {
-	rankCounts := make(map[Rank]int)
-	for _, card := range cards {
-		rankCounts[card.Rank]++
-	}
-	
-	score := 0
-	for _, count := range rankCounts {
-		if count >= 2 {
-			// n cards of same rank = n(n-1)/2 pairs
-			score += count * (count - 1) / 2 * 2
-		}
-	}
-	
-	return score
+	var score CribbageScore
+	score.addPairs(cards)
+	return score.Total
 }
 
 func scoreRuns(cards []*Card) int {
-	if len(cards) < 3 {
-		return 0
-	}
-	
-	rankCounts := make(map[Rank]int)
-	for _, card := range cards {
-		rankCounts[card.Rank]++
-	}
-	
-	// Find consecutive ranks
-	ranks := make([]Rank, 0, len(rankCounts))
-	for rank := range rankCounts {
-		ranks = append(ranks, rank)
-	}
-	
-	// Sort ranks
-	for i := 0; i < len(ranks)-1; i++ {
-		for j := i + 1; j < len(ranks); j++ {
-			if ranks[i] > ranks[j] {
-				ranks[i], ranks[j] = ranks[j], ranks[i]
-			}
-		}
-	}
-	
-	// Find longest consecutive sequence
-	longestRun := 0
-	currentRun := 1
-	multiplier := rankCounts[ranks[0]]
-	
-	for i := 1; i < len(ranks); i++ {
-		if int(ranks[i]) == int(ranks[i-1])+1 {
-			currentRun++
-			multiplier *= rankCounts[ranks[i]]
-		} else {
-			if currentRun >= 3 {
-				longestRun = currentRun
-				break
-			}
-			currentRun = 1
-			multiplier = rankCounts[ranks[i]]
-		}
-	}
-	
-	if currentRun >= 3 {
-		longestRun = currentRun
-	}
-	
-	if longestRun >= 3 {
-		return longestRun * multiplier
-	}
-	
-	return 0
+	var score CribbageScore
+	score.addRuns(cards)
+	return score.Total
 }
 
 func scoreFlush(cards []*Card) int {
-	if len(cards) < 4 {
-		return 0
-	}
-	
-	// Check if hand cards (excluding starter) are all same suit
-	handSuit := cards[0].Suit
-	handFlush := true
-	
-	// Assuming last card is starter if 5 cards, otherwise all are hand cards
-	handSize := len(cards)
-	if handSize == 5 {
-		handSize = 4 // First 4 are hand, last is starter
-	}
-	
-	for i := 1; i < handSize; i++ {
-		if cards[i].Suit != handSuit {
-			handFlush = false
-			break
-		}
-	}
-	
-	if !handFlush {
-		return 0
-	}
-	
-	// If 5 cards and all same suit, score 5
-	if len(cards) == 5 && cards[4].Suit == handSuit {
-		return 5
-	}
-	
-	// Otherwise score 4 for hand flush
-	return 4
+	var score CribbageScore
+	score.addFlush(cards)
+	return score.Total
 }
 
 func scoreNobs(cards []*Card) int {
-	if len(cards) != 5 {
-		return 0
+	var score CribbageScore
+	score.addNobs(cards)
+	return score.Total
+}
+
+// cribbageDiscards is how many cards each player lays away to the crib: two each when two play, otherwise one.
+func (g *Game) cribbageDiscards() int {
+	if len(g.Players) == 2 {
+		return 2
 	}
-	
-	starter := cards[4] // Last card is starter
-	
-	// Check if any jack in hand matches starter suit
-	for i := 0; i < 4; i++ {
-		if cards[i].Rank == Jack && cards[i].Suit == starter.Suit {
-			return 1
+	return 1
+}
+
+// nextCribbageSeat returns the next seat after the given one holding a card, or with a card that
+// fits on the count when playable is set, or -1 if there is none.
+func (g *Game) nextCribbageSeat(seat int, playable bool) int {
+	for i := 1; i <= len(g.Players); i++ {
+		next := (seat + i) % len(g.Players)
+		for _, card := range g.Players[next].Hand {
+			if !playable || g.CribbageState.PlayTotal+card.CribbagePlayValue() <= 31 {
+				return next
+			}
 		}
 	}
-	
-	return 0
-}
\ No newline at end of file
+	return -1
+}
@@ -2,10 +2,11 @@ package models
 
 import (
 	"fmt"
+	"time"
 )
 
-// CribbageDiscard handles players discarding 2 cards to the crib during the discard phase.
-// Once both players discard, it cuts the starter card and moves to the play phase.
+// CribbageDiscard handles players discarding to the crib during the discard phase: 2 cards each
+// when two play, otherwise 1. Once the crib has 4 cards, it cuts the starter card and moves to the play phase.
 func (g *Game) CribbageDiscard(playerID string, cardIndices []int) error {
 	if g.CribbageState == nil || g.CribbageState.Phase != CribbageDiscard {
 		return fmt.Errorf("not in discard phase")
@@ -16,15 +17,28 @@ func (g *Game) CribbageDiscard(playerID string, cardIndices []int) error {
 		return fmt.Errorf("player not found")
 	}
 	
-	if len(cardIndices) != 2 {
-		return fmt.Errorf("must discard exactly 2 cards")
+	discards := g.cribbageDiscards()
+	if len(cardIndices) != discards {
+		if discards == 1 {
+			return fmt.Errorf("must discard exactly 1 card")
+		}
+		return fmt.Errorf("must discard exactly %d cards", discards)
 	}
 	
 	// Validate indices and discard cards to crib
-	if len(player.Hand) != 6 {
-		return fmt.Errorf("player must have 6 cards to discard")
+	if len(player.Hand) != 4+discards {
+		return fmt.Errorf("player must have %d cards to discard", 4+discards)
+	}
+	
+	for _, index := range cardIndices {
+		if index < 0 || index >= len(player.Hand) {
+			return fmt.Errorf("invalid card index: %d", index)
+		}
 	}
 	
+	event := GameEvent{Type: EventCribbageDiscard, PlayerID: playerID, CardIndices: append([]int(nil), cardIndices...)}
+	defer func() { g.record(event) }()
+	
 	// Sort indices in descending order to avoid index shifting
 	for i := 0; i < len(cardIndices)-1; i++ {
 		for j := i + 1; j < len(cardIndices); j++ {
@@ -42,13 +56,14 @@ func (g *Game) CribbageDiscard(playerID string, cardIndices []int) error {
 		card := player.RemoveCard(index)
 		if card != nil {
 			g.CribbageState.Crib = append(g.CribbageState.Crib, card)
+			event.Cards = append(event.Cards, *card)
 		}
 	}
 	
-	// Check if both players have discarded
+	// Check if every player has discarded
 	if len(g.CribbageState.Crib) == 4 {
 		// Cut starter card
-		starter := g.Deck.Deal()
+		starter := g.drawCard()
 		if starter == nil {
 			return fmt.Errorf("no cards remaining for starter")
 		}
@@ -57,13 +72,17 @@ func (g *Game) CribbageDiscard(playerID string, cardIndices []int) error {
 		
 		// Check for "his heels" (Jack of same suit as starter = 2 points for dealer)
 		if starter.Rank == Jack {
-			dealerIndex := g.CribbageState.Dealer
-			g.CribbageState.PlayerScores[dealerIndex] += 2
+			var heels CribbageScore
+			heels.add(ScoreHisHeels, 2, starter)
+			g.peg(g.CribbageState.Dealer, heels, "cut")
+			if g.CribbageState.Phase == CribbageFinished {
+				return nil
+			}
 		}
 		
 		// Move to play phase
 		g.CribbageState.Phase = CribbagePlay
-		g.CurrentPlayer = (g.CribbageState.Dealer + 1) % len(g.Players) // Non-dealer plays first
+		g.CurrentPlayer = (g.CribbageState.Dealer + 1) % len(g.Players) // Player left of the dealer plays first
 	}
 	
 	return nil
@@ -104,24 +123,33 @@ func (g *Game) CribbagePlay(playerID string, cardIndex int) error {
 	
 	// Play the card
 	playedCard := player.RemoveCard(cardIndex)
+	defer g.record(GameEvent{Type: EventCribbagePlay, PlayerID: playerID, CardIndices: []int{cardIndex}, Cards: []Card{*playedCard}})
 	g.CribbageState.PlayedCards = append(g.CribbageState.PlayedCards, playedCard)
+	if len(g.CribbageState.Pegged) != len(g.Players) {
+		g.CribbageState.Pegged = make([][]*Card, len(g.Players))
+	}
+	g.CribbageState.Pegged[playerIndex] = append(g.CribbageState.Pegged[playerIndex], playedCard)
 	g.CribbageState.PlayTotal = newTotal
 	g.CribbageState.PlayCount++
 	g.CribbageState.LastToPlay = playerIndex
+	g.CribbageState.LastPeg = nil
 	
 	// Score pegging points
-	points := g.scorePegging()
-	g.CribbageState.PlayerScores[playerIndex] += points
+	g.peg(playerIndex, g.pegScore(), "play")
+	if g.CribbageState.Phase == CribbageFinished {
+		return nil
+	}
 	
 	// Check for end of play round or game
 	if newTotal == 31 || g.allHandsEmpty() {
 		g.resetPlayRound()
 	} else {
-		g.CurrentPlayer = (g.CurrentPlayer + 1) % len(g.Players)
+		// Players who have laid all their cards are passed over
+		g.CurrentPlayer = g.nextCribbageSeat(playerIndex, false)
 	}
 	
 	// Check if play phase is complete
-	if g.allHandsEmpty() && g.CribbageState.PlayTotal == 0 {
+	if g.CribbageState.Phase != CribbageFinished && g.allHandsEmpty() && g.CribbageState.PlayTotal == 0 {
 		g.CribbageState.Phase = CribbageShow
 		g.CurrentPlayer = (g.CribbageState.Dealer + 1) % len(g.Players) // Non-dealer shows first
 	}
@@ -160,65 +188,55 @@ func (g *Game) CribbageGo(playerID string) error {
 		return fmt.Errorf("you must play a card if possible")
 	}
 	
-	// Move to next player
-	g.CurrentPlayer = (g.CurrentPlayer + 1) % len(g.Players)
+	defer g.record(GameEvent{Type: EventCribbageGo, PlayerID: playerID})
+	g.CribbageState.LastPeg = nil
 	
-	// If opponent also can't play, current player gets 1 point for "go"
-	opponent := g.Players[g.CurrentPlayer]
-	opponentCanPlay := false
-	for _, card := range opponent.Hand {
-		if g.CribbageState.PlayTotal+card.CribbagePlayValue() <= 31 {
-			opponentCanPlay = true
-			break
-		}
+	// Play passes to the next player who can still lay a card on the count
+	if next := g.nextCribbageSeat(playerIndex, true); next >= 0 {
+		g.CurrentPlayer = next
+		return nil
 	}
 	
-	if !opponentCanPlay {
-		// Last to play gets 1 point for go
-		g.CribbageState.PlayerScores[g.CribbageState.LastToPlay] += 1
-		g.resetPlayRound()
-	}
+	// Nobody can play, so the last to play pegs 1 for the go as the count restarts
+	g.resetPlayRound()
 	
 	return nil
 }
 
 func (g *Game) CribbageShow() map[string]interface{} {
-	if g.CribbageState == nil || g.CribbageState.Phase != CribbageShow {
+	if g.CribbageState == nil || g.CribbageState.Phase != CribbageShow || g.CurrentCribbageRules().Muggins {
 		return nil
 	}
 	
 	scores := make(map[string]interface{})
+	defer g.record(GameEvent{Type: EventCribbageShow})
 	
-	// Score non-dealer's hand first
-	nonDealer := (g.CribbageState.Dealer + 1) % len(g.Players)
-	nonDealerScore := g.Players[nonDealer].ScoreCribbageHand(g.CribbageState.Starter)
-	g.CribbageState.PlayerScores[nonDealer] += nonDealerScore
-	scores[g.Players[nonDealer].ID] = nonDealerScore
-	
-	// Score dealer's hand
-	dealerScore := g.Players[g.CribbageState.Dealer].ScoreCribbageHand(g.CribbageState.Starter)
-	g.CribbageState.PlayerScores[g.CribbageState.Dealer] += dealerScore
-	scores[g.Players[g.CribbageState.Dealer].ID] = dealerScore
+	g.CribbageState.Show = []CribbageShowing{}
 	
-	// Score crib (dealer gets these points)
-	cribWithStarter := make([]*Card, len(g.CribbageState.Crib))
-	copy(cribWithStarter, g.CribbageState.Crib)
-	cribWithStarter = append(cribWithStarter, g.CribbageState.Starter)
-	cribScore := scoreCribbageCards(cribWithStarter)
-	g.CribbageState.PlayerScores[g.CribbageState.Dealer] += cribScore
-	scores["crib"] = cribScore
-	
-	// Check for game winner
-	for i, score := range g.CribbageState.PlayerScores {
-		if score >= g.CribbageState.GameScore {
-			g.Status = GameFinished
-			g.CribbageState.Phase = CribbageFinished
-			scores["winner"] = i
+	// Score hands from the dealer's left, the dealer's last; the game ends as soon as a side reaches the game score
+	dealer := g.CribbageState.Dealer
+	for i := 1; i <= len(g.Players); i++ {
+		seat := (dealer + i) % len(g.Players)
+		scores[g.Players[seat].ID] = g.countShowing(seat, false)
+		if g.CribbageState.Phase == CribbageFinished {
+			scores["winner"] = seat
 			return scores
 		}
 	}
 	
-	// Move to next hand
+	// Score crib (dealer gets these points)
+	scores["crib"] = g.countShowing(dealer, true)
+	if g.CribbageState.Phase == CribbageFinished {
+		scores["winner"] = dealer
+		return scores
+	}
+	
+	g.endCribbageShow()
+	return scores
+}
+
+// endCribbageShow clears the hands once they are counted and passes the deal to the left.
+func (g *Game) endCribbageShow() {
 	g.CribbageState.Dealer = (g.CribbageState.Dealer + 1) % len(g.Players)
 	g.CribbageState.Phase = CribbageDeal
 	
@@ -227,58 +245,48 @@ func (g *Game) CribbageShow() map[string]interface{} {
 		player.ClearHand()
 	}
 	g.CribbageState.Crib = []*Card{}
+	g.CribbageState.Pegged = nil
 	g.CribbageState.Starter = nil
 	g.CribbageState.PlayedCards = []*Card{}
 	g.CribbageState.PlayTotal = 0
 	g.CribbageState.PlayCount = 0
 	g.CribbageState.LastToPlay = -1
-	
-	return scores
 }
 
-func (g *Game) scorePegging() int {
-	if len(g.CribbageState.PlayedCards) == 0 {
-		return 0
-	}
-	
-	points := 0
-	playedCards := g.CribbageState.PlayedCards
-	
-	// Fifteen (2 points)
-	if g.CribbageState.PlayTotal == 15 {
-		points += 2
-	}
-	
-	// Thirty-one (2 points)
-	if g.CribbageState.PlayTotal == 31 {
-		points += 2
-	}
-	
-	// Pairs (2 points each)
-	if len(playedCards) >= 2 {
-		lastCard := playedCards[len(playedCards)-1]
-		pairCount := 1
-		
-		for i := len(playedCards) - 2; i >= 0; i-- {
-			if playedCards[i].Rank == lastCard.Rank {
-				pairCount++
-			} else {
-				break
-			}
-		}
-		
-		if pairCount >= 2 {
-			// n of a kind = n * (n-1) points
-			points += pairCount * (pairCount - 1)
+// CribbageNextHand shuffles a fresh deck and deals the next hand once the show has been counted
+// and the deal has passed to the other player. In a muggins game it waits for every claim that
+// missed points to be called or to run out of time.
+func (g *Game) CribbageNextHand() error {
+	return g.nextCribbageHand(nil, time.Now())
+}
+
+// nextCribbageHand deals the next hand at the given time from the given deck when replaying, or a freshly shuffled one.
+func (g *Game) nextCribbageHand(deck *Deck, now time.Time) error {
+	if g.CribbageState == nil {
+		return fmt.Errorf("cribbage game has not been started")
+	}
+	if g.CribbageState.Phase != CribbageDeal {
+		if g.CribbageState.Phase == CribbageFinished {
+			return fmt.Errorf("cribbage game is over")
 		}
+		return fmt.Errorf("current hand is still being played")
 	}
-	
-	// Runs (1 point per card)
-	if len(playedCards) >= 3 {
-		points += g.scorePlayRun()
+	if g.CribbageState.mugginsOpen(now) {
+		return fmt.Errorf("muggins can still be called on the last show")
 	}
-	
-	return points
+
+	if deck != nil {
+		g.Deck.restore(deck)
+	} else {
+		g.Deck.Reset()
+		g.shuffle()
+	}
+	defer g.record(GameEvent{Type: EventCribbageNextHand, Deck: g.Deck.snapshot(), Timestamp: now})
+	return g.dealCribbageHand()
+}
+
+func (g *Game) scorePegging() int {
+	return g.pegScore().Total
 }
 
 func (g *Game) scorePlayRun() int {
@@ -332,20 +340,32 @@ func (g *Game) allHandsEmpty() bool {
 }
 
 func (g *Game) resetPlayRound() {
-	// Last to play gets 1 point for last card (if not 31)
+	// Last to play pegs 1 for the go, or for the last card once every hand is empty (not on 31)
 	if g.CribbageState.PlayTotal != 31 && g.CribbageState.LastToPlay >= 0 {
-		g.CribbageState.PlayerScores[g.CribbageState.LastToPlay] += 1
+		kind := ScoreGo
+		if g.allHandsEmpty() {
+			kind = ScoreLastCard
+		}
+		var score CribbageScore
+		played := g.CribbageState.PlayedCards
+		if len(played) > 0 {
+			score.add(kind, 1, played[len(played)-1])
+		} else {
+			score.add(kind, 1)
+		}
+		g.peg(g.CribbageState.LastToPlay, score, "play")
 	}
 	
+	last := g.CribbageState.LastToPlay
+	if last < 0 {
+		last = len(g.Players) - 1
+	}
 	g.CribbageState.PlayTotal = 0
 	g.CribbageState.PlayedCards = []*Card{}
 	g.CribbageState.LastToPlay = -1
 	
-	// Find next player who can play
-	for i := 0; i < len(g.Players); i++ {
-		if len(g.Players[i].Hand) > 0 {
-			g.CurrentPlayer = i
-			break
-		}
+	// Play resumes with the next player left of the last card who still holds cards
+	if next := g.nextCribbageSeat(last, false); next >= 0 {
+		g.CurrentPlayer = next
 	}
 }
\ No newline at end of file
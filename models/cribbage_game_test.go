@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCribbagePlay(t *testing.T) {
@@ -429,4 +430,134 @@ func TestCribbageNextHandPreparation(t *testing.T) {
 	// Players should have empty hands
 	assert.Equal(t, 0, len(player1.Hand))
 	assert.Equal(t, 0, len(player2.Hand))
-}
\ No newline at end of file
+}
+func TestCribbageNextHandDealsAfterShow(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	require.NoError(t, game.StartCribbageGame())
+	assert.EqualError(t, game.CribbageNextHand(), "current hand is still being played")
+
+	require.NoError(t, game.CribbageDiscard(alice.ID, []int{0, 1}))
+	require.NoError(t, game.CribbageDiscard(bob.ID, []int{0, 1}))
+	playCribbagePegging(t, game)
+	require.Equal(t, CribbageShow, game.CribbageState.Phase)
+	require.NotNil(t, game.CribbageShow())
+
+	require.NoError(t, game.CribbageNextHand())
+	assert.Equal(t, CribbageDiscard, game.CribbageState.Phase)
+	assert.Equal(t, 1, game.CribbageState.Dealer)
+	assert.Equal(t, 0, game.CurrentPlayer, "the new non-dealer acts first")
+	assert.Len(t, alice.Hand, 6)
+	assert.Len(t, bob.Hand, 6)
+	assert.Equal(t, 40, game.Deck.RemainingCards())
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, alice.Hand, replayed.Players[0].Hand)
+	assert.Equal(t, game.CribbageState.Dealer, replayed.CribbageState.Dealer)
+
+	game.CribbageState.Phase = CribbageFinished
+	assert.EqualError(t, game.CribbageNextHand(), "cribbage game is over")
+	assert.EqualError(t, NewGame(1).CribbageNextHand(), "cribbage game has not been started")
+}
+
+// playCribbagePegging plays out the pegging, each player laying their first card that fits or saying go.
+func playCribbagePegging(t *testing.T, game *Game) {
+	for game.CribbageState.Phase == CribbagePlay {
+		player := game.Players[game.CurrentPlayer]
+		played := false
+		for i, card := range player.Hand {
+			if game.CribbageState.PlayTotal+card.CribbagePlayValue() <= 31 {
+				require.NoError(t, game.CribbagePlay(player.ID, i))
+				played = true
+				break
+			}
+		}
+		if !played {
+			require.NoError(t, game.CribbageGo(player.ID))
+		}
+	}
+}
+
+// newThreeHandedPlay seats Alice, Bob and Carol with Alice dealing, the count at the total and the given hands.
+func newThreeHandedPlay(t *testing.T, total, lastToPlay int, hands ...[]*Card) *Game {
+	game := NewGameWithType(1, Standard, Cribbage, 3)
+	for i, name := range []string{"Alice", "Bob", "Carol"} {
+		require.NotNil(t, game.AddPlayer(name))
+		game.Players[i].Hand = hands[i]
+	}
+	game.GameType = Cribbage
+	game.Status = GameInProgress
+	game.CribbageState = &CribbageState{
+		Phase:        CribbagePlay,
+		PlayedCards:  []*Card{{Rank: Ten, Suit: Clubs}},
+		PlayTotal:    total,
+		PlayerScores: make([]int, 3),
+		GameScore:    121,
+		LastToPlay:   lastToPlay,
+	}
+	return game
+}
+
+func TestCribbageGoPassesAroundThreePlayers(t *testing.T) {
+	game := newThreeHandedPlay(t, 25, 0,
+		[]*Card{{Rank: Ace, Suit: Hearts}},
+		[]*Card{{Rank: King, Suit: Hearts}},
+		[]*Card{{Rank: Five, Suit: Hearts}, {Rank: Two, Suit: Spades}},
+	)
+	alice, bob, carol := game.Players[0], game.Players[1], game.Players[2]
+	game.CurrentPlayer = 1
+
+	// Bob cannot play, so Carol and then Alice keep the count going to 31
+	require.NoError(t, game.CribbageGo(bob.ID))
+	assert.Equal(t, 2, game.CurrentPlayer)
+	require.NoError(t, game.CribbagePlay(carol.ID, 0))
+	assert.Equal(t, 0, game.CurrentPlayer)
+	require.NoError(t, game.CribbagePlay(alice.ID, 0))
+	assert.Equal(t, []int{2, 0, 0}, game.CribbageState.PlayerScores)
+	assert.Equal(t, 0, game.CribbageState.PlayTotal)
+	assert.Equal(t, 1, game.CurrentPlayer, "the player left of the 31 leads the next count")
+
+	// Alice has laid all her cards, so she is passed over
+	require.NoError(t, game.CribbagePlay(bob.ID, 0))
+	assert.Equal(t, 2, game.CurrentPlayer)
+}
+
+func TestCribbageGoWhenNobodyCanPlay(t *testing.T) {
+	game := newThreeHandedPlay(t, 28, 2,
+		[]*Card{{Rank: King, Suit: Hearts}},
+		[]*Card{{Rank: Queen, Suit: Hearts}},
+		[]*Card{{Rank: Ten, Suit: Hearts}},
+	)
+	game.CurrentPlayer = 0
+
+	require.NoError(t, game.CribbageGo(game.Players[0].ID))
+	assert.Equal(t, []int{0, 0, 1}, game.CribbageState.PlayerScores, "Carol played last and pegs the go")
+	assert.Equal(t, 0, game.CribbageState.PlayTotal)
+	assert.Equal(t, 0, game.CurrentPlayer)
+}
+
+func TestCribbagePartnershipsScoreAndWinTogether(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 4)
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+	require.NoError(t, game.StartCribbageGame())
+	for _, player := range game.Players {
+		require.NoError(t, game.CribbageDiscard(player.ID, []int{0}))
+	}
+	playCribbagePegging(t, game)
+
+	state := game.CribbageState
+	assert.Equal(t, state.PlayerScores[0]+state.PlayerScores[2], state.TeamScores[0])
+	assert.Equal(t, state.PlayerScores[1]+state.PlayerScores[3], state.TeamScores[1])
+
+	// Bob and Dave need nothing more; Bob's hand is counted first
+	state.TeamScores[1] = 121
+	scores := game.CribbageShow()
+	require.NotNil(t, scores)
+	assert.Equal(t, 1, scores["winner"])
+	assert.Equal(t, []string{game.Players[1].ID, game.Players[3].ID}, state.Winners)
+	assert.Equal(t, GameFinished, game.Status)
+}
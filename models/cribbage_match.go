@@ -0,0 +1,244 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Skunk results: a game won while every opponent is short of the skunk line earns extra match points.
+const (
+	CribbageSkunk       = "skunk"
+	CribbageDoubleSkunk = "double_skunk"
+
+	cribbageSkunkLine       = 91
+	cribbageDoubleSkunkLine = 61
+	maxCribbageBestOf       = 21
+)
+
+// CribbageRules are the options a cribbage game is created with.
+type CribbageRules struct {
+	BestOf        int  `json:"best_of"`                  // Games in the match, an odd number; 1 plays a single game
+	Muggins       bool `json:"muggins,omitempty"`        // Players claim their own counts and opponents take what they miss
+	MugginsWindow int  `json:"muggins_window,omitempty"` // Seconds opponents have to call muggins on a claim; 0 uses the default
+}
+
+// DefaultCribbageRules returns the options games get when none are chosen: a single game.
+func DefaultCribbageRules() CribbageRules {
+	return CribbageRules{BestOf: 1}
+}
+
+// Validate reports the first option that is out of range.
+func (r CribbageRules) Validate() error {
+	if r.BestOf < 1 || r.BestOf > maxCribbageBestOf || r.BestOf%2 == 0 {
+		return fmt.Errorf("best of must be an odd number of games from 1 to %d", maxCribbageBestOf)
+	}
+	if r.MugginsWindow < 0 || r.MugginsWindow > maxMugginsWindow {
+		return fmt.Errorf("muggins window must be 0-%d seconds", maxMugginsWindow)
+	}
+	return nil
+}
+
+// SetCribbageRules attaches options to a cribbage game before it is dealt.
+func (g *Game) SetCribbageRules(rules CribbageRules) error {
+	if g.GameType != Cribbage {
+		return fmt.Errorf("cribbage rules only apply to cribbage games")
+	}
+	if g.CribbageState != nil {
+		return fmt.Errorf("cribbage rules cannot change once the game has started")
+	}
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+
+	defer g.record(GameEvent{Type: EventCribbageRulesSet, CribbageRules: &rules})
+	g.CribbageRules = &rules
+	return nil
+}
+
+// CurrentCribbageRules returns the options the game is played under, falling back to DefaultCribbageRules.
+func (g *Game) CurrentCribbageRules() CribbageRules {
+	if g.CribbageRules == nil {
+		return DefaultCribbageRules()
+	}
+	return *g.CribbageRules
+}
+
+// CribbagePegs are the two pegs a side leapfrogs along the board: the front peg at its score
+// and the back peg where the front one stood before the last move.
+type CribbagePegs struct {
+	Front int `json:"front"`
+	Back  int `json:"back"`
+}
+
+// CribbagePegMove records one move of a side's front peg.
+type CribbagePegMove struct {
+	Seat   int    `json:"seat"` // Player who scored
+	Side   int    `json:"side"` // Lane on the board: the seat, or the partnership when four play
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Reason string `json:"reason"` // cut, play, hand, crib or muggins
+}
+
+// CribbageGameResult is the outcome of one game of a match.
+type CribbageGameResult struct {
+	Winners     []string `json:"winners"`
+	Scores      []int    `json:"scores"` // Final score of each side
+	Skunk       string   `json:"skunk,omitempty"`
+	MatchPoints int      `json:"match_points"`
+}
+
+// CribbageMatch tracks a best-of-N match. A game won scores 1 match point, a skunk 2 and a double
+// skunk 3, and the first side to more than half of N match points wins.
+type CribbageMatch struct {
+	BestOf  int                  `json:"best_of"`
+	Points  []int                `json:"points"` // Match points won by each side
+	Games   []CribbageGameResult `json:"games"`
+	Winners []string             `json:"winners,omitempty"`
+}
+
+// CribbageNextGame starts the next game of a match once a game is over: scores and pegs go back
+// to zero, the deal passes to the left and a fresh deck is shuffled and dealt. Like the next hand,
+// it waits for muggins to be called on the last show or for the window to close.
+func (g *Game) CribbageNextGame() error {
+	return g.nextCribbageGame(nil, time.Now())
+}
+
+// nextCribbageGame deals the next game at the given time from the given deck when replaying, or a freshly shuffled one.
+func (g *Game) nextCribbageGame(deck *Deck, now time.Time) error {
+	state := g.CribbageState
+	if state == nil {
+		return fmt.Errorf("cribbage game has not been started")
+	}
+	if state.Phase != CribbageFinished {
+		return fmt.Errorf("current game is still being played")
+	}
+	if state.Match == nil || len(state.Match.Winners) > 0 {
+		return fmt.Errorf("match is over")
+	}
+	if state.mugginsOpen(now) {
+		return fmt.Errorf("muggins can still be called on the last show")
+	}
+
+	if deck != nil {
+		g.Deck.restore(deck)
+	} else {
+		g.Deck.Reset()
+		g.shuffle()
+	}
+	defer g.record(GameEvent{Type: EventCribbageNextGame, Deck: g.Deck.snapshot(), Timestamp: now})
+
+	for _, player := range g.Players {
+		player.ClearHand()
+	}
+	state.Dealer = (state.Dealer + 1) % len(g.Players)
+	state.Crib = []*Card{}
+	state.Starter = nil
+	state.PlayedCards = []*Card{}
+	state.PlayTotal = 0
+	state.PlayCount = 0
+	state.LastToPlay = -1
+	state.Pegged = nil
+	state.PlayerScores = make([]int, len(g.Players))
+	if state.TeamScores != nil {
+		state.TeamScores = make([]int, len(state.TeamScores))
+	}
+	state.Board = make([]CribbagePegs, g.cribbageSides())
+	state.PegHistory = []CribbagePegMove{}
+	state.Winners = nil
+	g.Status = GameInProgress
+	return g.dealCribbageHand()
+}
+
+// cribbageSides is how many lanes are raced on the board: one per player, or two partnerships when four play.
+func (g *Game) cribbageSides() int {
+	if g.CribbageState.TeamScores != nil {
+		return len(g.CribbageState.TeamScores)
+	}
+	return len(g.Players)
+}
+
+// cribbageSide returns the lane a seat scores on.
+func (g *Game) cribbageSide(seat int) int {
+	if g.CribbageState.TeamScores != nil {
+		return seat % len(g.CribbageState.TeamScores)
+	}
+	return seat
+}
+
+// addCribbagePoints adds points to a seat's score and, when four play, to its partnership's, moves the
+// side's pegs and ends the game at once if the front peg reaches the game score.
+func (g *Game) addCribbagePoints(seat, points int, reason string) {
+	state := g.CribbageState
+	if points == 0 {
+		return
+	}
+	state.PlayerScores[seat] += points
+	if state.TeamScores != nil {
+		state.TeamScores[g.cribbageSide(seat)] += points
+	}
+
+	side := g.cribbageSide(seat)
+	if len(state.Board) != g.cribbageSides() {
+		state.Board = make([]CribbagePegs, g.cribbageSides())
+	}
+	pegs := &state.Board[side]
+	pegs.Back, pegs.Front = pegs.Front, g.cribbageSideScore(seat)
+	state.PegHistory = append(state.PegHistory, CribbagePegMove{Seat: seat, Side: side, From: pegs.Back, To: pegs.Front, Reason: reason})
+
+	if state.GameScore > 0 && pegs.Front >= state.GameScore && state.Phase != CribbageFinished {
+		g.finishCribbage(seat)
+	}
+}
+
+// cribbageSideScore returns the score a seat is racing to the game score with: its partnership's when four play.
+func (g *Game) cribbageSideScore(seat int) int {
+	if g.CribbageState.TeamScores != nil {
+		return g.CribbageState.TeamScores[g.cribbageSide(seat)]
+	}
+	return g.CribbageState.PlayerScores[seat]
+}
+
+// finishCribbage ends the game, won by the seat and, when four play, its partner, and scores it in the match.
+func (g *Game) finishCribbage(seat int) {
+	state := g.CribbageState
+	state.Winners = []string{}
+	for i, player := range g.Players {
+		if g.cribbageSide(i) == g.cribbageSide(seat) {
+			state.Winners = append(state.Winners, player.ID)
+		}
+	}
+	state.Phase = CribbageFinished
+	g.Status = GameFinished
+
+	result := CribbageGameResult{Winners: state.Winners, Scores: []int{}, MatchPoints: 1}
+	runnerUp := 0
+	for side := 0; side < g.cribbageSides(); side++ {
+		score := state.PlayerScores[side]
+		if state.TeamScores != nil {
+			score = state.TeamScores[side]
+		}
+		result.Scores = append(result.Scores, score)
+		if side != g.cribbageSide(seat) {
+			runnerUp = max(runnerUp, score)
+		}
+	}
+	switch {
+	case runnerUp < cribbageDoubleSkunkLine:
+		result.Skunk, result.MatchPoints = CribbageDoubleSkunk, 3
+	case runnerUp < cribbageSkunkLine:
+		result.Skunk, result.MatchPoints = CribbageSkunk, 2
+	}
+
+	match := state.Match
+	if match == nil {
+		return
+	}
+	if len(match.Points) != g.cribbageSides() {
+		match.Points = make([]int, g.cribbageSides())
+	}
+	match.Games = append(match.Games, result)
+	match.Points[g.cribbageSide(seat)] += result.MatchPoints
+	if match.Points[g.cribbageSide(seat)] > match.BestOf/2 {
+		match.Winners = state.Winners
+	}
+}
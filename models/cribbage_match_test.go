@@ -0,0 +1,132 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCribbageRules(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	for _, bestOf := range []int{0, 2, 23} {
+		assert.Error(t, game.SetCribbageRules(CribbageRules{BestOf: bestOf}), bestOf)
+	}
+	require.NoError(t, game.SetCribbageRules(CribbageRules{BestOf: 3}))
+	assert.Equal(t, 3, game.CurrentCribbageRules().BestOf)
+
+	require.NotNil(t, game.AddPlayer("Alice"))
+	require.NotNil(t, game.AddPlayer("Bob"))
+	require.NoError(t, game.StartCribbageGame())
+	assert.Equal(t, 3, game.CribbageState.Match.BestOf)
+	assert.Error(t, game.SetCribbageRules(CribbageRules{BestOf: 5}), "rules are fixed once dealt")
+	assert.Error(t, NewGame(1).SetCribbageRules(CribbageRules{BestOf: 1}))
+	assert.Equal(t, DefaultCribbageRules(), NewGameWithType(1, Standard, Cribbage, 2).CurrentCribbageRules())
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.CribbageState.Match, replayed.CribbageState.Match)
+}
+
+func TestCribbageWinsMidPegging(t *testing.T) {
+	game := NewGame(1)
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	alice.Hand = []*Card{{Rank: Five, Suit: Hearts}, {Rank: Two, Suit: Hearts}}
+	bob.Hand = []*Card{{Rank: Three, Suit: Clubs}}
+	game.GameType = Cribbage
+	game.Status = GameInProgress
+	game.CribbageState = &CribbageState{
+		Phase:        CribbagePlay,
+		Dealer:       1,
+		PlayedCards:  []*Card{{Rank: King, Suit: Spades}},
+		PlayTotal:    10,
+		PlayerScores: []int{119, 95},
+		GameScore:    121,
+		LastToPlay:   1,
+		Board:        []CribbagePegs{{Front: 119, Back: 112}, {Front: 95, Back: 90}},
+		Match:        &CribbageMatch{BestOf: 3, Points: []int{0, 0}, Games: []CribbageGameResult{}},
+	}
+
+	// Alice's five makes fifteen and takes her out before the hands are counted
+	require.NoError(t, game.CribbagePlay(alice.ID, 0))
+	state := game.CribbageState
+	assert.Equal(t, CribbageFinished, state.Phase)
+	assert.Equal(t, GameFinished, game.Status)
+	assert.Equal(t, []string{alice.ID}, state.Winners)
+	assert.Equal(t, CribbagePegs{Front: 121, Back: 119}, state.Board[0])
+	assert.Equal(t, CribbagePegMove{Seat: 0, Side: 0, From: 119, To: 121, Reason: "play"}, state.PegHistory[len(state.PegHistory)-1])
+	assert.Len(t, alice.Hand, 1, "play stops at once")
+	assert.Error(t, game.CribbagePlay(bob.ID, 0))
+
+	require.Len(t, state.Match.Games, 1)
+	assert.Equal(t, CribbageGameResult{Winners: []string{alice.ID}, Scores: []int{121, 95}, MatchPoints: 1}, state.Match.Games[0])
+	assert.Equal(t, []int{1, 0}, state.Match.Points)
+	assert.Empty(t, state.Match.Winners, "best of three needs two match points")
+}
+
+func TestCribbageSkunks(t *testing.T) {
+	for _, tc := range []struct {
+		loser  int
+		skunk  string
+		points int
+	}{
+		{91, "", 1},
+		{90, CribbageSkunk, 2},
+		{61, CribbageSkunk, 2},
+		{60, CribbageDoubleSkunk, 3},
+	} {
+		game := NewGame(1)
+		game.AddPlayer("Alice")
+		game.AddPlayer("Bob")
+		game.CribbageState = &CribbageState{
+			PlayerScores: []int{120, tc.loser},
+			GameScore:    121,
+			Match:        &CribbageMatch{BestOf: 1, Points: []int{0, 0}},
+		}
+		game.addCribbagePoints(0, 2, "hand")
+
+		match := game.CribbageState.Match
+		require.Len(t, match.Games, 1)
+		assert.Equal(t, tc.skunk, match.Games[0].Skunk, tc.loser)
+		assert.Equal(t, tc.points, match.Points[0], tc.loser)
+		assert.Equal(t, []string{game.Players[0].ID}, match.Winners, "best of one is over after a game")
+	}
+}
+
+func TestCribbageMatchPlay(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	require.NoError(t, game.SetCribbageRules(CribbageRules{BestOf: 3}))
+	alice := game.AddPlayer("Alice")
+	game.AddPlayer("Bob")
+	require.NoError(t, game.StartCribbageGame())
+	assert.EqualError(t, game.CribbageNextGame(), "current game is still being played")
+
+	// A skunk is worth two match points, enough to take a best of three outright
+	game.CribbageState.PlayerScores = []int{120, 80}
+	game.addCribbagePoints(0, 1, "play")
+	assert.Equal(t, []int{2, 0}, game.CribbageState.Match.Points)
+	assert.Equal(t, []string{alice.ID}, game.CribbageState.Match.Winners)
+	assert.EqualError(t, game.CribbageNextGame(), "match is over")
+
+	game = NewGameWithType(1, Standard, Cribbage, 2)
+	require.NoError(t, game.SetCribbageRules(CribbageRules{BestOf: 3}))
+	game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	require.NoError(t, game.StartCribbageGame())
+	game.CribbageState.PlayerScores = []int{100, 120}
+	game.addCribbagePoints(1, 1, "play")
+	require.Equal(t, []int{0, 1}, game.CribbageState.Match.Points)
+
+	require.NoError(t, game.CribbageNextGame())
+	state := game.CribbageState
+	assert.Equal(t, GameInProgress, game.Status)
+	assert.Equal(t, CribbageDiscard, state.Phase)
+	assert.Equal(t, 1, state.Dealer, "the deal passes to the left")
+	assert.Equal(t, []int{0, 0}, state.PlayerScores)
+	assert.Equal(t, []CribbagePegs{{}, {}}, state.Board)
+	assert.Empty(t, state.PegHistory)
+	assert.Nil(t, state.Winners)
+	assert.Len(t, bob.Hand, 6)
+	assert.Len(t, state.Match.Games, 1, "the match carries over")
+}
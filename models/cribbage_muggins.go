@@ -0,0 +1,150 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultMugginsWindow = 30 * time.Second
+	maxMugginsWindow     = 300 // Seconds
+	maxCribbageHandScore = 29
+)
+
+// CribbageClaim is the score a player claimed for their hand or crib in a muggins game.
+type CribbageClaim struct {
+	PlayerID string    `json:"player_id"`
+	Crib     bool      `json:"crib,omitempty"`
+	Claimed  int       `json:"claimed"`
+	Pegged   int       `json:"pegged"`              // Points the claimant pegged: the claim, or the true count if they claimed more
+	Missed   int       `json:"missed"`              // Points the claim left out, there for an opponent to take
+	Deadline time.Time `json:"deadline"`            // Muggins must be called before this
+	MuggedBy string    `json:"mugged_by,omitempty"` // Opponent who called muggins and took the missed points
+}
+
+// mugginsWindow returns how long opponents have to call muggins on a claim.
+func (r CribbageRules) mugginsWindow() time.Duration {
+	if r.MugginsWindow == 0 {
+		return defaultMugginsWindow
+	}
+	return time.Duration(r.MugginsWindow) * time.Second
+}
+
+// CribbageClaim claims the score of the next hand to be counted in a muggins game: each hand from
+// the dealer's left, the dealer's last, then the dealer's crib. The claim is compared with the hand's
+// true count; the claimant pegs what they claimed, up to that count, and anything missed is open to muggins.
+func (g *Game) CribbageClaim(playerID string, points int) error {
+	return g.claimCribbage(playerID, points, time.Now())
+}
+
+// claimCribbage records a claim made at the given time.
+func (g *Game) claimCribbage(playerID string, points int, now time.Time) error {
+	state := g.CribbageState
+	if state == nil {
+		return fmt.Errorf("cribbage game has not been started")
+	}
+	if !g.CurrentCribbageRules().Muggins {
+		return fmt.Errorf("scores are only claimed in muggins games")
+	}
+	if state.Phase != CribbageShow {
+		return fmt.Errorf("not in show phase")
+	}
+	if points < 0 || points > maxCribbageHandScore {
+		return fmt.Errorf("claim must be 0-%d points", maxCribbageHandScore)
+	}
+	seat, crib := g.nextCribbageClaim()
+	if g.Players[seat].ID != playerID {
+		return fmt.Errorf("not your turn to claim")
+	}
+
+	defer g.record(GameEvent{Type: EventCribbageClaim, PlayerID: playerID, Amount: points, Timestamp: now})
+	score := g.showHand(seat, crib)
+	claim := CribbageClaim{
+		PlayerID: playerID,
+		Crib:     crib,
+		Claimed:  points,
+		Pegged:   min(points, score.Total),
+		Deadline: now.Add(g.CurrentCribbageRules().mugginsWindow()),
+	}
+	claim.Missed = score.Total - claim.Pegged
+	state.Claims = append(state.Claims, claim)
+
+	g.addCribbagePoints(seat, claim.Pegged, showingReason(crib))
+	if crib && state.Phase != CribbageFinished {
+		g.endCribbageShow()
+	}
+	return nil
+}
+
+// nextCribbageClaim returns the seat due to claim next and whether it is claiming the crib.
+func (g *Game) nextCribbageClaim() (int, bool) {
+	dealer := g.CribbageState.Dealer
+	claimed := len(g.CribbageState.Claims)
+	if claimed < len(g.Players) {
+		return (dealer + 1 + claimed) % len(g.Players), false
+	}
+	return dealer, true
+}
+
+// CribbageMuggins calls muggins on a claim, by its index in the hand's claims, taking the points it missed.
+// Only an opponent of the claimant may call, once per claim, and only before the claim's window closes.
+func (g *Game) CribbageMuggins(playerID string, claim int) error {
+	return g.callMuggins(playerID, claim, time.Now())
+}
+
+// callMuggins records a muggins call made at the given time.
+func (g *Game) callMuggins(playerID string, index int, now time.Time) error {
+	state := g.CribbageState
+	if state == nil {
+		return fmt.Errorf("cribbage game has not been started")
+	}
+	if state.Phase == CribbageFinished {
+		return fmt.Errorf("cribbage game is over")
+	}
+	if index < 0 || index >= len(state.Claims) {
+		return fmt.Errorf("invalid claim: %d", index)
+	}
+	seat := g.cribbageSeat(playerID)
+	if seat < 0 {
+		return fmt.Errorf("player not found")
+	}
+	claim := &state.Claims[index]
+	if g.cribbageSide(seat) == g.cribbageSide(g.cribbageSeat(claim.PlayerID)) {
+		return fmt.Errorf("only an opponent can call muggins")
+	}
+	if claim.MuggedBy != "" {
+		return fmt.Errorf("muggins has already been called on that claim")
+	}
+	if !now.Before(claim.Deadline) {
+		return fmt.Errorf("muggins window has closed")
+	}
+	if claim.Missed == 0 {
+		return fmt.Errorf("no points were missed in that claim")
+	}
+
+	defer g.record(GameEvent{Type: EventCribbageMuggins, PlayerID: playerID, Claim: index, Timestamp: now})
+	claim.MuggedBy = playerID
+	g.addCribbagePoints(seat, claim.Missed, "muggins")
+	return nil
+}
+
+// mugginsOpen reports whether muggins can still be called at the given time on a claim that missed points.
+// The next hand or game is not dealt until every such claim has been called or its window has closed.
+func (s *CribbageState) mugginsOpen(now time.Time) bool {
+	for _, claim := range s.Claims {
+		if claim.Missed > 0 && claim.MuggedBy == "" && now.Before(claim.Deadline) {
+			return true
+		}
+	}
+	return false
+}
+
+// cribbageSeat returns the seat index of a player, or -1 if they are not at the table.
+func (g *Game) cribbageSeat(playerID string) int {
+	for i, player := range g.Players {
+		if player.ID == playerID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMugginsShow seats Alice and Bob at the show of a muggins game with Bob dealing, so Alice claims first.
+func newMugginsShow(t *testing.T, alice, bob, crib []*Card, starter *Card) *Game {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	require.NoError(t, game.SetCribbageRules(CribbageRules{BestOf: 1, Muggins: true}))
+	require.NotNil(t, game.AddPlayer("Alice"))
+	require.NotNil(t, game.AddPlayer("Bob"))
+	game.Players[0].Hand = alice
+	game.Players[1].Hand = bob
+	game.Status = GameInProgress
+	game.CribbageState = &CribbageState{
+		Phase:        CribbageShow,
+		Dealer:       1,
+		Crib:         crib,
+		Starter:      starter,
+		PlayedCards:  []*Card{},
+		PlayerScores: []int{0, 0},
+		GameScore:    121,
+		LastToPlay:   -1,
+	}
+	return game
+}
+
+func TestCribbageMugginsClaims(t *testing.T) {
+	game := newMugginsShow(t,
+		[]*Card{{Rank: Five, Suit: Hearts}, {Rank: Ten, Suit: Clubs}, {Rank: Two, Suit: Diamonds}, {Rank: Three, Suit: Spades}},
+		[]*Card{{Rank: Seven, Suit: Hearts}, {Rank: Eight, Suit: Clubs}, {Rank: Nine, Suit: Diamonds}, {Rank: Ace, Suit: Spades}},
+		[]*Card{{Rank: Two, Suit: Hearts}, {Rank: Two, Suit: Clubs}, {Rank: Queen, Suit: Diamonds}, {Rank: Queen, Suit: Spades}},
+		&Card{Rank: King, Suit: Hearts})
+	alice, bob := game.Players[0], game.Players[1]
+	state := game.CribbageState
+
+	assert.Nil(t, game.CribbageShow(), "muggins hands are claimed, not counted for the players")
+	assert.EqualError(t, game.CribbageClaim(bob.ID, 5), "not your turn to claim")
+	assert.Error(t, game.CribbageClaim(alice.ID, 30))
+
+	// Alice holds 8, four fifteens, and claims 6
+	require.NoError(t, game.CribbageClaim(alice.ID, 6))
+	require.Len(t, state.Claims, 1)
+	assert.Equal(t, 6, state.Claims[0].Pegged)
+	assert.Equal(t, 2, state.Claims[0].Missed)
+	assert.Equal(t, 8, state.Show[0].Score.Total)
+	assert.Equal(t, []int{6, 0}, state.PlayerScores)
+	assert.EqualError(t, game.CribbageMuggins(alice.ID, 0), "only an opponent can call muggins")
+
+	// Bob overclaims his run and fifteen and pegs only the 5 they are worth
+	require.NoError(t, game.CribbageClaim(bob.ID, 9))
+	assert.Equal(t, 5, state.Claims[1].Pegged)
+	assert.EqualError(t, game.CribbageMuggins(alice.ID, 1), "no points were missed in that claim")
+
+	require.NoError(t, game.CribbageMuggins(bob.ID, 0))
+	assert.Equal(t, bob.ID, state.Claims[0].MuggedBy)
+	assert.Equal(t, []int{6, 7}, state.PlayerScores)
+	assert.Equal(t, "muggins", state.PegHistory[len(state.PegHistory)-1].Reason)
+	assert.EqualError(t, game.CribbageMuggins(bob.ID, 0), "muggins has already been called on that claim")
+
+	// The dealer claims the crib's two pairs last, ending the show
+	assert.EqualError(t, game.CribbageClaim(alice.ID, 4), "not your turn to claim")
+	require.NoError(t, game.CribbageClaim(bob.ID, 2))
+	assert.True(t, state.Claims[2].Crib)
+	assert.Equal(t, CribbageDeal, state.Phase)
+	assert.Equal(t, 0, state.Dealer)
+
+	// Alice can still take what Bob missed in the crib after the show
+	require.NoError(t, game.CribbageMuggins(alice.ID, 2))
+	assert.Equal(t, []int{8, 9}, state.PlayerScores)
+	assert.Error(t, game.CribbageMuggins(alice.ID, 3))
+}
+
+func TestCribbageMugginsWindow(t *testing.T) {
+	game := newMugginsShow(t,
+		[]*Card{{Rank: Five, Suit: Hearts}, {Rank: Ten, Suit: Clubs}, {Rank: Two, Suit: Diamonds}, {Rank: Three, Suit: Spades}},
+		[]*Card{{Rank: Seven, Suit: Hearts}, {Rank: Eight, Suit: Clubs}, {Rank: Nine, Suit: Diamonds}, {Rank: Ace, Suit: Spades}},
+		[]*Card{}, &Card{Rank: King, Suit: Hearts})
+	alice, bob := game.Players[0], game.Players[1]
+
+	claimed := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, game.claimCribbage(alice.ID, 0, claimed))
+	assert.Equal(t, claimed.Add(defaultMugginsWindow), game.CribbageState.Claims[0].Deadline)
+	assert.Equal(t, claimed, game.Events[len(game.Events)-1].Timestamp)
+	assert.EqualError(t, game.callMuggins(bob.ID, 0, claimed.Add(defaultMugginsWindow)), "muggins window has closed")
+	require.NoError(t, game.callMuggins(bob.ID, 0, claimed.Add(defaultMugginsWindow-time.Second)))
+
+	assert.Error(t, (CribbageRules{BestOf: 1, Muggins: true, MugginsWindow: maxMugginsWindow + 1}).Validate())
+	assert.Equal(t, 10*time.Second, CribbageRules{MugginsWindow: 10}.mugginsWindow())
+}
+
+func TestCribbageMugginsReplay(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	require.NoError(t, game.SetCribbageRules(CribbageRules{BestOf: 1, Muggins: true}))
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	require.NoError(t, game.StartCribbageGame())
+	assert.EqualError(t, game.CribbageClaim(bob.ID, 0), "not in show phase")
+	require.NoError(t, game.CribbageDiscard(alice.ID, []int{0, 1}))
+	require.NoError(t, game.CribbageDiscard(bob.ID, []int{0, 1}))
+	playCribbagePegging(t, game)
+	require.Equal(t, CribbageShow, game.CribbageState.Phase)
+
+	// Everyone claims nothing, so Alice can take whatever Bob's hand was worth
+	require.NoError(t, game.CribbageClaim(bob.ID, 0))
+	if game.CribbageState.Claims[0].Missed > 0 {
+		require.NoError(t, game.CribbageMuggins(alice.ID, 0))
+	}
+	require.NoError(t, game.CribbageClaim(alice.ID, 0))
+	require.NoError(t, game.CribbageClaim(alice.ID, 0))
+	assert.Equal(t, CribbageDeal, game.CribbageState.Phase)
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.CribbageState.PlayerScores, replayed.CribbageState.PlayerScores)
+	assert.Equal(t, game.CribbageState.Claims, replayed.CribbageState.Claims)
+
+	// Once every window has closed the next hand clears the claims, and replays deal it at the same time
+	require.NoError(t, game.nextCribbageHand(nil, game.CribbageState.Claims[2].Deadline))
+	assert.Empty(t, game.CribbageState.Claims)
+	replayed, err = ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.CribbageState.Dealer, replayed.CribbageState.Dealer)
+	assert.Error(t, NewGame(1).CribbageClaim(alice.ID, 0))
+}
+
+func TestCribbageMugginsHoldsTheDeal(t *testing.T) {
+	game := newMugginsShow(t,
+		[]*Card{{Rank: Five, Suit: Hearts}, {Rank: Ten, Suit: Clubs}, {Rank: Two, Suit: Diamonds}, {Rank: Three, Suit: Spades}},
+		[]*Card{{Rank: Seven, Suit: Hearts}, {Rank: Eight, Suit: Clubs}, {Rank: Nine, Suit: Diamonds}, {Rank: Ace, Suit: Spades}},
+		[]*Card{{Rank: Two, Suit: Hearts}, {Rank: Two, Suit: Clubs}, {Rank: Queen, Suit: Diamonds}, {Rank: Queen, Suit: Spades}},
+		&Card{Rank: King, Suit: Hearts})
+	alice, bob := game.Players[0], game.Players[1]
+	state := game.CribbageState
+
+	// Alice counts her 8 exactly; Bob claims nothing for his hand of 5 or his crib of 4
+	claimed := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, game.claimCribbage(alice.ID, 8, claimed))
+	require.NoError(t, game.claimCribbage(bob.ID, 0, claimed))
+	require.NoError(t, game.claimCribbage(bob.ID, 0, claimed))
+	require.Equal(t, CribbageDeal, state.Phase)
+
+	assert.EqualError(t, game.nextCribbageHand(nil, claimed.Add(time.Second)), "muggins can still be called on the last show")
+	require.NoError(t, game.callMuggins(alice.ID, 1, claimed.Add(time.Second)))
+	assert.EqualError(t, game.nextCribbageHand(nil, claimed.Add(2*time.Second)), "muggins can still be called on the last show",
+		"the crib claim is still open")
+	require.Len(t, state.Claims, 3)
+
+	// A finished game of a match is held the same way before the next game is dealt
+	state.Phase = CribbageFinished
+	state.Match = &CribbageMatch{BestOf: 3, Points: []int{1, 0}}
+	assert.EqualError(t, game.nextCribbageGame(nil, claimed.Add(2*time.Second)), "muggins can still be called on the last show")
+	state.Phase = CribbageDeal
+	state.Match = nil
+
+	require.NoError(t, game.nextCribbageHand(nil, claimed.Add(defaultMugginsWindow)), "the crib claim's window has closed")
+	assert.Empty(t, state.Claims)
+	assert.Equal(t, CribbageDiscard, state.Phase)
+}
@@ -0,0 +1,270 @@
+package models
+
+// Cribbage score item types, one for every way cards score during the play or the show.
+const (
+	ScoreFifteen         = "fifteen"
+	ScorePair            = "pair"
+	ScorePairRoyal       = "pair_royal"        // Three of a kind pegged in a row
+	ScoreDoublePairRoyal = "double_pair_royal" // Four of a kind pegged in a row
+	ScoreRun             = "run"
+	ScoreFlush           = "flush"
+	ScoreNobs            = "nobs"      // Jack in hand of the starter's suit
+	ScoreHisHeels        = "his_heels" // Jack turned up as the starter
+	ScoreThirtyOne       = "thirty_one"
+	ScoreGo              = "go"        // Last card of a count that ended short of 31
+	ScoreLastCard        = "last_card" // Last card of the play
+)
+
+// CribbageScoreItem is a single scoring combination and the cards that make it.
+type CribbageScoreItem struct {
+	Type   string `json:"type"`
+	Cards  []Card `json:"cards,omitempty"`
+	Points int    `json:"points"`
+}
+
+// CribbageScore itemises the points scored by a hand, the crib or a card played.
+type CribbageScore struct {
+	Items []CribbageScoreItem `json:"items"`
+	Total int                 `json:"total"`
+}
+
+// CribbagePeg is what the last card played, go or starter cut pegged, and for whom.
+type CribbagePeg struct {
+	PlayerID string        `json:"player_id"`
+	Score    CribbageScore `json:"score"`
+}
+
+// CribbageShowing is a hand or the crib as it was counted at the show.
+type CribbageShowing struct {
+	PlayerID string        `json:"player_id"` // Owner of the hand, or the dealer for the crib
+	Crib     bool          `json:"crib,omitempty"`
+	Cards    []Card        `json:"cards"` // Starter last
+	Score    CribbageScore `json:"score"`
+}
+
+// CountCribbageHand itemises what a hand or crib scores with the starter: every fifteen,
+// pair and run, and any flush or nobs.
+func CountCribbageHand(hand []*Card, starter *Card) CribbageScore {
+	cards := append([]*Card{}, hand...)
+	if starter != nil {
+		cards = append(cards, starter)
+	}
+	return countCribbageCards(cards)
+}
+
+// countCribbageCards itemises a hand or crib whose last card, if there are five, is the starter.
+func countCribbageCards(cards []*Card) CribbageScore {
+	score := CribbageScore{Items: []CribbageScoreItem{}}
+	score.addFifteens(cards)
+	score.addPairs(cards)
+	score.addRuns(cards)
+	score.addFlush(cards)
+	score.addNobs(cards)
+	return score
+}
+
+// add appends a scoring item for the cards.
+func (s *CribbageScore) add(kind string, points int, cards ...*Card) {
+	item := CribbageScoreItem{Type: kind, Points: points}
+	for _, card := range cards {
+		item.Cards = append(item.Cards, *card)
+	}
+	s.Items = append(s.Items, item)
+	s.Total += points
+}
+
+// addFifteens scores 2 for every combination of cards adding up to 15.
+func (s *CribbageScore) addFifteens(cards []*Card) {
+	for mask := 1; mask < 1<<len(cards); mask++ {
+		sum := 0
+		combination := []*Card{}
+		for i, card := range cards {
+			if mask&(1<<i) != 0 {
+				sum += card.CribbageValue()
+				combination = append(combination, card)
+			}
+		}
+		if sum == 15 {
+			s.add(ScoreFifteen, 2, combination...)
+		}
+	}
+}
+
+// addPairs scores 2 for every pair of cards of the same rank, so three of a kind is three pairs.
+func (s *CribbageScore) addPairs(cards []*Card) {
+	for i := 0; i < len(cards); i++ {
+		for j := i + 1; j < len(cards); j++ {
+			if cards[i].Rank == cards[j].Rank {
+				s.add(ScorePair, 2, cards[i], cards[j])
+			}
+		}
+	}
+}
+
+// addRuns scores the longest sequence of three or more ranks once for every way of making it,
+// so a double run of three is two runs.
+func (s *CribbageScore) addRuns(cards []*Card) {
+	if len(cards) < 3 {
+		return
+	}
+	byRank := map[Rank][]*Card{}
+	for _, card := range cards {
+		byRank[card.Rank] = append(byRank[card.Rank], card)
+	}
+
+	low, length := Rank(0), 0
+	for rank := Ace; rank <= King; rank++ {
+		if len(byRank[rank]) == 0 {
+			continue
+		}
+		run := 1
+		for len(byRank[rank+Rank(run)]) > 0 {
+			run++
+		}
+		if run >= 3 && run > length {
+			low, length = rank, run
+		}
+		rank += Rank(run - 1)
+	}
+	if length == 0 {
+		return
+	}
+
+	var build func(run []*Card)
+	build = func(run []*Card) {
+		if len(run) == length {
+			s.add(ScoreRun, length, run...)
+			return
+		}
+		for _, card := range byRank[low+Rank(len(run))] {
+			build(append(append([]*Card{}, run...), card))
+		}
+	}
+	build(nil)
+}
+
+// addFlush scores 4 when the four hand cards share a suit, or 5 when the starter matches them too.
+func (s *CribbageScore) addFlush(cards []*Card) {
+	if len(cards) < 4 {
+		return
+	}
+	handSize := len(cards)
+	if handSize == 5 {
+		handSize = 4 // The fifth card is the starter
+	}
+	for _, card := range cards[1:handSize] {
+		if card.Suit != cards[0].Suit {
+			return
+		}
+	}
+	if len(cards) == 5 && cards[4].Suit == cards[0].Suit {
+		s.add(ScoreFlush, 5, cards...)
+		return
+	}
+	s.add(ScoreFlush, 4, cards[:handSize]...)
+}
+
+// addNobs scores 1 for a jack in hand of the same suit as the starter.
+func (s *CribbageScore) addNobs(cards []*Card) {
+	if len(cards) != 5 {
+		return
+	}
+	starter := cards[4]
+	for _, card := range cards[:4] {
+		if card.Rank == Jack && card.Suit == starter.Suit {
+			s.add(ScoreNobs, 1, card, starter)
+			return
+		}
+	}
+}
+
+// pegScore itemises what the card just played scores on the count: fifteen, thirty-one,
+// pairs with the cards before it, and a run ending with it.
+func (g *Game) pegScore() CribbageScore {
+	score := CribbageScore{Items: []CribbageScoreItem{}}
+	played := g.CribbageState.PlayedCards
+	if len(played) == 0 {
+		return score
+	}
+
+	switch g.CribbageState.PlayTotal {
+	case 15:
+		score.add(ScoreFifteen, 2, played...)
+	case 31:
+		score.add(ScoreThirtyOne, 2, played...)
+	}
+
+	last := played[len(played)-1]
+	same := 1
+	for i := len(played) - 2; i >= 0 && played[i].Rank == last.Rank; i-- {
+		same++
+	}
+	switch same {
+	case 2:
+		score.add(ScorePair, 2, played[len(played)-2:]...)
+	case 3:
+		score.add(ScorePairRoyal, 6, played[len(played)-3:]...)
+	case 4:
+		score.add(ScoreDoublePairRoyal, 12, played[len(played)-4:]...)
+	}
+
+	if run := g.scorePlayRun(); run > 0 {
+		score.add(ScoreRun, run, played[len(played)-run:]...)
+	}
+	return score
+}
+
+// peg adds itemised points to a player's score and to the breakdown of the last peg.
+func (g *Game) peg(index int, score CribbageScore, reason string) {
+	if score.Total == 0 {
+		return
+	}
+	state := g.CribbageState
+	playerID := g.Players[index].ID
+	if state.LastPeg == nil || state.LastPeg.PlayerID != playerID {
+		state.LastPeg = &CribbagePeg{PlayerID: playerID, Score: CribbageScore{Items: []CribbageScoreItem{}}}
+	}
+	state.LastPeg.Score.Items = append(state.LastPeg.Score.Items, score.Items...)
+	state.LastPeg.Score.Total += score.Total
+	g.addCribbagePoints(index, score.Total, reason)
+}
+
+// countShowing counts a seat's hand, including the cards it pegged, or the dealer's crib at the show.
+// The points are added to the seat's score and returned.
+func (g *Game) countShowing(index int, crib bool) int {
+	score := g.showHand(index, crib)
+	g.addCribbagePoints(index, score.Total, showingReason(crib))
+	return score.Total
+}
+
+// showHand itemises a seat's hand, including the cards it pegged, or the dealer's crib and adds
+// it to the show without pegging it.
+func (g *Game) showHand(index int, crib bool) CribbageScore {
+	state := g.CribbageState
+	cards := state.Crib
+	if !crib {
+		cards = []*Card{}
+		if index < len(state.Pegged) {
+			cards = append(cards, state.Pegged[index]...)
+		}
+		cards = append(cards, g.Players[index].Hand...)
+	}
+	score := CountCribbageHand(cards, state.Starter)
+	showing := CribbageShowing{PlayerID: g.Players[index].ID, Crib: crib, Cards: []Card{}, Score: score}
+	for _, card := range cards {
+		showing.Cards = append(showing.Cards, *card)
+	}
+	if state.Starter != nil {
+		showing.Cards = append(showing.Cards, *state.Starter)
+	}
+	state.Show = append(state.Show, showing)
+	return score
+}
+
+// showingReason is the peg move reason for points counted at the show.
+func showingReason(crib bool) string {
+	if crib {
+		return "crib"
+	}
+	return "hand"
+}
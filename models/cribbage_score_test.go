@@ -0,0 +1,139 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scoreItemCounts counts the items of each type in a breakdown.
+func scoreItemCounts(score CribbageScore) map[string]int {
+	counts := map[string]int{}
+	for _, item := range score.Items {
+		counts[item.Type]++
+	}
+	return counts
+}
+
+func TestCountCribbageHandItemises(t *testing.T) {
+	// The perfect 29: three fives and the jack of the starter's suit, with the fourth five cut
+	hand := []*Card{{Rank: Five, Suit: Hearts}, {Rank: Five, Suit: Diamonds}, {Rank: Five, Suit: Clubs}, {Rank: Jack, Suit: Spades}}
+	score := CountCribbageHand(hand, &Card{Rank: Five, Suit: Spades})
+	assert.Equal(t, 29, score.Total)
+	assert.Equal(t, map[string]int{ScoreFifteen: 8, ScorePair: 6, ScoreNobs: 1}, scoreItemCounts(score))
+
+	// A double run of three counts each run with its own cards
+	hand = []*Card{{Rank: Four, Suit: Hearts}, {Rank: Five, Suit: Diamonds}, {Rank: Five, Suit: Clubs}, {Rank: Six, Suit: Spades}}
+	score = CountCribbageHand(hand, &Card{Rank: King, Suit: Hearts})
+	assert.Equal(t, 16, score.Total)
+	assert.Equal(t, map[string]int{ScoreFifteen: 4, ScorePair: 1, ScoreRun: 2}, scoreItemCounts(score))
+	for _, item := range score.Items {
+		if item.Type == ScoreRun {
+			assert.Len(t, item.Cards, 3)
+			assert.Equal(t, 3, item.Points)
+		}
+	}
+
+	// A four-card flush leaves the starter out
+	hand = []*Card{{Rank: Two, Suit: Hearts}, {Rank: Four, Suit: Hearts}, {Rank: Six, Suit: Hearts}, {Rank: Eight, Suit: Hearts}}
+	score = CountCribbageHand(hand, &Card{Rank: King, Suit: Spades})
+	require.Len(t, score.Items, 1)
+	assert.Equal(t, CribbageScoreItem{Type: ScoreFlush, Points: 4, Cards: []Card{*hand[0], *hand[1], *hand[2], *hand[3]}}, score.Items[0])
+	assert.Equal(t, score.Total, scoreCribbageCards(append(hand, &Card{Rank: King, Suit: Spades})))
+}
+
+func TestPegScoreItemises(t *testing.T) {
+	game := NewGame(1)
+	game.CribbageState = &CribbageState{}
+	peg := func(total int, ranks ...Rank) CribbageScore {
+		game.CribbageState.PlayedCards = []*Card{}
+		for i, rank := range ranks {
+			game.CribbageState.PlayedCards = append(game.CribbageState.PlayedCards, &Card{Rank: rank, Suit: Suit(i % 4)})
+		}
+		game.CribbageState.PlayTotal = total
+		return game.pegScore()
+	}
+
+	score := peg(15, Seven, Eight)
+	assert.Equal(t, map[string]int{ScoreFifteen: 1}, scoreItemCounts(score))
+	assert.Len(t, score.Items[0].Cards, 2)
+
+	score = peg(15, Five, Five, Five)
+	assert.Equal(t, 8, score.Total)
+	assert.Equal(t, map[string]int{ScoreFifteen: 1, ScorePairRoyal: 1}, scoreItemCounts(score))
+
+	score = peg(31, King, Ten, Four, Three, Two, Two)
+	assert.Equal(t, map[string]int{ScoreThirtyOne: 1, ScorePair: 1}, scoreItemCounts(score))
+
+	score = peg(12, Three, Five, Four)
+	assert.Equal(t, []CribbageScoreItem{{Type: ScoreRun, Points: 3, Cards: []Card{
+		{Rank: Three, Suit: Hearts}, {Rank: Five, Suit: Diamonds}, {Rank: Four, Suit: Clubs},
+	}}}, score.Items)
+}
+
+func TestCribbageGoPegsOnce(t *testing.T) {
+	game := NewGame(1)
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	alice.Hand = []*Card{{Rank: King, Suit: Hearts}}
+	bob.Hand = []*Card{{Rank: Queen, Suit: Diamonds}}
+	game.GameType = Cribbage
+	game.CribbageState = &CribbageState{
+		Phase:        CribbagePlay,
+		PlayedCards:  []*Card{{Rank: Ten, Suit: Clubs}, {Rank: Ten, Suit: Spades}, {Rank: Five, Suit: Clubs}},
+		PlayTotal:    25,
+		PlayerScores: []int{0, 0},
+		GameScore:    121,
+		LastToPlay:   1,
+	}
+
+	require.NoError(t, game.CribbageGo(alice.ID))
+	assert.Equal(t, []int{0, 1}, game.CribbageState.PlayerScores, "one for the go, not a last card as well")
+	require.NotNil(t, game.CribbageState.LastPeg)
+	assert.Equal(t, bob.ID, game.CribbageState.LastPeg.PlayerID)
+	assert.Equal(t, []CribbageScoreItem{{Type: ScoreGo, Points: 1, Cards: []Card{{Rank: Five, Suit: Clubs}}}}, game.CribbageState.LastPeg.Score.Items)
+	assert.Equal(t, 0, game.CribbageState.PlayTotal)
+}
+
+func TestCribbageShowRecordsBreakdown(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	require.NoError(t, game.StartCribbageGame())
+	require.NoError(t, game.CribbageDiscard(alice.ID, []int{0, 1}))
+	require.NoError(t, game.CribbageDiscard(bob.ID, []int{0, 1}))
+	if game.CribbageState.Starter.Rank == Jack {
+		assert.Equal(t, ScoreHisHeels, game.CribbageState.LastPeg.Score.Items[0].Type)
+	}
+	playCribbagePegging(t, game)
+	pegged := append([]int{}, game.CribbageState.PlayerScores...)
+
+	scores := game.CribbageShow()
+	require.NotNil(t, scores)
+	show := game.CribbageState.Show
+	require.Len(t, show, 3)
+	assert.Equal(t, []string{bob.ID, alice.ID, alice.ID}, []string{show[0].PlayerID, show[1].PlayerID, show[2].PlayerID})
+	assert.True(t, show[2].Crib)
+	assert.Equal(t, scores[bob.ID], show[0].Score.Total)
+	assert.Equal(t, scores["crib"], show[2].Score.Total)
+	assert.Equal(t, pegged[1]+show[0].Score.Total, game.CribbageState.PlayerScores[1])
+	for _, showing := range show {
+		assert.Len(t, showing.Cards, 5)
+		assert.Equal(t, CountCribbageHand(cardPointers(showing.Cards[:4]), &showing.Cards[4]), showing.Score)
+	}
+
+	require.NoError(t, game.CribbageNextHand())
+	assert.Nil(t, game.CribbageState.Show)
+	assert.Nil(t, game.CribbageState.LastPeg)
+}
+
+// cardPointers returns pointers to copies of the cards.
+func cardPointers(cards []Card) []*Card {
+	pointers := make([]*Card, len(cards))
+	for i := range cards {
+		card := cards[i]
+		pointers[i] = &card
+	}
+	return pointers
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCribbagePhaseString(t *testing.T) {
@@ -63,17 +64,17 @@ func TestStartCribbageGameWrongPlayerCount(t *testing.T) {
 
 	err := game.StartCribbageGame()
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "cribbage requires exactly 2 players")
+	assert.Contains(t, err.Error(), "cribbage requires 2 to 4 players")
 
-	// Test with 3 players
+	// Test with 5 players
 	game = NewGame(1)
-	game.AddPlayer("Alice")
-	game.AddPlayer("Bob")
-	game.AddPlayer("Charlie")
+	for _, name := range []string{"Alice", "Bob", "Charlie", "Dave", "Eve"} {
+		game.AddPlayer(name)
+	}
 
 	err = game.StartCribbageGame()
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "cribbage requires exactly 2 players")
+	assert.Contains(t, err.Error(), "cribbage requires 2 to 4 players")
 }
 
 func TestCribbageDiscard(t *testing.T) {
@@ -393,4 +394,39 @@ func TestScoreCribbageCards(t *testing.T) {
 	}
 	score = scoreCribbageCards(cards)
 	assert.Greater(t, score, 0) // Should score for pairs and fifteens
-}
\ No newline at end of file
+}
+func TestStartCribbageGameThreeAndFourPlayers(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 3)
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+	require.NoError(t, game.StartCribbageGame())
+	for _, player := range game.Players {
+		assert.Len(t, player.Hand, 5)
+	}
+	assert.Len(t, game.CribbageState.Crib, 1, "one card from the deck starts the crib")
+	assert.Nil(t, game.CribbageState.TeamScores)
+	assert.Equal(t, 1, game.CurrentPlayer)
+
+	alice := game.Players[0]
+	assert.EqualError(t, game.CribbageDiscard(alice.ID, []int{0, 1}), "must discard exactly 1 card")
+	for _, player := range game.Players {
+		require.NoError(t, game.CribbageDiscard(player.ID, []int{0}))
+	}
+	assert.Equal(t, CribbagePlay, game.CribbageState.Phase)
+	assert.Len(t, game.CribbageState.Crib, 4)
+	assert.Equal(t, 52-16-1, game.Deck.RemainingCards())
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.CribbageState.Crib, replayed.CribbageState.Crib)
+
+	game = NewGameWithType(1, Standard, Cribbage, 4)
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+	require.NoError(t, game.StartCribbageGame())
+	assert.Equal(t, []int{0, 0}, game.CribbageState.TeamScores)
+	assert.Empty(t, game.CribbageState.Crib)
+	assert.Equal(t, 32, game.Deck.RemainingCards())
+}
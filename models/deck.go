@@ -1,9 +1,6 @@
 package models
 
-import (
-	"math/rand"
-	"time"
-)
+import "math/rand/v2"
 
 // Deck represents a collection of playing cards with metadata.
 // It maintains the card order for dealing and tracks the deck type for game rules.
@@ -11,6 +8,8 @@ type Deck struct {
 	Cards    []Card   `json:"cards"`
 	Name     string   `json:"name"`
 	DeckType DeckType `json:"deck_type"`
+	Source   *SeededSource `json:"source,omitempty"` // Randomness of a seeded game, saved with the deck
+	rng      *rand.Rand
 }
 
 // NewDeck creates a single standard 52-card deck.
@@ -89,13 +88,30 @@ func (d *Deck) ResetWithDecksAndType(numDecks int, deckType DeckType) {
 // Shuffle randomizes the order of all cards in the deck using Fisher-Yates algorithm.
 // This ensures fair card distribution and prevents predictable card sequences.
 func (d *Deck) Shuffle() {
-	rand.Seed(time.Now().UnixNano())
+	rng := d.random()
 	for i := len(d.Cards) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
+		j := rng.IntN(i + 1)
 		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
 	}
 }
 
+// SetRand sets the randomness the deck shuffles with, such as NewSeededRand for reproducible tests.
+// Passing nil restores the seeded game's source, or the crypto-secure default.
+func (d *Deck) SetRand(rng *rand.Rand) {
+	d.rng = rng
+}
+
+// random returns the deck's randomness, falling back to its seeded source and then the crypto-secure default.
+func (d *Deck) random() *rand.Rand {
+	if d.rng != nil {
+		return d.rng
+	}
+	if d.Source != nil {
+		return rand.New(d.Source)
+	}
+	return secureRand
+}
+
 // Deal removes and returns the top card from the deck.
 // Returns nil if the deck is empty, allowing callers to handle empty deck scenarios.
 func (d *Deck) Deal() *Card {
@@ -0,0 +1,374 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// GameEventType identifies the kind of state change recorded in a game's event log.
+// Every public action that mutates a Game appends exactly one event of the matching type.
+type GameEventType string
+
+const (
+	EventGameCreated       GameEventType = "game_created"
+	EventPlayerAdded       GameEventType = "player_added"
+	EventPlayerRemoved     GameEventType = "player_removed"
+	EventDiscardPileAdded  GameEventType = "discard_pile_added"
+	EventDeckShuffled      GameEventType = "deck_shuffled"
+	EventDeckReset         GameEventType = "deck_reset"
+	EventGameSeeded        GameEventType = "game_seeded"
+	EventShuffleModeSet    GameEventType = "shuffle_mode_set"
+	EventFairCommitted     GameEventType = "fair_shuffle_committed"
+	EventFairClientSeed    GameEventType = "fair_client_seed"
+	EventCardDrawn         GameEventType = "card_drawn"
+	EventCardDealt         GameEventType = "card_dealt"
+	EventCardDiscarded     GameEventType = "card_discarded"
+	EventBlackjackRulesSet GameEventType = "blackjack_rules_set"
+	EventChipsBought       GameEventType = "chips_bought"
+	EventBetPlaced         GameEventType = "bet_placed"
+	EventBlackjackStarted  GameEventType = "blackjack_started"
+	EventPlayerHit         GameEventType = "player_hit"
+	EventPlayerStood       GameEventType = "player_stood"
+	EventPlayerSplit       GameEventType = "player_split"
+	EventPlayerDoubled     GameEventType = "player_doubled"
+	EventPlayerInsured     GameEventType = "player_insured"
+	EventPlayerSurrendered GameEventType = "player_surrendered"
+	EventDealerPeeked      GameEventType = "dealer_peeked"
+	EventNextRound         GameEventType = "next_round"
+	EventDealerPlayed      GameEventType = "dealer_played"
+	EventGlitchjackStarted GameEventType = "glitchjack_started"
+	EventGlitchjackHit     GameEventType = "glitchjack_hit"
+	EventGlitchjackStood   GameEventType = "glitchjack_stood"
+	EventCribbageRulesSet  GameEventType = "cribbage_rules_set"
+	EventCribbageStarted   GameEventType = "cribbage_started"
+	EventCribbageDiscard   GameEventType = "cribbage_discard"
+	EventCribbagePlay      GameEventType = "cribbage_play"
+	EventCribbageGo        GameEventType = "cribbage_go"
+	EventCribbageShow      GameEventType = "cribbage_show"
+	EventCribbageClaim     GameEventType = "cribbage_claim"
+	EventCribbageMuggins   GameEventType = "cribbage_muggins"
+	EventCribbageNextHand  GameEventType = "cribbage_next_hand"
+	EventCribbageNextGame  GameEventType = "cribbage_next_game"
+	EventPokerStarted      GameEventType = "poker_started"
+	EventPokerAction       GameEventType = "poker_action"
+	EventPokerNextHand     GameEventType = "poker_next_hand"
+	EventWarStarted        GameEventType = "war_started"
+	EventWarFlip           GameEventType = "war_flip"
+	EventWarAutoPlay       GameEventType = "war_auto_play"
+	EventGoFishStarted     GameEventType = "gofish_started"
+	EventGoFishAsk         GameEventType = "gofish_ask"
+	EventGoFishDraw        GameEventType = "gofish_draw"
+)
+
+// GameEvent is a single entry in a game's append-only event log.
+// Events carry the action parameters needed to re-apply them plus the cards that moved,
+// so a support engineer can both read what happened and rebuild the game at any point.
+type GameEvent struct {
+	Seq           int             `json:"seq"`
+	Type          GameEventType   `json:"type"`
+	Timestamp     time.Time       `json:"timestamp"`
+	GameID        string          `json:"game_id,omitempty"`
+	PlayerID      string          `json:"player_id,omitempty"`
+	PileID        string          `json:"pile_id,omitempty"`
+	Name          string          `json:"name,omitempty"`
+	CardIndices   []int           `json:"card_indices,omitempty"`
+	FaceUp        bool            `json:"face_up,omitempty"`
+	GameType      GameType        `json:"game_type,omitempty"`
+	MaxPlayers    int             `json:"max_players,omitempty"`
+	Dealt         []Card          `json:"dealt,omitempty"`  // Cards drawn from the deck during the action
+	Cards         []Card          `json:"cards,omitempty"`  // Cards moved out of a hand (discards, plays)
+	Deck          *Deck           `json:"deck,omitempty"`   // Resulting deck for created, shuffle, reset and poker next-hand events
+	Action        string          `json:"action,omitempty"` // Betting action for poker events
+	Amount        int             `json:"amount,omitempty"`
+	Poker         *PokerConfig    `json:"poker,omitempty"`          // Stakes a poker game was started with
+	MaxRounds     int             `json:"max_rounds,omitempty"`     // Round limit a war game was started with
+	TargetID      string          `json:"target_id,omitempty"`      // Player asked for cards in Go Fish
+	Rank          Rank            `json:"rank,omitempty"`           // Rank asked for in Go Fish
+	Rules         *BlackjackRules `json:"rules,omitempty"`          // Table rules attached to a blackjack game
+	CribbageRules *CribbageRules  `json:"cribbage_rules,omitempty"` // Options attached to a cribbage game
+	Claim         int             `json:"claim,omitempty"`          // Claim muggins was called on
+	Fair          *FairShuffle    `json:"fair,omitempty"`           // Seeds of a provably fair game after the action
+	Seed          *uint64         `json:"seed,omitempty"`           // Seed a deterministic game was given
+	ShuffleMode   *ShuffleMode    `json:"shuffle_mode,omitempty"`   // How the deck was shuffled, when not perfectly
+}
+
+// record stamps an event with the next sequence number and appends it to the log. Actions that
+// depend on the time pass it as the event's timestamp so a replay sees the same clock.
+// Any cards drawn from the deck since the previous event are attached as Dealt.
+func (g *Game) record(event GameEvent) {
+	event.Seq = len(g.Events) + 1
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	for _, card := range g.drawn {
+		event.Dealt = append(event.Dealt, *card)
+	}
+	g.drawn = nil
+	g.Events = append(g.Events, event)
+}
+
+// drawCard deals the top card of the deck and remembers it for the next recorded event.
+// All game logic draws through here so the event log captures every card's identity.
+func (g *Game) drawCard() *Card {
+	card := g.Deck.Deal()
+	if card != nil {
+		g.drawn = append(g.drawn, card)
+		g.checkCutCard()
+	}
+	return card
+}
+
+// snapshot returns a deep copy of the deck suitable for storing in an event.
+func (d *Deck) snapshot() *Deck {
+	cards := make([]Card, len(d.Cards))
+	copy(cards, d.Cards)
+	return &Deck{
+		Cards:    cards,
+		Name:     d.Name,
+		DeckType: d.DeckType,
+	}
+}
+
+// restore replaces the deck contents with a previously captured snapshot.
+func (d *Deck) restore(snapshot *Deck) {
+	d.Cards = make([]Card, len(snapshot.Cards))
+	copy(d.Cards, snapshot.Cards)
+	d.Name = snapshot.Name
+	d.DeckType = snapshot.DeckType
+}
+
+// EventsSince returns the events recorded after the given sequence number.
+// Passing 0 returns the full log.
+func (g *Game) EventsSince(seq int) []GameEvent {
+	if seq < 0 {
+		seq = 0
+	}
+	if seq >= len(g.Events) {
+		return []GameEvent{}
+	}
+	events := make([]GameEvent, len(g.Events)-seq)
+	copy(events, g.Events[seq:])
+	return events
+}
+
+// ReplayGame rebuilds a game from its event log, applying events up to and including seq.
+// Each action is re-executed against a fresh game and the cards it draws are checked
+// against the log, so a replay that diverges from history is reported as an error.
+func ReplayGame(events []GameEvent, seq int) (*Game, error) {
+	if len(events) == 0 || events[0].Type != EventGameCreated || events[0].Deck == nil {
+		return nil, fmt.Errorf("event log must start with a %s event", EventGameCreated)
+	}
+	if seq < 1 || seq > len(events) {
+		return nil, fmt.Errorf("event %d out of range (1-%d)", seq, len(events))
+	}
+
+	created := events[0]
+	game := newGame(created.GameID, created.GameType, created.MaxPlayers, created.Deck.snapshot(), created.Timestamp)
+	game.Events = []GameEvent{created}
+
+	for _, event := range events[1:seq] {
+		// Actions that fail part-way still record an event, so only a missing event is fatal
+		before := len(game.Events)
+		err := game.applyEvent(event)
+		if len(game.Events) == before {
+			if err == nil {
+				err = fmt.Errorf("action did not record an event")
+			}
+			return nil, fmt.Errorf("replaying event %d (%s): %w", event.Seq, event.Type, err)
+		}
+
+		replayed := game.Events[len(game.Events)-1]
+		if !sameCards(replayed.Dealt, event.Dealt) {
+			return nil, fmt.Errorf("replaying event %d (%s): dealt cards do not match the log", event.Seq, event.Type)
+		}
+
+		// Keep the original entry so the rebuilt log is identical to the recorded one
+		game.Events[len(game.Events)-1] = event
+		game.LastUsed = event.Timestamp
+	}
+
+	return game, nil
+}
+
+// applyEvent re-executes a single recorded action against the game.
+func (g *Game) applyEvent(event GameEvent) error {
+	switch event.Type {
+	case EventPlayerAdded:
+		if g.addPlayer(event.PlayerID, event.Name) == nil {
+			return fmt.Errorf("game is full")
+		}
+		g.record(GameEvent{Type: EventPlayerAdded, PlayerID: event.PlayerID, Name: event.Name})
+	case EventPlayerRemoved:
+		if !g.RemovePlayer(event.PlayerID) {
+			return fmt.Errorf("player not found")
+		}
+	case EventDiscardPileAdded:
+		if g.AddDiscardPile(event.PileID, event.Name) == nil {
+			return fmt.Errorf("discard pile already exists")
+		}
+	case EventDeckShuffled, EventDeckReset:
+		if event.Deck == nil {
+			return fmt.Errorf("missing deck snapshot")
+		}
+		if event.Type == EventDeckShuffled && g.Fair != nil {
+			g.Fair.Nonce++
+		}
+		g.Deck.restore(event.Deck)
+		g.record(GameEvent{Type: event.Type, Deck: g.Deck.snapshot()})
+	case EventShuffleModeSet:
+		if event.Deck == nil || event.ShuffleMode == nil {
+			return fmt.Errorf("missing shuffle mode or deck snapshot")
+		}
+		mode := *event.ShuffleMode
+		g.ShuffleMode = &mode
+		g.Deck.restore(event.Deck)
+		g.record(GameEvent{Type: event.Type, ShuffleMode: &mode, Deck: g.Deck.snapshot()})
+	case EventGameSeeded:
+		if event.Seed == nil {
+			return fmt.Errorf("missing seed")
+		}
+		return g.SetSeed(*event.Seed)
+	case EventFairCommitted:
+		if event.Fair == nil {
+			return fmt.Errorf("missing fairness seeds")
+		}
+		return g.commitFairShuffle(event.Fair.ServerSeed)
+	case EventFairClientSeed:
+		if event.Fair == nil {
+			return fmt.Errorf("missing fairness seeds")
+		}
+		return g.SetClientSeed(event.Fair.ClientSeed)
+	case EventCardDrawn:
+		if g.DrawCard() == nil {
+			return fmt.Errorf("no cards remaining in deck")
+		}
+	case EventCardDealt:
+		if g.DealToPlayer(event.PlayerID, event.FaceUp) == nil {
+			return fmt.Errorf("could not deal to player")
+		}
+	case EventCardDiscarded:
+		if len(event.CardIndices) != 1 {
+			return fmt.Errorf("missing card index")
+		}
+		if _, err := g.DiscardCard(event.PlayerID, event.PileID, event.CardIndices[0]); err != nil {
+			return err
+		}
+	case EventBlackjackRulesSet:
+		if event.Rules == nil {
+			return fmt.Errorf("missing table rules")
+		}
+		return g.SetBlackjackRules(*event.Rules)
+	case EventChipsBought:
+		return g.PlayerBuyIn(event.PlayerID, event.Amount)
+	case EventBetPlaced:
+		return g.PlaceBet(event.PlayerID, event.Amount)
+	case EventBlackjackStarted:
+		return g.StartBlackjackGame()
+	case EventPlayerHit:
+		return g.PlayerHit(event.PlayerID)
+	case EventPlayerStood:
+		return g.PlayerStand(event.PlayerID)
+	case EventPlayerSplit:
+		return g.PlayerSplit(event.PlayerID)
+	case EventPlayerDoubled:
+		return g.PlayerDouble(event.PlayerID)
+	case EventPlayerInsured:
+		return g.PlayerInsure(event.PlayerID)
+	case EventPlayerSurrendered:
+		return g.PlayerSurrender(event.PlayerID)
+	case EventDealerPeeked:
+		if g.peekForBlackjack() == nil {
+			return fmt.Errorf("dealer does not have blackjack")
+		}
+	case EventDealerPlayed:
+		return g.PlayDealer()
+	case EventNextRound:
+		serverSeed := ""
+		if event.Fair != nil {
+			serverSeed = event.Fair.ServerSeed
+		}
+		return g.nextBlackjackRound(event.Deck, serverSeed)
+	case EventGlitchjackStarted:
+		return g.StartGlitchjackGame()
+	case EventGlitchjackHit:
+		return g.GlitchjackHit(event.PlayerID)
+	case EventGlitchjackStood:
+		return g.GlitchjackStand(event.PlayerID)
+	case EventCribbageRulesSet:
+		if event.CribbageRules == nil {
+			return fmt.Errorf("missing cribbage rules")
+		}
+		return g.SetCribbageRules(*event.CribbageRules)
+	case EventCribbageStarted:
+		return g.StartCribbageGame()
+	case EventCribbageDiscard:
+		indices := make([]int, len(event.CardIndices))
+		copy(indices, event.CardIndices)
+		return g.CribbageDiscard(event.PlayerID, indices)
+	case EventCribbagePlay:
+		if len(event.CardIndices) != 1 {
+			return fmt.Errorf("missing card index")
+		}
+		return g.CribbagePlay(event.PlayerID, event.CardIndices[0])
+	case EventCribbageGo:
+		return g.CribbageGo(event.PlayerID)
+	case EventCribbageNextHand:
+		if event.Deck == nil {
+			return fmt.Errorf("missing deck snapshot")
+		}
+		return g.nextCribbageHand(event.Deck, event.Timestamp)
+	case EventCribbageNextGame:
+		if event.Deck == nil {
+			return fmt.Errorf("missing deck snapshot")
+		}
+		return g.nextCribbageGame(event.Deck, event.Timestamp)
+	case EventCribbageShow:
+		if g.CribbageShow() == nil {
+			return fmt.Errorf("not in show phase")
+		}
+	case EventCribbageClaim:
+		return g.claimCribbage(event.PlayerID, event.Amount, event.Timestamp)
+	case EventCribbageMuggins:
+		return g.callMuggins(event.PlayerID, event.Claim, event.Timestamp)
+	case EventPokerStarted:
+		if event.Poker == nil {
+			return fmt.Errorf("missing poker stakes")
+		}
+		return g.StartPokerGame(*event.Poker)
+	case EventPokerAction:
+		return g.PokerAct(event.PlayerID, PokerAction(event.Action), event.Amount)
+	case EventPokerNextHand:
+		if event.Deck == nil {
+			return fmt.Errorf("missing deck snapshot")
+		}
+		return g.nextPokerHand(event.Deck)
+	case EventWarStarted:
+		return g.StartWarGame(event.MaxRounds)
+	case EventWarFlip:
+		return g.WarFlip()
+	case EventWarAutoPlay:
+		return g.WarAutoPlay()
+	case EventGoFishStarted:
+		return g.StartGoFishGame()
+	case EventGoFishAsk:
+		return g.GoFishAsk(event.PlayerID, event.TargetID, event.Rank)
+	case EventGoFishDraw:
+		return g.GoFishDraw(event.PlayerID)
+	default:
+		return fmt.Errorf("unknown event type %q", event.Type)
+	}
+	return nil
+}
+
+// sameCards reports whether two card lists are identical in order, rank, suit and face.
+func sameCards(a, b []Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
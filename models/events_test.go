@@ -0,0 +1,191 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gameState captures the parts of a game that replay must reproduce exactly.
+func gameState(t *testing.T, g *Game) string {
+	data, err := json.Marshal(struct {
+		ID            string
+		Status        GameStatus
+		Deck          *Deck
+		Players       []*Player
+		Dealer        *Player
+		DiscardPiles  map[string]*DiscardPile
+		CurrentPlayer int
+		CribbageState *CribbageState
+	}{g.ID, g.Status, g.Deck, g.Players, g.Dealer, g.DiscardPiles, g.CurrentPlayer, g.CribbageState})
+	require.NoError(t, err)
+	return string(data)
+}
+
+// assertReplaysHistory checks that replaying to every event reproduces the recorded snapshots.
+func assertReplaysHistory(t *testing.T, g *Game, snapshots map[int]string) {
+	for seq, expected := range snapshots {
+		replayed, err := ReplayGame(g.Events, seq)
+		require.NoError(t, err, "seq %d", seq)
+		assert.Equal(t, expected, gameState(t, replayed), "seq %d", seq)
+		assert.Len(t, replayed.Events, seq)
+	}
+}
+
+func TestGameEventsRecorded(t *testing.T) {
+	game := NewGame(1)
+	require.Len(t, game.Events, 1)
+	assert.Equal(t, EventGameCreated, game.Events[0].Type)
+	assert.Equal(t, 1, game.Events[0].Seq)
+	assert.Equal(t, game.ID, game.Events[0].GameID)
+	assert.Len(t, game.Events[0].Deck.Cards, 52)
+
+	alice := game.AddPlayer("Alice")
+	game.ShuffleDeck()
+	top := game.Deck.Cards[0]
+	card := game.DealToPlayer(alice.ID, true)
+	require.NotNil(t, card)
+	_, err := game.DiscardCard(alice.ID, "main", 0)
+	require.NoError(t, err)
+
+	types := []GameEventType{}
+	for i, event := range game.Events {
+		assert.Equal(t, i+1, event.Seq)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []GameEventType{EventGameCreated, EventPlayerAdded, EventDeckShuffled, EventCardDealt, EventCardDiscarded}, types)
+
+	assert.Equal(t, alice.ID, game.Events[1].PlayerID)
+	assert.Equal(t, "Alice", game.Events[1].Name)
+	assert.Equal(t, top, game.Events[2].Deck.Cards[0])
+	require.Len(t, game.Events[3].Dealt, 1)
+	assert.Equal(t, top.Rank, game.Events[3].Dealt[0].Rank)
+	assert.Equal(t, top.Suit, game.Events[3].Dealt[0].Suit)
+	assert.Equal(t, []int{0}, game.Events[4].CardIndices)
+	assert.Equal(t, []Card{*card}, game.Events[4].Cards)
+
+	// Failed actions leave no trace in the log
+	assert.Nil(t, game.DealToPlayer("missing", true))
+	_, err = game.DiscardCard(alice.ID, "main", 0)
+	assert.Error(t, err)
+	assert.Len(t, game.Events, 5)
+
+	assert.Len(t, game.EventsSince(3), 2)
+	assert.Empty(t, game.EventsSince(5))
+	assert.Len(t, game.EventsSince(-1), 5)
+}
+
+func TestReplayBlackjackGame(t *testing.T) {
+	game := NewGame(2)
+	snapshots := map[int]string{1: gameState(t, game)}
+	step := func() { snapshots[len(game.Events)] = gameState(t, game) }
+
+	alice := game.AddPlayer("Alice")
+	step()
+	bob := game.AddPlayer("Bob")
+	step()
+	game.ShuffleDeck()
+	step()
+	require.NoError(t, game.StartBlackjackGame())
+	step()
+	require.NoError(t, game.PlayerHit(alice.ID))
+	step()
+	require.NoError(t, game.PlayerStand(alice.ID))
+	step()
+	require.NoError(t, game.PlayerStand(bob.ID))
+	step()
+	assert.Equal(t, GameFinished, game.Status)
+
+	// Standing last triggers the dealer within the same event
+	last := game.Events[len(game.Events)-1]
+	assert.Equal(t, EventPlayerStood, last.Type)
+	assert.Len(t, last.Dealt, len(game.Dealer.Hand)-2)
+
+	assertReplaysHistory(t, game, snapshots)
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.GetGameResult(), replayed.GetGameResult())
+	assert.Equal(t, game.Events, replayed.Events)
+}
+
+func TestReplayCribbageGame(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	snapshots := map[int]string{}
+	step := func() { snapshots[len(game.Events)] = gameState(t, game) }
+
+	game.AddPlayer("Alice")
+	game.AddPlayer("Bob")
+	game.ShuffleDeck()
+	require.NoError(t, game.StartCribbageGame())
+	step()
+	for _, player := range game.Players {
+		require.NoError(t, game.CribbageDiscard(player.ID, []int{0, 1}))
+		step()
+	}
+
+	// Play out the hand, saying go whenever the current player cannot play
+	for game.CribbageState.Phase == CribbagePlay {
+		player := game.Players[game.CurrentPlayer]
+		played := false
+		for i := range player.Hand {
+			if game.CribbagePlay(player.ID, i) == nil {
+				played = true
+				break
+			}
+		}
+		if !played {
+			require.NoError(t, game.CribbageGo(player.ID))
+		}
+		step()
+	}
+	require.NotNil(t, game.CribbageShow())
+	step()
+
+	assertReplaysHistory(t, game, snapshots)
+}
+
+func TestReplayGlitchjackGame(t *testing.T) {
+	game := NewGameWithType(1, Standard, Glitchjack, 2)
+	game.ReplaceDeck(NewGlitchjackDeck())
+	alice := game.AddPlayer("Alice")
+	require.NoError(t, game.StartGlitchjackGame())
+	snapshots := map[int]string{len(game.Events): gameState(t, game)}
+
+	require.NoError(t, game.GlitchjackStand(alice.ID))
+	snapshots[len(game.Events)] = gameState(t, game)
+	assert.Equal(t, GameFinished, game.Status)
+
+	assertReplaysHistory(t, game, snapshots)
+}
+
+func TestReplayGameErrors(t *testing.T) {
+	_, err := ReplayGame(nil, 1)
+	assert.Error(t, err)
+
+	game := NewGame(1)
+	alice := game.AddPlayer("Alice")
+	game.DealToPlayer(alice.ID, true)
+
+	_, err = ReplayGame(game.Events, 0)
+	assert.Error(t, err)
+	_, err = ReplayGame(game.Events, 4)
+	assert.Error(t, err)
+
+	// A log whose dealt cards disagree with the deck order is rejected
+	tampered := make([]GameEvent, len(game.Events))
+	copy(tampered, game.Events)
+	tampered[2].Dealt = []Card{{Rank: King, Suit: Spades, FaceUp: true}}
+	if tampered[2].Dealt[0] == game.Events[2].Dealt[0] {
+		tampered[2].Dealt[0].Suit = Hearts
+	}
+	_, err = ReplayGame(tampered, 3)
+	assert.Error(t, err)
+
+	replayed, err := ReplayGame(game.Events, 3)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, replayed.Players[0].ID)
+	assert.Len(t, replayed.Players[0].Hand, 1)
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	mrand "math/rand/v2"
+)
+
+// maxClientSeed caps the length of a client seed.
+const maxClientSeed = 64
+
+// FairShuffle is the commit-reveal record of a provably fair game. The commitment is published when
+// the game is created; the server seed behind it stays hidden until the round is finished.
+type FairShuffle struct {
+	Commitment string      `json:"commitment"`            // Hex SHA-256 of the server seed
+	ServerSeed string      `json:"server_seed,omitempty"` // Revealed once the round is finished
+	ClientSeed string      `json:"client_seed"`
+	Decks      int         `json:"decks"`              // Decks in the shoe, each drawn at random for Glitchjack
+	Nonce      int         `json:"nonce"`              // Shuffles of a dealt deck made from these seeds; 0 for the fresh deck
+	Revealed   []FairSeeds `json:"revealed,omitempty"` // Seeds of earlier rounds, oldest first
+}
+
+// FairSeeds are the seeds a finished round was dealt from.
+type FairSeeds struct {
+	Commitment string `json:"commitment"`
+	ServerSeed string `json:"server_seed"`
+	ClientSeed string `json:"client_seed"`
+	Nonce      int    `json:"nonce"`
+}
+
+// FairCommitment returns the commitment published for a server seed: its hex SHA-256.
+func FairCommitment(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// FairRand returns the generator a provably fair shuffle draws from: ChaCha8 keyed with the
+// SHA-256 of "server_seed:client_seed:nonce".
+func FairRand(serverSeed, clientSeed string, nonce int) *mrand.Rand {
+	key := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%d", serverSeed, clientSeed, nonce)))
+	return mrand.New(mrand.NewChaCha8(key))
+}
+
+// FairDeck rebuilds the deck a provably fair game deals from its seeds: the decks in order, or
+// drawn one Glitchjack deck after another, then shuffled, all from FairRand with nonce 0.
+func FairDeck(serverSeed, clientSeed string, gameType GameType, deckType DeckType, numDecks int) *Deck {
+	rng := FairRand(serverSeed, clientSeed, 0)
+	var deck *Deck
+	if gameType == Glitchjack {
+		deck = NewGlitchjackDeckWithRand(rng)
+		for i := 1; i < numDecks; i++ {
+			deck.Cards = append(deck.Cards, NewGlitchjackDeckWithRand(rng).Cards...)
+		}
+	} else {
+		deck = NewCustomDeck(numDecks, deckType)
+		deck.SetRand(rng)
+	}
+	deck.Shuffle()
+	return deck
+}
+
+// newServerSeed returns 32 bytes from crypto/rand, hex encoded.
+func newServerSeed() string {
+	seed := make([]byte, 32)
+	rand.Read(seed) // crypto/rand.Read never fails; it aborts the program if the OS source is broken
+	return hex.EncodeToString(seed)
+}
+
+// MakeProvablyFair commits a blackjack or Glitchjack table to a fresh server seed and rebuilds its
+// deck, with as many decks as it holds, from that seed before anyone has had the chance to add a client seed.
+func (g *Game) MakeProvablyFair() error {
+	return g.commitFairShuffle(newServerSeed())
+}
+
+// commitFairShuffle commits the game to the given server seed and rebuilds its deck from it.
+func (g *Game) commitFairShuffle(serverSeed string) error {
+	if g.GameType != Blackjack && g.GameType != Glitchjack {
+		return fmt.Errorf("provably fair shuffles only apply to blackjack and glitchjack games")
+	}
+	if g.Status != GameWaiting || g.Round > 0 {
+		return fmt.Errorf("provably fair shuffles must be chosen before the first deal")
+	}
+	if g.Seed != nil {
+		return fmt.Errorf("seeded games cannot be provably fair")
+	}
+	if g.ShuffleMode != nil {
+		return fmt.Errorf("provably fair games always use a perfect shuffle")
+	}
+	if g.Fair != nil {
+		return fmt.Errorf("game is already provably fair")
+	}
+
+	decks := g.Deck.RemainingCards() / g.Deck.DeckType.CardsPerDeck()
+	g.Fair = &FairShuffle{Commitment: FairCommitment(serverSeed), ServerSeed: serverSeed, Decks: max(decks, 1)}
+	g.dealFairDeck()
+	g.record(GameEvent{Type: EventFairCommitted, Fair: g.Fair.snapshot(), Deck: g.Deck.snapshot()})
+	return nil
+}
+
+// SetClientSeed mixes a player's seed into a provably fair game's shuffle. The deck is rebuilt from
+// the committed server seed and the client seed, so the server cannot choose the order once it is known.
+func (g *Game) SetClientSeed(clientSeed string) error {
+	if g.Fair == nil {
+		return fmt.Errorf("game is not provably fair")
+	}
+	if g.Status != GameWaiting {
+		return fmt.Errorf("client seed can only be set before the deal")
+	}
+	if clientSeed == "" || len(clientSeed) > maxClientSeed {
+		return fmt.Errorf("client seed must be 1-%d characters", maxClientSeed)
+	}
+
+	g.Fair.ClientSeed = clientSeed
+	g.Fair.Nonce = 0
+	g.dealFairDeck()
+	g.record(GameEvent{Type: EventFairClientSeed, Fair: g.Fair.snapshot(), Deck: g.Deck.snapshot()})
+	return nil
+}
+
+// dealFairDeck replaces the deck with the one the current seeds derive, keeping its name.
+func (g *Game) dealFairDeck() {
+	deck := FairDeck(g.Fair.ServerSeed, g.Fair.ClientSeed, g.GameType, g.Deck.DeckType, g.Fair.Decks)
+	deck.Name = g.Deck.Name
+	g.Deck = deck
+}
+
+// rotateFairSeeds reveals the finished round's seeds and commits to the next server seed, keeping
+// the client seed, then deals the next round's deck from them.
+func (g *Game) rotateFairSeeds(serverSeed string) {
+	g.Fair.Revealed = append(g.Fair.Revealed, FairSeeds{
+		Commitment: g.Fair.Commitment,
+		ServerSeed: g.Fair.ServerSeed,
+		ClientSeed: g.Fair.ClientSeed,
+		Nonce:      g.Fair.Nonce,
+	})
+	g.Fair.Commitment = FairCommitment(serverSeed)
+	g.Fair.ServerSeed = serverSeed
+	g.Fair.Nonce = 0
+	g.dealFairDeck()
+}
+
+// fairReshuffle shuffles the dealt deck from the next nonce of the game's seeds.
+func (g *Game) fairReshuffle() {
+	g.Fair.Nonce++
+	g.Deck.SetRand(FairRand(g.Fair.ServerSeed, g.Fair.ClientSeed, g.Fair.Nonce))
+	g.Deck.Shuffle()
+}
+
+// snapshot returns a copy of the fairness record suitable for storing in an event.
+func (f *FairShuffle) snapshot() *FairShuffle {
+	copied := *f
+	copied.Revealed = append([]FairSeeds(nil), f.Revealed...)
+	return &copied
+}
+
+// fairRevealed reports whether the current server seed may be shown to players: once the round is over.
+func (g *Game) fairRevealed() bool {
+	return g.Status == GameFinished
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairDeck(t *testing.T) {
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", FairCommitment("abc"))
+
+	deck := FairDeck("server", "client", Blackjack, Standard, 2)
+	assert.Len(t, deck.Cards, 104)
+	assert.Equal(t, deck.Cards, FairDeck("server", "client", Blackjack, Standard, 2).Cards)
+	assert.NotEqual(t, deck.Cards, FairDeck("server", "other", Blackjack, Standard, 2).Cards)
+	assert.NotEqual(t, deck.Cards, FairDeck("other", "client", Blackjack, Standard, 2).Cards)
+	assert.Len(t, FairDeck("server", "client", Blackjack, Spanish21, 1).Cards, 48)
+
+	glitch := FairDeck("server", "client", Glitchjack, Standard, 3)
+	assert.Len(t, glitch.Cards, 156)
+	assert.Equal(t, glitch.Cards, FairDeck("server", "client", Glitchjack, Standard, 3).Cards)
+}
+
+func TestProvablyFairGlitchjack(t *testing.T) {
+	game := NewGameWithType(1, Standard, Glitchjack, 6)
+	game.ReplaceDeck(NewGlitchjackDeck())
+	require.NoError(t, game.MakeProvablyFair())
+	assert.Error(t, game.MakeProvablyFair(), "already committed")
+	fair := game.Fair
+	assert.Equal(t, FairCommitment(fair.ServerSeed), fair.Commitment)
+	assert.Len(t, fair.ServerSeed, 64)
+	assert.Equal(t, FairDeck(fair.ServerSeed, "", Glitchjack, Standard, 1).Cards, game.Deck.Cards)
+
+	assert.Error(t, game.SetClientSeed(""))
+	require.NoError(t, game.SetClientSeed("lucky"))
+	assert.Equal(t, FairDeck(fair.ServerSeed, "lucky", Glitchjack, Standard, 1).Cards, game.Deck.Cards)
+
+	alice := game.AddPlayer("Alice")
+	require.NoError(t, game.StartGlitchjackGame())
+	assert.EqualError(t, game.SetClientSeed("late"), "client seed can only be set before the deal")
+
+	// The server seed stays secret from players until the round is over
+	view := game.ViewFor(Viewer{PlayerID: alice.ID})
+	assert.Empty(t, view.Fair.ServerSeed)
+	assert.Equal(t, fair.Commitment, view.Fair.Commitment)
+	for _, event := range game.EventsFor(Viewer{PlayerID: alice.ID}, 0) {
+		if event.Fair != nil {
+			assert.Empty(t, event.Fair.ServerSeed, event.Type)
+		}
+	}
+	assert.Equal(t, fair.ServerSeed, game.ViewFor(Viewer{Admin: true}).Fair.ServerSeed)
+
+	if game.Status != GameFinished {
+		require.NoError(t, game.GlitchjackStand(alice.ID))
+	}
+	require.Equal(t, GameFinished, game.Status)
+	assert.Equal(t, fair.ServerSeed, game.ViewFor(Viewer{PlayerID: alice.ID}).Fair.ServerSeed)
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.Fair, replayed.Fair)
+	assert.Equal(t, game.Deck.Cards, replayed.Deck.Cards)
+}
+
+func TestProvablyFairRoundsRotateSeeds(t *testing.T) {
+	game := NewGame(2)
+	rules := DefaultBlackjackRules()
+	rules.Decks = 2
+	rules.BurnCards = 3
+	require.NoError(t, game.SetBlackjackRules(rules))
+	require.NoError(t, game.MakeProvablyFair())
+	first := *game.Fair
+	require.NoError(t, game.SetClientSeed("lucky"))
+
+	// A host shuffle draws from the next nonce of the same seeds
+	game.ShuffleDeck()
+	assert.Equal(t, 1, game.Fair.Nonce)
+	assert.Equal(t, 2, game.Fair.Decks)
+	deck := FairDeck(first.ServerSeed, "lucky", Blackjack, Standard, 2)
+	deck.SetRand(FairRand(first.ServerSeed, "lucky", 1))
+	deck.Shuffle()
+	assert.Equal(t, deck.Cards, game.Deck.Cards)
+
+	alice := game.AddPlayer("Alice")
+	require.NoError(t, game.StartBlackjackGame())
+	if game.Status != GameFinished {
+		require.NoError(t, game.PlayerStand(alice.ID))
+	}
+	require.NoError(t, game.NextRound())
+	assert.Nil(t, game.Shoe, "fair tables deal every round from a fresh deck rather than a shoe")
+	assert.Nil(t, game.ShoeStatus())
+
+	fair := game.Fair
+	require.Len(t, fair.Revealed, 1)
+	assert.Equal(t, FairSeeds{Commitment: first.Commitment, ServerSeed: first.ServerSeed, ClientSeed: "lucky", Nonce: 1}, fair.Revealed[0])
+	assert.NotEqual(t, first.ServerSeed, fair.ServerSeed)
+	assert.Equal(t, FairCommitment(fair.ServerSeed), fair.Commitment)
+	assert.Equal(t, "lucky", fair.ClientSeed)
+	assert.Equal(t, 0, fair.Nonce)
+	assert.Equal(t, FairDeck(fair.ServerSeed, "lucky", Blackjack, Standard, 2).Cards, game.Deck.Cards)
+	assert.Empty(t, game.ViewFor(Viewer{PlayerID: alice.ID}).Fair.ServerSeed, "the new seed is secret")
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.Fair, replayed.Fair)
+	assert.Equal(t, game.Deck.Cards, replayed.Deck.Cards)
+
+	assert.Error(t, NewGameWithType(1, Standard, Cribbage, 2).MakeProvablyFair())
+	assert.Error(t, game.MakeProvablyFair())
+	assert.EqualError(t, NewGame(1).SetClientSeed("lucky"), "game is not provably fair")
+}
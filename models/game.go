@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -32,7 +33,7 @@ func (gs GameStatus) String() string {
 }
 
 // Game represents a complete card game session with players, deck, and game state.
-// It supports multiple game types (Blackjack, Cribbage) and manages all game operations.
+// It supports multiple game types (Blackjack, Cribbage, Poker, War, Go Fish) and manages all game operations.
 type Game struct {
 	ID           string                  `json:"id"`
 	GameType     GameType                `json:"game_type"`
@@ -44,8 +45,21 @@ type Game struct {
 	MaxPlayers   int                     `json:"max_players"`
 	CurrentPlayer int                    `json:"current_player"`
 	CribbageState *CribbageState         `json:"cribbage_state,omitempty"`
+	PokerState   *PokerState             `json:"poker_state,omitempty"`
+	WarState     *WarState               `json:"war_state,omitempty"`
+	GoFishState  *GoFishState            `json:"gofish_state,omitempty"`
+	BlackjackRules *BlackjackRules       `json:"blackjack_rules,omitempty"`
+	CribbageRules  *CribbageRules        `json:"cribbage_rules,omitempty"`
+	Fair         *FairShuffle            `json:"fair,omitempty"`      // Seeds of a provably fair game
+	Seed         *uint64                 `json:"seed,omitempty"`      // Seed of a deterministic game
+	ShuffleMode  *ShuffleMode            `json:"shuffle_mode,omitempty"` // How the deck is shuffled; perfect when nil
+	Round        int                     `json:"round,omitempty"`     // Blackjack round being bet on or played, from 1
+	Shoe         *Shoe                   `json:"shoe,omitempty"`      // Shoe a blackjack table is dealing from
+	Events       []GameEvent             `json:"events,omitempty"`
 	Created      time.Time               `json:"created"`
 	LastUsed     time.Time               `json:"last_used"`
+
+	drawn []*Card // Cards drawn since the last recorded event
 }
 
 // NewGame creates a new blackjack game with the specified number of standard decks.
@@ -63,18 +77,33 @@ func NewCustomGame(numDecks int, deckType DeckType) *Game {
 // NewGameWithType creates a fully customized game with all parameters specified.
 // This is the most flexible constructor supporting different games, decks, and player limits.
 func NewGameWithType(numDecks int, deckType DeckType, gameType GameType, maxPlayers int) *Game {
+	game := newGame(uuid.New().String(), gameType, maxPlayers, NewCustomDeck(numDecks, deckType), time.Now())
+	game.record(GameEvent{
+		Type:       EventGameCreated,
+		GameID:     game.ID,
+		GameType:   gameType,
+		MaxPlayers: maxPlayers,
+		Deck:       game.Deck.snapshot(),
+	})
+	return game
+}
+
+// newGame builds an empty game around an existing deck without recording any events.
+// It is shared by the public constructors and the replay engine.
+func newGame(id string, gameType GameType, maxPlayers int, deck *Deck, created time.Time) *Game {
 	game := &Game{
-		ID:            uuid.New().String(),
+		ID:            id,
 		GameType:      gameType,
 		Status:        GameWaiting,
-		Deck:          NewCustomDeck(numDecks, deckType),
+		Deck:          deck,
 		Players:       []*Player{},
 		Dealer:        &Player{ID: "dealer", Name: "Dealer", Hand: []*Card{}, Standing: false, Busted: false},
 		DiscardPiles:  make(map[string]*DiscardPile),
 		MaxPlayers:    maxPlayers,
 		CurrentPlayer: 0,
-		Created:       time.Now(),
-		LastUsed:      time.Now(),
+		Events:        []GameEvent{},
+		Created:       created,
+		LastUsed:      created,
 	}
 	
 	// Create a default discard pile
@@ -96,12 +125,21 @@ func (g *Game) UpdateLastUsed() {
 // AddPlayer creates and adds a new player to the game.
 // Returns nil if the game is at maximum capacity, otherwise returns the new player.
 func (g *Game) AddPlayer(name string) *Player {
+	player := g.addPlayer(uuid.New().String(), name)
+	if player != nil {
+		g.record(GameEvent{Type: EventPlayerAdded, PlayerID: player.ID, Name: name})
+	}
+	return player
+}
+
+// addPlayer seats a player with a known ID, used by AddPlayer and by replay.
+func (g *Game) addPlayer(id, name string) *Player {
 	if len(g.Players) >= g.MaxPlayers {
 		return nil
 	}
 	
 	player := &Player{
-		ID:       uuid.New().String(),
+		ID:       id,
 		Name:     name,
 		Hand:     []*Card{},
 		Standing: false,
@@ -132,6 +170,7 @@ func (g *Game) RemovePlayer(playerID string) bool {
 	for i, player := range g.Players {
 		if player.ID == playerID {
 			g.Players = append(g.Players[:i], g.Players[i+1:]...)
+			g.record(GameEvent{Type: EventPlayerRemoved, PlayerID: playerID})
 			return true
 		}
 	}
@@ -141,12 +180,22 @@ func (g *Game) RemovePlayer(playerID string) bool {
 // DealToPlayer deals a single card from the deck to the specified player.
 // The card's face-up status can be controlled, used for initial deals and hits.
 func (g *Game) DealToPlayer(playerID string, faceUp bool) *Card {
+	card := g.dealToPlayer(playerID, faceUp)
+	if card != nil {
+		g.record(GameEvent{Type: EventCardDealt, PlayerID: playerID, FaceUp: faceUp})
+	}
+	return card
+}
+
+// dealToPlayer moves the top card of the deck into a player's hand without recording an event.
+// Game actions that deal several cards use this and record a single event for the whole action.
+func (g *Game) dealToPlayer(playerID string, faceUp bool) *Card {
 	player := g.GetPlayer(playerID)
 	if player == nil {
 		return nil
 	}
 	
-	card := g.Deck.Deal()
+	card := g.drawCard()
 	if card == nil {
 		return nil
 	}
@@ -169,6 +218,7 @@ func (g *Game) AddDiscardPile(id, name string) *DiscardPile {
 		Cards: []*Card{},
 	}
 	g.DiscardPiles[id] = pile
+	g.record(GameEvent{Type: EventDiscardPileAdded, PileID: id, Name: name})
 	return pile
 }
 
@@ -176,4 +226,62 @@ func (g *Game) AddDiscardPile(id, name string) *DiscardPile {
 // Returns nil if the pile doesn't exist in the game.
 func (g *Game) GetDiscardPile(id string) *DiscardPile {
 	return g.DiscardPiles[id]
-}
\ No newline at end of file
+}
+
+// ShuffleDeck shuffles the game's deck and records the resulting card order.
+func (g *Game) ShuffleDeck() {
+	if g.Fair != nil {
+		g.fairReshuffle()
+	} else {
+		g.shuffle()
+	}
+	g.record(GameEvent{Type: EventDeckShuffled, Deck: g.Deck.snapshot()})
+}
+
+// ResetDeck rebuilds the game's deck with the given count and type and records the result.
+func (g *Game) ResetDeck(numDecks int, deckType DeckType) {
+	g.Deck.ResetWithDecksAndType(numDecks, deckType)
+	g.record(GameEvent{Type: EventDeckReset, Deck: g.Deck.snapshot()})
+}
+
+// ReplaceDeck swaps in a new deck, such as a generated Glitchjack deck, and records it.
+func (g *Game) ReplaceDeck(deck *Deck) {
+	g.Deck = deck
+	g.record(GameEvent{Type: EventDeckReset, Deck: g.Deck.snapshot()})
+}
+
+// DrawCard deals the top card of the deck face up to the table rather than to a player.
+// Returns nil if the deck is empty.
+func (g *Game) DrawCard() *Card {
+	card := g.drawCard()
+	if card == nil {
+		return nil
+	}
+	
+	card.FaceUp = true
+	g.record(GameEvent{Type: EventCardDrawn})
+	return card
+}
+
+// DiscardCard moves a card from a player's hand onto the named discard pile.
+// Returns an error if the player, pile, or card index is invalid.
+func (g *Game) DiscardCard(playerID, pileID string, cardIndex int) (*Card, error) {
+	player := g.GetPlayer(playerID)
+	if player == nil {
+		return nil, fmt.Errorf("player not found")
+	}
+	
+	pile := g.GetDiscardPile(pileID)
+	if pile == nil {
+		return nil, fmt.Errorf("discard pile not found")
+	}
+	
+	card := player.RemoveCard(cardIndex)
+	if card == nil {
+		return nil, fmt.Errorf("invalid card index")
+	}
+	
+	pile.AddCard(card)
+	g.record(GameEvent{Type: EventCardDiscarded, PlayerID: playerID, PileID: pileID, CardIndices: []int{cardIndex}, Cards: []Card{*card}})
+	return card, nil
+}
@@ -7,6 +7,20 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// rankedCards returns a card of each rank in order, cycling through the suits.
+func rankedCards(ranks []Rank) []Card {
+	cards := make([]Card, len(ranks))
+	for i, rank := range ranks {
+		cards[i] = Card{Rank: rank, Suit: Suit(i % 4)}
+	}
+	return cards
+}
+
+// stackDeck puts cards of the given ranks on top of the game's deck, to be dealt first.
+func stackDeck(game *Game, top []Rank) {
+	game.Deck.Cards = append(rankedCards(top), game.Deck.Cards...)
+}
+
 func TestNewGame(t *testing.T) {
 	game := NewGame(1)
 	
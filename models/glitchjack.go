@@ -1,8 +1,8 @@
 package models
 
 import (
-	"math/rand"
-	"time"
+	"fmt"
+	"math/rand/v2"
 )
 
 // GlitchjackGame represents a Glitchjack game with the same rules as Blackjack
@@ -16,20 +16,26 @@ type GlitchjackGame struct {
 // NewGlitchjackDeck creates a new deck for Glitchjack with 52 randomly selected cards
 // from the standard deck. Cards can repeat (e.g., multiple Ace of Hearts).
 func NewGlitchjackDeck() *Deck {
-	rand.Seed(time.Now().UnixNano())
-	
+	return NewGlitchjackDeckWithRand(nil)
+}
+
+// NewGlitchjackDeckWithRand draws a Glitchjack deck with the given randomness, which the deck
+// then keeps for its shuffles. Nil uses the crypto-secure default.
+func NewGlitchjackDeckWithRand(rng *rand.Rand) *Deck {
 	deck := &Deck{
 		Cards:    make([]Card, 0, 52),
-		Name:     GenerateDeckName(),
 		DeckType: Standard, // Using Standard type but with random composition
+		rng:      rng,
 	}
+	rng = deck.random()
+	deck.Name = generateDeckName(rng)
 	
 	// Generate 52 random cards from standard deck possibilities
 	for i := 0; i < 52; i++ {
 		// Random suit (0-3)
-		suit := Suit(rand.Intn(4))
+		suit := Suit(rng.IntN(4))
 		// Random rank (1-13)
-		rank := Rank(rand.Intn(13) + 1)
+		rank := Rank(rng.IntN(13) + 1)
 		
 		deck.Cards = append(deck.Cards, Card{
 			Rank:   rank,
@@ -42,6 +48,19 @@ func NewGlitchjackDeck() *Deck {
 	return deck
 }
 
+// NewGlitchjackShoe draws one Glitchjack deck after another with the given randomness and
+// shuffles them together. Nil uses the crypto-secure default.
+func NewGlitchjackShoe(numDecks int, rng *rand.Rand) *Deck {
+	deck := NewGlitchjackDeckWithRand(rng)
+	if numDecks > 1 {
+		for i := 1; i < numDecks; i++ {
+			deck.Cards = append(deck.Cards, NewGlitchjackDeckWithRand(rng).Cards...)
+		}
+		deck.Shuffle()
+	}
+	return deck
+}
+
 // CalculateGlitchjackHand calculates the value of a hand in Glitchjack.
 // Uses the same rules as Blackjack for hand calculation.
 func CalculateGlitchjackHand(cards []*Card) int {
@@ -91,3 +110,136 @@ func (gr GlitchjackResult) String() string {
 	return string(gr)
 }
 
+// StartGlitchjackGame clears all hands and deals two cards to each player and the dealer.
+// Cards go to players first each round; the dealer's second card is the face-down hole card.
+func (g *Game) StartGlitchjackGame() error {
+	if g.GameType != Glitchjack {
+		return fmt.Errorf("not a Glitchjack game")
+	}
+	
+	if g.Status != GameWaiting {
+		return fmt.Errorf("game already started")
+	}
+	
+	if len(g.Players) == 0 {
+		return fmt.Errorf("no players in game")
+	}
+	
+	// Clear any existing cards and reset player state
+	for _, player := range append([]*Player{g.Dealer}, g.Players...) {
+		player.Hand = []*Card{}
+		player.Standing = false
+		player.Busted = false
+	}
+	g.openShoe()
+	defer g.record(GameEvent{Type: EventGlitchjackStarted})
+	
+	for round := 0; round < 2; round++ {
+		for _, player := range g.Players {
+			if g.dealToPlayer(player.ID, true) == nil {
+				return fmt.Errorf("not enough cards in deck")
+			}
+		}
+		
+		// Dealer's first card is face up, second is the hole card
+		if g.dealToPlayer("dealer", round == 0) == nil {
+			return fmt.Errorf("not enough cards for dealer")
+		}
+	}
+	
+	g.Status = GameInProgress
+	g.CurrentPlayer = 0
+	return nil
+}
+
+// GlitchjackHit deals another card to the current player in a Glitchjack game.
+// A player who busts is finished and play moves on, ending with the dealer's turn.
+func (g *Game) GlitchjackHit(playerID string) error {
+	player, err := g.glitchjackTurn(playerID)
+	if err != nil {
+		return err
+	}
+	
+	if g.dealToPlayer(playerID, true) == nil {
+		return fmt.Errorf("no cards left in deck")
+	}
+	
+	if CalculateGlitchjackHand(player.Hand) > 21 {
+		player.Busted = true
+		g.advanceGlitchjackTurn()
+	}
+	g.record(GameEvent{Type: EventGlitchjackHit, PlayerID: playerID})
+	return nil
+}
+
+// GlitchjackStand finishes the current player's turn in a Glitchjack game.
+// Once every player has finished, the dealer plays out their hand.
+func (g *Game) GlitchjackStand(playerID string) error {
+	player, err := g.glitchjackTurn(playerID)
+	if err != nil {
+		return err
+	}
+	
+	player.Standing = true
+	g.advanceGlitchjackTurn()
+	g.record(GameEvent{Type: EventGlitchjackStood, PlayerID: playerID})
+	return nil
+}
+
+// glitchjackTurn checks that the given player may act in a Glitchjack game right now.
+func (g *Game) glitchjackTurn(playerID string) (*Player, error) {
+	if g.GameType != Glitchjack {
+		return nil, fmt.Errorf("not a Glitchjack game")
+	}
+	
+	if g.Status != GameInProgress {
+		return nil, fmt.Errorf("game not in progress")
+	}
+	
+	player := g.GetPlayer(playerID)
+	if player == nil || player == g.Dealer {
+		return nil, fmt.Errorf("player not found")
+	}
+	
+	if player.Standing || player.Busted {
+		return player, fmt.Errorf("player already finished")
+	}
+	
+	if g.CurrentPlayer < 0 || g.CurrentPlayer >= len(g.Players) || g.Players[g.CurrentPlayer].ID != playerID {
+		return player, fmt.Errorf("not player's turn")
+	}
+	
+	return player, nil
+}
+
+// advanceGlitchjackTurn moves to the next unfinished player, or plays the dealer if none remain.
+func (g *Game) advanceGlitchjackTurn() {
+	for i := g.CurrentPlayer + 1; i < len(g.Players); i++ {
+		if !g.Players[i].Standing && !g.Players[i].Busted {
+			g.CurrentPlayer = i
+			return
+		}
+	}
+	
+	g.playGlitchjackDealer()
+}
+
+// playGlitchjackDealer reveals the hole card and draws until the dealer reaches 17,
+// hitting soft 17 when the table rules say so.
+func (g *Game) playGlitchjackDealer() {
+	if len(g.Dealer.Hand) > 1 {
+		g.Dealer.Hand[1].FaceUp = true
+	}
+	
+	// Dealer hits on 16 or less, stands on 17 or more
+	rules := g.Rules()
+	for rules.dealerDraws(g.Dealer.Hand) {
+		if g.dealToPlayer("dealer", true) == nil {
+			break
+		}
+	}
+	
+	g.Status = GameFinished
+	g.CurrentPlayer = -1
+	g.settleBlackjack()
+}
@@ -0,0 +1,307 @@
+package models
+
+import "fmt"
+
+// GoFishPhase is the stage a game of Go Fish is in.
+type GoFishPhase int
+
+const (
+	GoFishPlaying GoFishPhase = iota
+	GoFishFinished
+)
+
+// String returns the phase name used in API responses.
+func (gp GoFishPhase) String() string {
+	switch gp {
+	case GoFishPlaying:
+		return "playing"
+	case GoFishFinished:
+		return "finished"
+	default:
+		return "playing"
+	}
+}
+
+// goFishBooks is how many books a standard deck makes, one per rank. Games started before the
+// book count was kept from the deck in play assume it.
+const goFishBooks = 13
+
+// GoFishSeat tracks the books a player has laid down.
+type GoFishSeat struct {
+	PlayerID string `json:"player_id"`
+	Books    []Rank `json:"books"`
+}
+
+// GoFishTurn describes the last ask or draw and what came of it.
+type GoFishTurn struct {
+	PlayerID  string `json:"player_id"`
+	TargetID  string `json:"target_id,omitempty"` // Empty for a draw without an ask
+	Rank      Rank   `json:"rank,omitempty"`
+	Received  int    `json:"received"`        // Cards handed over by the target
+	Fished    bool   `json:"fished"`          // Whether the player drew from the deck
+	Lucky     bool   `json:"lucky"`           // Whether the card drawn was the rank asked for
+	Books     []Rank `json:"books,omitempty"` // Books completed during the turn
+	GoesAgain bool   `json:"goes_again"`
+}
+
+// GoFishState holds all game state specific to Go Fish.
+type GoFishState struct {
+	Phase    GoFishPhase   `json:"phase"`
+	Seats    []*GoFishSeat `json:"seats"`
+	LastTurn *GoFishTurn   `json:"last_turn,omitempty"`
+	Winners  []string      `json:"winners,omitempty"` // Everyone tied for the most books once the game is over
+	Books    int           `json:"books"`             // Books the deck makes: every four cards of a rank
+}
+
+// BookCount returns how many books there are to lay before the game is over.
+func (s *GoFishState) BookCount() int {
+	if s.Books == 0 {
+		return goFishBooks
+	}
+	return s.Books
+}
+
+// deckBooks counts the books a deck makes, so multiple decks make more and a Spanish 21 deck fewer.
+func deckBooks(deck *Deck) int {
+	counts := map[Rank]int{}
+	for _, card := range deck.Cards {
+		counts[card.Rank]++
+	}
+	books := 0
+	for _, count := range counts {
+		books += count / 4
+	}
+	return books
+}
+
+// StartGoFishGame deals 7 cards each to 2 or 3 players, or 5 cards each to 4 to 6 players.
+// The first player asks first. Any four of a kind dealt is laid down as a book straight away.
+func (g *Game) StartGoFishGame() error {
+	if len(g.Players) < 2 || len(g.Players) > 6 {
+		return fmt.Errorf("go fish requires 2 to 6 players")
+	}
+	if g.GoFishState != nil {
+		return fmt.Errorf("go fish game has already been started")
+	}
+	handSize := 7
+	if len(g.Players) >= 4 {
+		handSize = 5
+	}
+	if g.Deck.RemainingCards() < handSize*len(g.Players) {
+		return fmt.Errorf("not enough cards in the deck to deal every player")
+	}
+
+	g.GameType = GoFish
+	g.Status = GameInProgress
+	g.CurrentPlayer = 0
+	defer g.record(GameEvent{Type: EventGoFishStarted})
+
+	g.GoFishState = &GoFishState{Seats: make([]*GoFishSeat, len(g.Players)), Books: deckBooks(g.Deck)}
+	for i, player := range g.Players {
+		player.ClearHand()
+		g.GoFishState.Seats[i] = &GoFishSeat{PlayerID: player.ID, Books: []Rank{}}
+	}
+	for n := 0; n < handSize; n++ {
+		for _, player := range g.Players {
+			g.dealToPlayer(player.ID, false)
+		}
+	}
+	for i := range g.Players {
+		g.layGoFishBooks(i)
+	}
+	return nil
+}
+
+// GoFishAsk has the player to act ask another player for every card of a rank they already hold.
+// If the target has any they are handed over and the player asks again; otherwise the player
+// goes fishing, drawing a card and only asking again if it is the rank they asked for.
+func (g *Game) GoFishAsk(playerID, targetID string, rank Rank) error {
+	asker, err := g.goFishTurn(playerID)
+	if err != nil {
+		return err
+	}
+	target := g.goFishSeatIndex(targetID)
+	if target < 0 {
+		return fmt.Errorf("target player not found")
+	}
+	if target == asker {
+		return fmt.Errorf("cannot ask yourself for cards")
+	}
+	if rank < Ace || rank > King {
+		return fmt.Errorf("invalid rank %d", rank)
+	}
+	if countRank(g.Players[asker].Hand, rank) == 0 {
+		return fmt.Errorf("you must hold a %s to ask for one", rank)
+	}
+	if len(g.Players[target].Hand) == 0 {
+		return fmt.Errorf("target player has no cards")
+	}
+
+	event := GameEvent{Type: EventGoFishAsk, PlayerID: playerID, TargetID: targetID, Rank: rank}
+	defer func() { g.record(event) }()
+
+	turn := &GoFishTurn{PlayerID: playerID, TargetID: targetID, Rank: rank}
+	g.GoFishState.LastTurn = turn
+
+	kept := []*Card{}
+	for _, card := range g.Players[target].Hand {
+		if card.Rank == rank {
+			g.Players[asker].AddCard(card)
+			event.Cards = append(event.Cards, *card)
+			turn.Received++
+			continue
+		}
+		kept = append(kept, card)
+	}
+	g.Players[target].Hand = kept
+
+	if turn.Received > 0 {
+		turn.GoesAgain = true
+	} else if card := g.dealToPlayer(playerID, false); card != nil {
+		turn.Fished = true
+		turn.Lucky = card.Rank == rank
+		turn.GoesAgain = turn.Lucky
+	}
+
+	turn.Books = g.layGoFishBooks(asker)
+	g.finishGoFishTurn(turn.GoesAgain)
+	return nil
+}
+
+// GoFishDraw draws a card for a player who cannot ask: one whose hand is empty keeps the turn
+// and asks once they hold a card, while one with nobody left to ask passes the turn after drawing.
+func (g *Game) GoFishDraw(playerID string) error {
+	index, err := g.goFishTurn(playerID)
+	if err != nil {
+		return err
+	}
+	emptyHanded := len(g.Players[index].Hand) == 0
+	if !emptyHanded && g.goFishCanAsk(index) {
+		return fmt.Errorf("you can only draw without asking when your hand is empty or nobody else has cards")
+	}
+	if g.Deck.IsEmpty() {
+		return fmt.Errorf("no cards remaining in deck")
+	}
+
+	defer g.record(GameEvent{Type: EventGoFishDraw, PlayerID: playerID})
+	turn := &GoFishTurn{PlayerID: playerID, Fished: true, GoesAgain: emptyHanded}
+	g.GoFishState.LastTurn = turn
+
+	g.dealToPlayer(playerID, false)
+	turn.Books = g.layGoFishBooks(index)
+	g.finishGoFishTurn(turn.GoesAgain)
+	return nil
+}
+
+// goFishTurn checks the game is being played and returns the seat of the player to act.
+func (g *Game) goFishTurn(playerID string) (int, error) {
+	if g.GoFishState == nil {
+		return -1, fmt.Errorf("go fish game has not been started")
+	}
+	if g.GoFishState.Phase == GoFishFinished {
+		return -1, fmt.Errorf("go fish game is over")
+	}
+	index := g.goFishSeatIndex(playerID)
+	if index < 0 {
+		return -1, fmt.Errorf("player not found")
+	}
+	if index != g.CurrentPlayer {
+		return -1, fmt.Errorf("not your turn")
+	}
+	return index, nil
+}
+
+// goFishCanAsk reports whether any other player holds cards the seat could ask for.
+func (g *Game) goFishCanAsk(index int) bool {
+	for i, player := range g.Players {
+		if i != index && len(player.Hand) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// layGoFishBooks lays down every four of a kind in the seat's hand and returns their ranks. A hand
+// holding eight of a rank from a multi-deck game lays two books of it.
+func (g *Game) layGoFishBooks(index int) []Rank {
+	player := g.Players[index]
+	seat := g.GoFishState.Seats[index]
+
+	books := []Rank{}
+	for rank := Ace; rank <= King; rank++ {
+		for countRank(player.Hand, rank) >= 4 {
+			kept, laid := []*Card{}, 0
+			for _, card := range player.Hand {
+				if card.Rank == rank && laid < 4 {
+					laid++
+					continue
+				}
+				kept = append(kept, card)
+			}
+			player.Hand = kept
+			seat.Books = append(seat.Books, rank)
+			books = append(books, rank)
+		}
+	}
+	return books
+}
+
+// finishGoFishTurn ends the game once every book is down or nobody can play, and otherwise
+// passes the turn unless the player goes again. Players with no cards are skipped once the deck is empty.
+func (g *Game) finishGoFishTurn(goesAgain bool) {
+	state := g.GoFishState
+	books := 0
+	for _, seat := range state.Seats {
+		books += len(seat.Books)
+	}
+
+	canPlay := func(i int) bool {
+		return len(g.Players[i].Hand) > 0 || !g.Deck.IsEmpty()
+	}
+	if books < state.BookCount() {
+		if goesAgain && canPlay(g.CurrentPlayer) {
+			return
+		}
+		for n := 1; n <= len(g.Players); n++ {
+			next := (g.CurrentPlayer + n) % len(g.Players)
+			if canPlay(next) {
+				g.CurrentPlayer = next
+				return
+			}
+		}
+	}
+
+	most := 0
+	for _, seat := range state.Seats {
+		most = max(most, len(seat.Books))
+	}
+	state.Winners = []string{}
+	for _, seat := range state.Seats {
+		if len(seat.Books) == most {
+			state.Winners = append(state.Winners, seat.PlayerID)
+		}
+	}
+	state.Phase = GoFishFinished
+	g.Status = GameFinished
+}
+
+// goFishSeatIndex returns the seat index of a player, or -1 if they are not seated.
+func (g *Game) goFishSeatIndex(playerID string) int {
+	for i, seat := range g.GoFishState.Seats {
+		if seat.PlayerID == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// countRank returns how many of the cards have the given rank.
+func countRank(cards []*Card, rank Rank) int {
+	count := 0
+	for _, card := range cards {
+		if card.Rank == rank {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGoFishGame creates a go fish game for the named players with the given ranks on top of the deck.
+// With two players the first 14 ranks are dealt alternately, so Alice gets the even positions.
+func newGoFishGame(t *testing.T, top []Rank, names ...string) *Game {
+	game := NewGameWithType(1, Standard, GoFish, 6)
+	for _, name := range names {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+	stackDeck(game, top)
+	return game
+}
+
+func TestStartGoFishGameDealsByPlayerCount(t *testing.T) {
+	game := newGoFishGame(t, nil, "Alice")
+	assert.Error(t, game.StartGoFishGame())
+
+	game = newGoFishGame(t, nil, "Alice", "Bob", "Carol")
+	require.NoError(t, game.StartGoFishGame())
+	assert.Error(t, game.StartGoFishGame())
+	for i, player := range game.Players {
+		assert.Len(t, player.Hand, 7-4*len(game.GoFishState.Seats[i].Books))
+	}
+
+	game = newGoFishGame(t, nil, "Alice", "Bob", "Carol", "Dave")
+	require.NoError(t, game.StartGoFishGame())
+	assert.Equal(t, GameInProgress, game.Status)
+	assert.Equal(t, 32, game.Deck.RemainingCards())
+	for i, player := range game.Players {
+		assert.Len(t, player.Hand, 5-4*len(game.GoFishState.Seats[i].Books))
+	}
+}
+
+func TestGoFishAskTransfersCardsAndGoesAgain(t *testing.T) {
+	game := newGoFishGame(t, []Rank{
+		Two, Two, Three, Two, Four, Two, Five, Six, Seven, Eight, Nine, Ten, Jack, Queen,
+		King,
+	}, "Alice", "Bob")
+	require.NoError(t, game.StartGoFishGame())
+	alice, bob := game.Players[0], game.Players[1]
+
+	assert.Error(t, game.GoFishAsk(bob.ID, alice.ID, Two), "it is Alice's turn")
+	assert.Error(t, game.GoFishAsk(alice.ID, alice.ID, Two))
+	assert.Error(t, game.GoFishAsk(alice.ID, bob.ID, Six), "Alice holds no Six")
+	assert.Error(t, game.GoFishAsk(alice.ID, bob.ID, Rank(14)))
+
+	// Bob hands over his three Twos, giving Alice a book
+	require.NoError(t, game.GoFishAsk(alice.ID, bob.ID, Two))
+	turn := game.GoFishState.LastTurn
+	assert.Equal(t, 3, turn.Received)
+	assert.False(t, turn.Fished)
+	assert.Equal(t, []Rank{Two}, turn.Books)
+	assert.Equal(t, []Rank{Two}, game.GoFishState.Seats[0].Books)
+	assert.Len(t, alice.Hand, 6)
+	assert.Len(t, bob.Hand, 4)
+	assert.Equal(t, 0, game.CurrentPlayer, "a successful ask goes again")
+	require.Len(t, game.Events[len(game.Events)-1].Cards, 3)
+
+	// Bob has no Threes, so Alice fishes a King and the turn passes
+	require.NoError(t, game.GoFishAsk(alice.ID, bob.ID, Three))
+	turn = game.GoFishState.LastTurn
+	assert.True(t, turn.Fished)
+	assert.False(t, turn.Lucky)
+	assert.Equal(t, King, alice.Hand[len(alice.Hand)-1].Rank)
+	assert.Equal(t, 1, game.CurrentPlayer)
+}
+
+func TestGoFishLuckyDrawGoesAgain(t *testing.T) {
+	game := newGoFishGame(t, []Rank{
+		Ace, Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten, Jack, Queen, King, Two,
+		Ace,
+	}, "Alice", "Bob")
+	require.NoError(t, game.StartGoFishGame())
+
+	require.NoError(t, game.GoFishAsk(game.Players[0].ID, game.Players[1].ID, Ace))
+	assert.True(t, game.GoFishState.LastTurn.Lucky)
+	assert.True(t, game.GoFishState.LastTurn.GoesAgain)
+	assert.Equal(t, 0, game.CurrentPlayer)
+}
+
+func TestGoFishDrawAndEndOfGame(t *testing.T) {
+	game := newGoFishGame(t, nil, "Alice", "Bob")
+	require.NoError(t, game.StartGoFishGame())
+	alice, bob := game.Players[0], game.Players[1]
+	assert.Error(t, game.GoFishDraw(alice.ID), "Alice has cards and Bob to ask")
+
+	// Leave Alice with one card short of her last book and Bob holding nothing
+	game.Deck.Cards = []Card{{Rank: King, Suit: Spades}}
+	alice.Hand = []*Card{{Rank: King, Suit: Hearts}, {Rank: King, Suit: Diamonds}, {Rank: King, Suit: Clubs}}
+	bob.Hand = []*Card{}
+	for i := range game.GoFishState.Seats {
+		game.GoFishState.Seats[i].Books = []Rank{}
+	}
+	game.GoFishState.Seats[0].Books = []Rank{Ace, Two, Three, Four, Five, Six}
+	game.GoFishState.Seats[1].Books = []Rank{Seven, Eight, Nine, Ten, Jack, Queen}
+
+	assert.Error(t, game.GoFishAsk(alice.ID, bob.ID, King), "Bob has no cards")
+	require.NoError(t, game.GoFishDraw(alice.ID))
+	assert.Equal(t, []Rank{King}, game.GoFishState.LastTurn.Books)
+	assert.Equal(t, GoFishFinished, game.GoFishState.Phase)
+	assert.Equal(t, GameFinished, game.Status)
+	assert.Equal(t, []string{alice.ID}, game.GoFishState.Winners)
+	assert.Error(t, game.GoFishDraw(alice.ID))
+}
+
+func TestGoFishBooksComeFromTheDeckInPlay(t *testing.T) {
+	game := NewGameWithType(1, Spanish21, GoFish, 6)
+	require.NotNil(t, game.AddPlayer("Alice"))
+	require.NotNil(t, game.AddPlayer("Bob"))
+	require.NoError(t, game.StartGoFishGame())
+	assert.Equal(t, 12, game.GoFishState.Books, "a Spanish 21 deck has no tens")
+
+	game = NewGameWithType(1, Standard, GoFish, 6)
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	game.ResetDeck(2, Standard)
+	require.NoError(t, game.StartGoFishGame())
+	assert.Equal(t, 26, game.GoFishState.Books)
+
+	// Alice fishes for her eighth king and lays two books, but 13 books leave half of a double deck to play for
+	game.Deck.Cards = []Card{{Rank: King, Suit: Spades}, {Rank: Two, Suit: Spades}}
+	alice.Hand = []*Card{{Rank: King, Suit: Hearts}, {Rank: Queen, Suit: Hearts}}
+	for i := 0; i < 6; i++ {
+		alice.Hand = append(alice.Hand, &Card{Rank: King, Suit: Suit(i % 4)})
+	}
+	bob.Hand = []*Card{{Rank: Queen, Suit: Clubs}}
+	game.GoFishState.Seats[0].Books = []Rank{Ace, Two, Three, Four, Five, Six}
+	game.GoFishState.Seats[1].Books = []Rank{Seven, Eight, Nine, Ten, Jack}
+
+	require.NoError(t, game.GoFishAsk(alice.ID, bob.ID, King))
+	assert.Equal(t, []Rank{King, King}, game.GoFishState.LastTurn.Books)
+	require.Len(t, alice.Hand, 1)
+	assert.Equal(t, Queen, alice.Hand[0].Rank, "the queen is all Alice has left")
+	assert.Equal(t, GoFishPlaying, game.GoFishState.Phase, "13 of 26 books are down")
+	assert.Equal(t, GameInProgress, game.Status)
+}
+
+func TestGoFishViewHidesOtherHandsAndReplays(t *testing.T) {
+	game := newGoFishGame(t, nil, "Alice", "Bob", "Carol")
+	require.NoError(t, game.StartGoFishGame())
+	alice, bob := game.Players[0], game.Players[1]
+
+	view := game.ViewFor(Viewer{PlayerID: alice.ID})
+	assert.Equal(t, *alice.Hand[0], *view.Players[0].Hand[0])
+	assert.Equal(t, Card{}, *view.Players[1].Hand[0])
+	for _, event := range game.EventsFor(Viewer{PlayerID: bob.ID}, 0) {
+		for _, card := range event.Dealt {
+			assert.Equal(t, Card{}, card, "dealt cards are private")
+		}
+	}
+
+	for i := 0; i < 10 && game.GoFishState.Phase == GoFishPlaying; i++ {
+		player := game.Players[game.CurrentPlayer]
+		if len(player.Hand) == 0 {
+			require.NoError(t, game.GoFishDraw(player.ID))
+			continue
+		}
+		target := game.Players[(game.CurrentPlayer+1)%3]
+		if len(target.Hand) == 0 {
+			target = game.Players[(game.CurrentPlayer+2)%3]
+		}
+		require.NoError(t, game.GoFishAsk(player.ID, target.ID, player.Hand[0].Rank))
+	}
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	require.NotNil(t, replayed.GoFishState)
+	assert.Equal(t, game.CurrentPlayer, replayed.CurrentPlayer)
+	for i, player := range game.Players {
+		assert.Equal(t, player.Hand, replayed.Players[i].Hand)
+		assert.Equal(t, game.GoFishState.Seats[i].Books, replayed.GoFishState.Seats[i].Books)
+	}
+}
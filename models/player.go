@@ -1,5 +1,12 @@
 package models
 
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
 // Player represents a game participant with a unique ID, name, and hand of cards.
 // Players can be human users or the dealer, identified by UUID or "dealer" respectively.
 type Player struct {
@@ -8,6 +15,41 @@ type Player struct {
 	Hand     []*Card `json:"hand"`
 	Standing bool    `json:"standing,omitempty"`
 	Busted   bool    `json:"busted,omitempty"`
+	// Hands are the player's blackjack hands, several once they split; Hand mirrors the one in play
+	Hands      []*BlackjackHand `json:"hands,omitempty"`
+	ActiveHand int              `json:"active_hand,omitempty"`
+	Insured    bool             `json:"insured,omitempty"`
+	// Bankroll is the player's chips off the table; Bet is staked on the next or current deal
+	Bankroll     int           `json:"bankroll,omitempty"`
+	Bet          int           `json:"bet,omitempty"`
+	InsuranceBet int           `json:"insurance_bet,omitempty"`
+	Ledger       []LedgerEntry `json:"ledger,omitempty"`
+	// TokenHash is the SHA-256 of the player's secret token; the token itself is never stored
+	TokenHash string `json:"token_hash,omitempty"`
+}
+
+// IssueToken generates a new secret token for the player and stores only its hash.
+// The returned token is the player's proof of identity and cannot be recovered later.
+func (p *Player) IssueToken() string {
+	secret := make([]byte, 32)
+	rand.Read(secret) // crypto/rand.Read never fails; it aborts the program if the OS source is broken
+	token := hex.EncodeToString(secret)
+	p.TokenHash = hashToken(token)
+	return token
+}
+
+// HasToken reports whether the token was issued to this player, using a constant-time comparison.
+func (p *Player) HasToken(token string) bool {
+	if p.TokenHash == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(p.TokenHash), []byte(hashToken(token))) == 1
+}
+
+// hashToken returns the hex-encoded SHA-256 digest of a player token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 // AddCard adds a new card to the player's hand.
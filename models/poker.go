@@ -0,0 +1,613 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PokerPhase represents the betting round of a Texas Hold'em hand.
+type PokerPhase int
+
+const (
+	PokerPreflop PokerPhase = iota
+	PokerFlop
+	PokerTurn
+	PokerRiver
+	PokerShowdown // The hand is over and its pots have been awarded
+	PokerFinished // Only one player has chips left
+)
+
+// String returns the string representation of the poker phase.
+// This is used in JSON responses to show which betting round the hand is in.
+func (pp PokerPhase) String() string {
+	switch pp {
+	case PokerPreflop:
+		return "preflop"
+	case PokerFlop:
+		return "flop"
+	case PokerTurn:
+		return "turn"
+	case PokerRiver:
+		return "river"
+	case PokerShowdown:
+		return "showdown"
+	case PokerFinished:
+		return "finished"
+	default:
+		return "preflop"
+	}
+}
+
+// PokerAction is a betting decision made by the player whose turn it is.
+type PokerAction string
+
+const (
+	PokerCheck PokerAction = "check"
+	PokerBet   PokerAction = "bet" // Amount is the size of the opening bet
+	PokerCall  PokerAction = "call"
+	PokerRaise PokerAction = "raise" // Amount is the total the player raises to this round
+	PokerFold  PokerAction = "fold"
+	PokerAllIn PokerAction = "all_in"
+)
+
+// PokerConfig holds the stakes a Texas Hold'em game is played at.
+type PokerConfig struct {
+	SmallBlind    int `json:"small_blind"`
+	BigBlind      int `json:"big_blind"`
+	StartingStack int `json:"starting_stack"`
+}
+
+// DefaultPokerConfig returns the stakes used when a game is started without any.
+func DefaultPokerConfig() PokerConfig {
+	return PokerConfig{SmallBlind: 5, BigBlind: 10, StartingStack: 1000}
+}
+
+// Validate checks that the blinds and stacks describe a playable game.
+func (pc PokerConfig) Validate() error {
+	if pc.SmallBlind <= 0 {
+		return fmt.Errorf("small blind must be positive")
+	}
+	if pc.BigBlind < pc.SmallBlind {
+		return fmt.Errorf("big blind must be at least the small blind")
+	}
+	if pc.StartingStack < pc.BigBlind {
+		return fmt.Errorf("starting stack must cover the big blind")
+	}
+	return nil
+}
+
+// PokerSeat holds one player's chips and betting status. Seats are in the same order as Game.Players.
+type PokerSeat struct {
+	PlayerID   string      `json:"player_id"`
+	Stack      int         `json:"stack"`
+	Bet        int         `json:"bet"`       // Chips put in during the current betting round
+	Committed  int         `json:"committed"` // Chips put in during the whole hand
+	Folded     bool        `json:"folded"`
+	AllIn      bool        `json:"all_in"`
+	Out        bool        `json:"out,omitempty"` // Busted before the hand was dealt
+	Acted      bool        `json:"acted"`
+	CallOnly   bool        `json:"call_only,omitempty"` // A short all-in did not reopen the betting to this seat
+	LastAction PokerAction `json:"last_action,omitempty"`
+}
+
+// canAct reports whether the seat still makes betting decisions this hand.
+func (s *PokerSeat) canAct() bool {
+	return !s.Folded && !s.Out && !s.AllIn
+}
+
+// inHand reports whether the seat is still contesting the pot.
+func (s *PokerSeat) inHand() bool {
+	return !s.Folded && !s.Out
+}
+
+// PokerPot is the main pot or a side pot along with the players who can win it.
+type PokerPot struct {
+	Amount   int      `json:"amount"`
+	Eligible []string `json:"eligible"`
+}
+
+// PokerPotResult records who won a pot and with what.
+type PokerPotResult struct {
+	Amount  int      `json:"amount"`
+	Winners []string `json:"winners"`
+	Hand    string   `json:"hand,omitempty"` // Empty when everyone else folded
+}
+
+// PokerShowdownHand is a hand revealed at showdown.
+type PokerShowdownHand struct {
+	PlayerID string    `json:"player_id"`
+	Hand     PokerHand `json:"hand"`
+}
+
+// PokerState holds all game state specific to Texas Hold'em.
+// The phase, seats and pots describe the hand in progress; results describe the last hand played.
+type PokerState struct {
+	Phase      PokerPhase          `json:"phase"`
+	HandNumber int                 `json:"hand_number"`
+	Button     int                 `json:"button"`
+	SmallBlind int                 `json:"small_blind"`
+	BigBlind   int                 `json:"big_blind"`
+	Seats      []*PokerSeat        `json:"seats"`
+	Community  []*Card             `json:"community"`
+	Burned     []*Card             `json:"burned"`
+	CurrentBet int                 `json:"current_bet"`
+	MinRaise   int                 `json:"min_raise"`
+	Pot        int                 `json:"pot"`
+	Pots       []PokerPot          `json:"pots"`
+	Showdown   []PokerShowdownHand `json:"showdown,omitempty"`
+	Results    []PokerPotResult    `json:"results,omitempty"`
+}
+
+// ToCall returns how many chips the seat must add to stay in the hand.
+func (ps *PokerState) ToCall(seat int) int {
+	if seat < 0 || seat >= len(ps.Seats) {
+		return 0
+	}
+	toCall := ps.CurrentBet - ps.Seats[seat].Bet
+	if toCall > ps.Seats[seat].Stack {
+		toCall = ps.Seats[seat].Stack
+	}
+	if toCall < 0 {
+		return 0
+	}
+	return toCall
+}
+
+// StartPokerGame seats every player with the starting stack and deals the first hand of No-Limit Texas Hold'em.
+// The first player is on the button; blinds are posted and two hole cards dealt face down to each player.
+// The first hand is dealt from the deck as it stands, like the first deal of every other game, so shuffle
+// the deck beforehand for the seed and shuffle mode to apply; later hands are shuffled by NextPokerHand.
+func (g *Game) StartPokerGame(config PokerConfig) error {
+	if len(g.Players) < 2 || len(g.Players) > 10 {
+		return fmt.Errorf("poker requires 2 to 10 players")
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	g.GameType = Poker
+	g.Status = GameInProgress
+	defer g.record(GameEvent{Type: EventPokerStarted, Poker: &config})
+
+	g.PokerState = &PokerState{
+		SmallBlind: config.SmallBlind,
+		BigBlind:   config.BigBlind,
+		Seats:      make([]*PokerSeat, len(g.Players)),
+	}
+	for i, player := range g.Players {
+		g.PokerState.Seats[i] = &PokerSeat{PlayerID: player.ID, Stack: config.StartingStack}
+	}
+
+	return g.dealPokerHand()
+}
+
+// NextPokerHand moves the button, reshuffles a full deck and deals the next hand.
+// Players who have lost all their chips sit out.
+func (g *Game) NextPokerHand() error {
+	return g.nextPokerHand(nil)
+}
+
+// nextPokerHand deals the next hand from a fresh shuffled deck, or from the given deck when replaying.
+func (g *Game) nextPokerHand(deck *Deck) error {
+	if g.PokerState == nil {
+		return fmt.Errorf("poker game has not been started")
+	}
+	if g.PokerState.Phase != PokerShowdown {
+		if g.PokerState.Phase == PokerFinished {
+			return fmt.Errorf("poker game is over")
+		}
+		return fmt.Errorf("current hand is still being played")
+	}
+
+	if deck != nil {
+		g.Deck.restore(deck)
+	} else {
+		g.Deck.Reset()
+		g.shuffle()
+	}
+	defer g.record(GameEvent{Type: EventPokerNextHand, Deck: g.Deck.snapshot()})
+
+	state := g.PokerState
+	state.Button = g.nextPokerSeat(state.Button, func(s *PokerSeat) bool { return s.Stack > 0 })
+	return g.dealPokerHand()
+}
+
+// dealPokerHand clears the previous hand, posts the blinds and deals hole cards.
+func (g *Game) dealPokerHand() error {
+	state := g.PokerState
+	state.HandNumber++
+	state.Phase = PokerPreflop
+	state.Community = []*Card{}
+	state.Burned = []*Card{}
+	state.Pots = []PokerPot{}
+	state.Showdown = nil
+	state.Results = nil
+	state.CurrentBet = state.BigBlind
+	state.MinRaise = state.BigBlind
+
+	active := 0
+	for i, seat := range state.Seats {
+		out := seat.Stack == 0
+		*seat = PokerSeat{PlayerID: seat.PlayerID, Stack: seat.Stack, Out: out, Folded: out}
+		if !out {
+			active++
+		}
+		if i < len(g.Players) {
+			g.Players[i].Hand = []*Card{}
+		}
+	}
+	if active < 2 {
+		return fmt.Errorf("poker requires at least 2 players with chips")
+	}
+
+	// Heads-up the button posts the small blind and acts first before the flop
+	smallBlind := g.nextPokerSeat(state.Button, (*PokerSeat).inHand)
+	if active == 2 {
+		smallBlind = state.Button
+	}
+	bigBlind := g.nextPokerSeat(smallBlind, (*PokerSeat).inHand)
+	g.postPokerChips(smallBlind, state.SmallBlind)
+	g.postPokerChips(bigBlind, state.BigBlind)
+
+	for round := 0; round < 2; round++ {
+		seat := state.Button
+		for dealt := 0; dealt < active; dealt++ {
+			seat = g.nextPokerSeat(seat, (*PokerSeat).inHand)
+			if g.dealToPlayer(state.Seats[seat].PlayerID, false) == nil {
+				return fmt.Errorf("not enough cards in deck")
+			}
+		}
+	}
+
+	g.CurrentPlayer = bigBlind
+	g.advancePoker()
+	return nil
+}
+
+// PokerAct applies a betting decision for the player whose turn it is.
+// Bets give the size of the bet and raises the total to raise to; other actions ignore the amount.
+func (g *Game) PokerAct(playerID string, action PokerAction, amount int) error {
+	state := g.PokerState
+	if state == nil || g.Status != GameInProgress || state.Phase >= PokerShowdown {
+		return fmt.Errorf("no poker hand in progress")
+	}
+	if g.CurrentPlayer < 0 || g.CurrentPlayer >= len(state.Seats) || state.Seats[g.CurrentPlayer].PlayerID != playerID {
+		return fmt.Errorf("not your turn")
+	}
+
+	index := g.CurrentPlayer
+	seat := state.Seats[index]
+	toCall := state.CurrentBet - seat.Bet
+
+	switch action {
+	case PokerFold:
+		seat.Folded = true
+	case PokerCheck:
+		if toCall > 0 {
+			return fmt.Errorf("cannot check facing a bet of %d", toCall)
+		}
+	case PokerCall:
+		if toCall <= 0 {
+			return fmt.Errorf("nothing to call, check instead")
+		}
+		g.postPokerChips(index, toCall)
+	case PokerBet:
+		if state.CurrentBet > 0 {
+			return fmt.Errorf("cannot bet after a bet has been made, raise instead")
+		}
+		if amount > seat.Stack {
+			return fmt.Errorf("not enough chips to bet %d", amount)
+		}
+		if amount < state.BigBlind && amount != seat.Stack {
+			return fmt.Errorf("minimum bet is %d", state.BigBlind)
+		}
+		g.raisePokerTo(index, amount)
+	case PokerRaise:
+		if state.CurrentBet == 0 {
+			return fmt.Errorf("nothing to raise, bet instead")
+		}
+		if seat.CallOnly {
+			return fmt.Errorf("betting was not reopened, call or fold")
+		}
+		if amount <= state.CurrentBet {
+			return fmt.Errorf("raise must be above the current bet of %d", state.CurrentBet)
+		}
+		if amount-seat.Bet > seat.Stack {
+			return fmt.Errorf("not enough chips to raise to %d", amount)
+		}
+		if amount < state.CurrentBet+state.MinRaise && amount-seat.Bet != seat.Stack {
+			return fmt.Errorf("minimum raise is to %d", state.CurrentBet+state.MinRaise)
+		}
+		g.raisePokerTo(index, amount)
+	case PokerAllIn:
+		total := seat.Bet + seat.Stack
+		if total > state.CurrentBet {
+			if seat.CallOnly {
+				return fmt.Errorf("betting was not reopened, call or fold")
+			}
+			g.raisePokerTo(index, total)
+		} else {
+			g.postPokerChips(index, seat.Stack)
+		}
+	default:
+		return fmt.Errorf("unknown poker action %q", action)
+	}
+
+	defer g.record(GameEvent{Type: EventPokerAction, PlayerID: playerID, Action: string(action), Amount: amount})
+	seat.Acted = true
+	seat.LastAction = action
+	if seat.Stack == 0 && !seat.Folded {
+		seat.LastAction = PokerAllIn
+	}
+	g.advancePoker()
+	return nil
+}
+
+// postPokerChips moves chips from a seat's stack into the pot, putting the seat all in if it runs out.
+func (g *Game) postPokerChips(index, amount int) {
+	state := g.PokerState
+	seat := state.Seats[index]
+	if amount > seat.Stack {
+		amount = seat.Stack
+	}
+	seat.Stack -= amount
+	seat.Bet += amount
+	seat.Committed += amount
+	state.Pot += amount
+	if seat.Stack == 0 {
+		seat.AllIn = true
+	}
+}
+
+// raisePokerTo brings a seat's bet up to total and reopens the betting.
+// A full raise lets everyone act again; a short all-in only obliges players who already acted to call or fold.
+func (g *Game) raisePokerTo(index, total int) {
+	state := g.PokerState
+	seat := state.Seats[index]
+	g.postPokerChips(index, total-seat.Bet)
+
+	increase := seat.Bet - state.CurrentBet
+	full := increase >= state.MinRaise
+	if full {
+		state.MinRaise = increase
+	}
+	state.CurrentBet = seat.Bet
+
+	for i, other := range state.Seats {
+		if i == index || !other.canAct() {
+			continue
+		}
+		if full {
+			other.CallOnly = false
+		} else if other.Acted {
+			other.CallOnly = true
+		}
+		other.Acted = false
+	}
+}
+
+// advancePoker moves the turn on, ending betting rounds, dealing the board and settling the hand as needed.
+func (g *Game) advancePoker() {
+	state := g.PokerState
+
+	contesting, acting := 0, 0
+	for _, seat := range state.Seats {
+		if seat.inHand() {
+			contesting++
+		}
+		if seat.canAct() {
+			acting++
+		}
+	}
+	if contesting == 1 {
+		g.settlePoker(false)
+		return
+	}
+
+	// Someone still owes a decision this round
+	next := g.nextPokerSeat(g.CurrentPlayer, func(s *PokerSeat) bool {
+		return s.canAct() && (s.Bet < state.CurrentBet || (!s.Acted && acting > 1))
+	})
+	if next >= 0 {
+		g.CurrentPlayer = next
+		return
+	}
+
+	for _, seat := range state.Seats {
+		seat.Bet = 0
+		seat.Acted = false
+		seat.CallOnly = false
+	}
+	state.CurrentBet = 0
+	state.MinRaise = state.BigBlind
+	state.Pots = pokerPots(state.Seats)
+
+	// With at most one player able to bet, the rest of the board is dealt without further action
+	for state.Phase < PokerRiver {
+		g.dealPokerStreet()
+		if acting > 1 {
+			g.CurrentPlayer = g.nextPokerSeat(state.Button, (*PokerSeat).canAct)
+			return
+		}
+	}
+	g.settlePoker(true)
+}
+
+// dealPokerStreet burns a card and deals the flop, turn or river face up and moves to that betting round.
+func (g *Game) dealPokerStreet() {
+	state := g.PokerState
+	count := 1
+	if state.Phase == PokerPreflop {
+		count = 3
+	}
+
+	if burn := g.drawCard(); burn != nil {
+		state.Burned = append(state.Burned, burn)
+	}
+	for i := 0; i < count; i++ {
+		card := g.drawCard()
+		if card == nil {
+			break
+		}
+		card.FaceUp = true
+		state.Community = append(state.Community, card)
+	}
+	state.Phase++
+}
+
+// settlePoker awards the pots, at showdown by the best hand or otherwise to the last player left in.
+// Split pots are divided evenly with odd chips going to the first winners left of the button.
+func (g *Game) settlePoker(showdown bool) {
+	state := g.PokerState
+	state.Pots = pokerPots(state.Seats)
+
+	hands := make(map[string]PokerHand)
+	if showdown {
+		for i, seat := range state.Seats {
+			if !seat.inHand() || i >= len(g.Players) {
+				continue
+			}
+			cards := append(append([]*Card{}, g.Players[i].Hand...), state.Community...)
+			hand, err := EvaluatePokerHand(cards)
+			if err != nil {
+				continue
+			}
+			for _, card := range g.Players[i].Hand {
+				card.FaceUp = true
+			}
+			hands[seat.PlayerID] = hand
+			state.Showdown = append(state.Showdown, PokerShowdownHand{PlayerID: seat.PlayerID, Hand: hand})
+		}
+	}
+
+	order := g.pokerSeatsFromButton()
+	for _, pot := range state.Pots {
+		winners := []string{}
+		var best PokerHand
+		for _, index := range order {
+			id := state.Seats[index].PlayerID
+			if !containsString(pot.Eligible, id) {
+				continue
+			}
+			hand := hands[id]
+			switch {
+			case len(winners) == 0 || hand.Value > best.Value:
+				winners, best = []string{id}, hand
+			case hand.Value == best.Value:
+				winners = append(winners, id)
+			}
+		}
+		if len(winners) == 0 {
+			continue
+		}
+
+		share, odd := pot.Amount/len(winners), pot.Amount%len(winners)
+		for i, id := range winners {
+			chips := share
+			if i < odd {
+				chips++
+			}
+			state.Seats[g.pokerSeatIndex(id)].Stack += chips
+		}
+
+		result := PokerPotResult{Amount: pot.Amount, Winners: winners}
+		if showdown && len(pot.Eligible) > 1 {
+			result.Hand = best.Name
+		}
+		state.Results = append(state.Results, result)
+	}
+
+	for _, seat := range state.Seats {
+		seat.Bet = 0
+	}
+	state.Pot = 0
+	state.CurrentBet = 0
+	state.Phase = PokerShowdown
+
+	withChips := 0
+	for _, seat := range state.Seats {
+		if seat.Stack > 0 {
+			withChips++
+		}
+	}
+	if withChips < 2 {
+		state.Phase = PokerFinished
+		g.Status = GameFinished
+	}
+}
+
+// pokerPots splits the chips committed this hand into a main pot and side pots.
+// Each pot can be won only by players still in the hand who put in at least that pot's level.
+func pokerPots(seats []*PokerSeat) []PokerPot {
+	levels := []int{}
+	seen := make(map[int]bool)
+	for _, seat := range seats {
+		if seat.inHand() && seat.Committed > 0 && !seen[seat.Committed] {
+			seen[seat.Committed] = true
+			levels = append(levels, seat.Committed)
+		}
+	}
+	sort.Ints(levels)
+
+	pots := []PokerPot{}
+	previous := 0
+	for i, level := range levels {
+		pot := PokerPot{Eligible: []string{}}
+		for _, seat := range seats {
+			if seat.Committed > previous {
+				pot.Amount += min(seat.Committed, level) - previous
+			}
+			// Chips folded above the top level still belong in the last pot
+			if i == len(levels)-1 && seat.Committed > level {
+				pot.Amount += seat.Committed - level
+			}
+			if seat.inHand() && seat.Committed >= level {
+				pot.Eligible = append(pot.Eligible, seat.PlayerID)
+			}
+		}
+		previous = level
+		pots = append(pots, pot)
+	}
+	return pots
+}
+
+// nextPokerSeat returns the first seat after from, wrapping around the table, that matches, or -1 if none does.
+func (g *Game) nextPokerSeat(from int, matches func(*PokerSeat) bool) int {
+	seats := g.PokerState.Seats
+	for step := 1; step <= len(seats); step++ {
+		index := (from + step + len(seats)) % len(seats)
+		if matches(seats[index]) {
+			return index
+		}
+	}
+	return -1
+}
+
+// pokerSeatsFromButton lists seat indices starting left of the button, the order odd chips are handed out in.
+func (g *Game) pokerSeatsFromButton() []int {
+	seats := len(g.PokerState.Seats)
+	order := make([]int, seats)
+	for i := range order {
+		order[i] = (g.PokerState.Button + 1 + i) % seats
+	}
+	return order
+}
+
+// pokerSeatIndex returns the seat index of a player, or -1 if they are not seated.
+func (g *Game) pokerSeatIndex(playerID string) int {
+	for i, seat := range g.PokerState.Seats {
+		if seat.PlayerID == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
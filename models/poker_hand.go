@@ -0,0 +1,339 @@
+package models
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// PokerHandCategory ranks the kinds of five-card poker hands from weakest to strongest.
+type PokerHandCategory int
+
+const (
+	HighCard PokerHandCategory = iota
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	Straight
+	Flush
+	FullHouse
+	FourOfAKind
+	StraightFlush
+)
+
+// String returns the name of the hand category as used in API responses.
+func (hc PokerHandCategory) String() string {
+	switch hc {
+	case HighCard:
+		return "high_card"
+	case OnePair:
+		return "one_pair"
+	case TwoPair:
+		return "two_pair"
+	case ThreeOfAKind:
+		return "three_of_a_kind"
+	case Straight:
+		return "straight"
+	case Flush:
+		return "flush"
+	case FullHouse:
+		return "full_house"
+	case FourOfAKind:
+		return "four_of_a_kind"
+	case StraightFlush:
+		return "straight_flush"
+	default:
+		return "high_card"
+	}
+}
+
+// pokerGroups lists, per category, how many cards of each tie-break rank make up the five-card hand.
+// The ranks after the made hand are the kickers.
+var pokerGroups = [...][]int{
+	HighCard:      {1, 1, 1, 1, 1},
+	OnePair:       {2, 1, 1, 1},
+	TwoPair:       {2, 2, 1},
+	ThreeOfAKind:  {3, 1, 1},
+	Straight:      {5},
+	Flush:         {1, 1, 1, 1, 1},
+	FullHouse:     {3, 2},
+	FourOfAKind:   {4, 1},
+	StraightFlush: {5},
+}
+
+// pokerMadeRanks is how many leading tie-break ranks describe the made hand rather than kickers.
+var pokerMadeRanks = [...]int{
+	HighCard:      1,
+	OnePair:       1,
+	TwoPair:       2,
+	ThreeOfAKind:  1,
+	Straight:      1,
+	Flush:         5,
+	FullHouse:     2,
+	FourOfAKind:   1,
+	StraightFlush: 1,
+}
+
+// PokerHand is the evaluated best five-card hand from a set of cards.
+// Value orders hands completely: a higher value wins and equal values split the pot.
+type PokerHand struct {
+	Category PokerHandCategory `json:"category"`
+	Name     string            `json:"name"`
+	Ranks    []int             `json:"ranks"`   // Tie-break ranks in order of significance, Ace high = 14
+	Kickers  []int             `json:"kickers"` // The ranks in Ranks that only break ties
+	Cards    []Card            `json:"cards"`   // The five cards making the hand
+	Value    int               `json:"value"`
+}
+
+// Compare returns 1 if h beats other, -1 if it loses and 0 if the hands tie.
+func (h PokerHand) Compare(other PokerHand) int {
+	switch {
+	case h.Value > other.Value:
+		return 1
+	case h.Value < other.Value:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// pokerRank returns the card's rank for poker comparisons, with Aces high.
+func pokerRank(card Card) int {
+	if card.Rank == Ace {
+		return 14
+	}
+	return int(card.Rank)
+}
+
+// EvaluatePokerHand finds the best five-card hand among five to seven cards,
+// so Texas Hold'em hands pass hole cards plus the board.
+func EvaluatePokerHand(cards []*Card) (PokerHand, error) {
+	value, err := PokerHandValue(cards)
+	if err != nil {
+		return PokerHand{}, err
+	}
+	return newPokerHand(value, cards), nil
+}
+
+// PokerHandValue returns only the comparable value of the best five-card hand among five to seven cards.
+// It does not allocate, so simulations can rank millions of hands per second.
+func PokerHandValue(cards []*Card) (int, error) {
+	if len(cards) < 5 || len(cards) > 7 {
+		return 0, fmt.Errorf("poker hands need 5 to 7 cards, got %d", len(cards))
+	}
+
+	var counts [15]uint8
+	var suits [4]uint16
+	var ranks uint16
+	for _, card := range cards {
+		if card == nil {
+			return 0, fmt.Errorf("poker hands cannot contain missing cards")
+		}
+		if card.Rank < Ace || card.Rank > King || card.Suit < Hearts || card.Suit > Spades {
+			return 0, fmt.Errorf("invalid card %d of suit %d", card.Rank, card.Suit)
+		}
+		rank := pokerRank(*card)
+		counts[rank]++
+		suits[card.Suit] |= 1 << rank
+		ranks |= 1 << rank
+	}
+
+	var flush uint16
+	for _, suit := range suits {
+		if bits.OnesCount16(suit) >= 5 {
+			flush = suit
+		}
+	}
+	if flush != 0 {
+		if high := straightHigh(flush); high > 0 {
+			return packPokerValue(StraightFlush, high, 0, 0, 0, 0), nil
+		}
+	}
+
+	quads, trips, secondTrips, pair, secondPair := 0, 0, 0, 0, 0
+	for rank := 14; rank >= 2; rank-- {
+		switch {
+		case counts[rank] >= 4 && quads == 0:
+			quads = rank
+		case counts[rank] == 3 && trips == 0:
+			trips = rank
+		case counts[rank] == 3 && secondTrips == 0:
+			secondTrips = rank
+		case counts[rank] == 2 && pair == 0:
+			pair = rank
+		case counts[rank] == 2 && secondPair == 0:
+			secondPair = rank
+		}
+	}
+
+	switch {
+	case quads > 0:
+		kicker := topRanks(ranks&^(1<<quads), 1)
+		return packPokerValue(FourOfAKind, quads, kicker[0], 0, 0, 0), nil
+	case trips > 0 && (secondTrips > 0 || pair > 0):
+		return packPokerValue(FullHouse, trips, max(secondTrips, pair), 0, 0, 0), nil
+	case flush != 0:
+		top := topRanks(flush, 5)
+		return packPokerValue(Flush, top[0], top[1], top[2], top[3], top[4]), nil
+	}
+	if high := straightHigh(ranks); high > 0 {
+		return packPokerValue(Straight, high, 0, 0, 0, 0), nil
+	}
+	switch {
+	case trips > 0:
+		kickers := topRanks(ranks&^(1<<trips), 2)
+		return packPokerValue(ThreeOfAKind, trips, kickers[0], kickers[1], 0, 0), nil
+	case secondPair > 0:
+		kicker := topRanks(ranks&^(1<<pair|1<<secondPair), 1)
+		return packPokerValue(TwoPair, pair, secondPair, kicker[0], 0, 0), nil
+	case pair > 0:
+		kickers := topRanks(ranks&^(1<<pair), 3)
+		return packPokerValue(OnePair, pair, kickers[0], kickers[1], kickers[2], 0), nil
+	}
+	top := topRanks(ranks, 5)
+	return packPokerValue(HighCard, top[0], top[1], top[2], top[3], top[4]), nil
+}
+
+// ComparePokerHands evaluates several hands and returns them along with the indices of the winners.
+// More than one index is returned when the best hands tie.
+func ComparePokerHands(hands [][]*Card) ([]PokerHand, []int, error) {
+	if len(hands) == 0 {
+		return nil, nil, fmt.Errorf("no hands to compare")
+	}
+
+	evaluated := make([]PokerHand, len(hands))
+	winners := []int{}
+	for i, cards := range hands {
+		hand, err := EvaluatePokerHand(cards)
+		if err != nil {
+			return nil, nil, fmt.Errorf("hand %d: %w", i, err)
+		}
+		evaluated[i] = hand
+
+		switch {
+		case len(winners) == 0 || hand.Value > evaluated[winners[0]].Value:
+			winners = append(winners[:0], i)
+		case hand.Value == evaluated[winners[0]].Value:
+			winners = append(winners, i)
+		}
+	}
+	return evaluated, winners, nil
+}
+
+// packPokerValue packs a category and up to five tie-break ranks into one comparable integer.
+func packPokerValue(category PokerHandCategory, r1, r2, r3, r4, r5 int) int {
+	return int(category)<<20 | r1<<16 | r2<<12 | r3<<8 | r4<<4 | r5
+}
+
+// straightHigh returns the top rank of the highest straight in a rank bit set, or 0 if there is none.
+func straightHigh(ranks uint16) int {
+	for high := 14; high >= 6; high-- {
+		if ranks>>(high-4)&0x1F == 0x1F {
+			return high
+		}
+	}
+	// The wheel, A-2-3-4-5, plays the Ace low
+	if ranks&(1<<14|0x3C) == 1<<14|0x3C {
+		return 5
+	}
+	return 0
+}
+
+// topRanks returns the n highest ranks in a rank bit set, padded with zeros.
+func topRanks(ranks uint16, n int) [5]int {
+	var top [5]int
+	for i := 0; i < n && ranks != 0; i++ {
+		rank := bits.Len16(ranks) - 1
+		top[i] = rank
+		ranks &^= 1 << rank
+	}
+	return top
+}
+
+// newPokerHand expands a hand value into its category, ranks, kickers and the five cards that make it.
+func newPokerHand(value int, cards []*Card) PokerHand {
+	category := PokerHandCategory(value >> 20)
+	ranks := []int{}
+	for shift := 16; shift >= 0; shift -= 4 {
+		if rank := value >> shift & 0xF; rank != 0 {
+			ranks = append(ranks, rank)
+		}
+	}
+
+	hand := PokerHand{
+		Category: category,
+		Name:     category.String(),
+		Ranks:    ranks,
+		Kickers:  append([]int{}, ranks[pokerMadeRanks[category]:]...),
+		Value:    value,
+	}
+
+	used := make([]bool, len(cards))
+	take := func(rank int, suit Suit, anySuit bool) {
+		for i, card := range cards {
+			if !used[i] && pokerRank(*card) == rank && (anySuit || card.Suit == suit) {
+				used[i] = true
+				hand.Cards = append(hand.Cards, *card)
+				return
+			}
+		}
+	}
+
+	switch category {
+	case Straight, StraightFlush:
+		suit, anySuit := Hearts, category == Straight
+		if !anySuit {
+			suit = straightFlushSuit(cards, ranks[0])
+		}
+		for rank := ranks[0]; rank > ranks[0]-5; rank-- {
+			if rank == 1 {
+				take(14, suit, anySuit) // The Ace plays low in the wheel
+				continue
+			}
+			take(rank, suit, anySuit)
+		}
+	case Flush:
+		suit := flushSuit(cards, ranks)
+		for _, rank := range ranks {
+			take(rank, suit, false)
+		}
+	default:
+		for i, count := range pokerGroups[category] {
+			for n := 0; n < count; n++ {
+				take(ranks[i], Hearts, true)
+			}
+		}
+	}
+	return hand
+}
+
+// straightFlushSuit returns the suit holding the straight flush topped by high.
+func straightFlushSuit(cards []*Card, high int) Suit {
+	var suits [4]uint16
+	for _, card := range cards {
+		suits[card.Suit] |= 1 << pokerRank(*card)
+	}
+	for suit, ranks := range suits {
+		if straightHigh(ranks) == high {
+			return Suit(suit)
+		}
+	}
+	return Hearts
+}
+
+// flushSuit returns the suit holding all of the given flush ranks.
+func flushSuit(cards []*Card, ranks []int) Suit {
+	var suits [4]uint16
+	for _, card := range cards {
+		suits[card.Suit] |= 1 << pokerRank(*card)
+	}
+	var want uint16
+	for _, rank := range ranks {
+		want |= 1 << rank
+	}
+	for suit, have := range suits {
+		if have&want == want {
+			return Suit(suit)
+		}
+	}
+	return Hearts
+}
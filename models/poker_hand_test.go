@@ -0,0 +1,259 @@
+package models
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pokerCards(cards ...Card) []*Card {
+	hand := make([]*Card, len(cards))
+	for i := range cards {
+		hand[i] = &cards[i]
+	}
+	return hand
+}
+
+func TestEvaluatePokerHandCategories(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    []*Card
+		category PokerHandCategory
+		ranks    []int
+	}{
+		{"high card", pokerCards(Card{Rank: Ace, Suit: Hearts}, Card{Rank: Nine, Suit: Clubs}, Card{Rank: Seven, Suit: Spades}, Card{Rank: Four, Suit: Diamonds}, Card{Rank: Two, Suit: Hearts}), HighCard, []int{14, 9, 7, 4, 2}},
+		{"one pair", pokerCards(Card{Rank: Eight, Suit: Hearts}, Card{Rank: Eight, Suit: Clubs}, Card{Rank: King, Suit: Spades}, Card{Rank: Four, Suit: Diamonds}, Card{Rank: Two, Suit: Hearts}), OnePair, []int{8, 13, 4, 2}},
+		{"two pair", pokerCards(Card{Rank: Eight, Suit: Hearts}, Card{Rank: Eight, Suit: Clubs}, Card{Rank: Four, Suit: Spades}, Card{Rank: Four, Suit: Diamonds}, Card{Rank: Jack, Suit: Hearts}), TwoPair, []int{8, 4, 11}},
+		{"trips", pokerCards(Card{Rank: Queen, Suit: Hearts}, Card{Rank: Queen, Suit: Clubs}, Card{Rank: Queen, Suit: Spades}, Card{Rank: Four, Suit: Diamonds}, Card{Rank: Two, Suit: Hearts}), ThreeOfAKind, []int{12, 4, 2}},
+		{"straight", pokerCards(Card{Rank: Nine, Suit: Hearts}, Card{Rank: Ten, Suit: Clubs}, Card{Rank: Jack, Suit: Spades}, Card{Rank: Queen, Suit: Diamonds}, Card{Rank: King, Suit: Hearts}), Straight, []int{13}},
+		{"wheel", pokerCards(Card{Rank: Ace, Suit: Hearts}, Card{Rank: Two, Suit: Clubs}, Card{Rank: Three, Suit: Spades}, Card{Rank: Four, Suit: Diamonds}, Card{Rank: Five, Suit: Hearts}), Straight, []int{5}},
+		{"flush", pokerCards(Card{Rank: Two, Suit: Clubs}, Card{Rank: Nine, Suit: Clubs}, Card{Rank: Jack, Suit: Clubs}, Card{Rank: Four, Suit: Clubs}, Card{Rank: Seven, Suit: Clubs}), Flush, []int{11, 9, 7, 4, 2}},
+		{"full house", pokerCards(Card{Rank: Three, Suit: Hearts}, Card{Rank: Three, Suit: Clubs}, Card{Rank: Three, Suit: Spades}, Card{Rank: King, Suit: Diamonds}, Card{Rank: King, Suit: Hearts}), FullHouse, []int{3, 13}},
+		{"quads", pokerCards(Card{Rank: Six, Suit: Hearts}, Card{Rank: Six, Suit: Clubs}, Card{Rank: Six, Suit: Spades}, Card{Rank: Six, Suit: Diamonds}, Card{Rank: Ace, Suit: Hearts}), FourOfAKind, []int{6, 14}},
+		{"straight flush", pokerCards(Card{Rank: Ace, Suit: Spades}, Card{Rank: Two, Suit: Spades}, Card{Rank: Three, Suit: Spades}, Card{Rank: Four, Suit: Spades}, Card{Rank: Five, Suit: Spades}), StraightFlush, []int{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand, err := EvaluatePokerHand(tt.cards)
+			require.NoError(t, err)
+			assert.Equal(t, tt.category, hand.Category)
+			assert.Equal(t, tt.category.String(), hand.Name)
+			assert.Equal(t, tt.ranks, hand.Ranks)
+			assert.Len(t, hand.Cards, 5)
+			assert.ElementsMatch(t, cardValues(tt.cards), hand.Cards)
+		})
+	}
+}
+
+func TestEvaluatePokerHandPicksBestFiveOfSeven(t *testing.T) {
+	// A flush on the board beats the pair in the hole cards
+	hand, err := EvaluatePokerHand(pokerCards(
+		Card{Rank: King, Suit: Spades}, Card{Rank: King, Suit: Hearts},
+		Card{Rank: Two, Suit: Hearts}, Card{Rank: Six, Suit: Hearts}, Card{Rank: Nine, Suit: Hearts},
+		Card{Rank: Jack, Suit: Hearts}, Card{Rank: Four, Suit: Clubs},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, Flush, hand.Category)
+	assert.Equal(t, []int{13, 11, 9, 6, 2}, hand.Ranks)
+
+	_, err = EvaluatePokerHand(pokerCards(Card{Rank: Ace, Suit: Spades}))
+	assert.Error(t, err)
+}
+
+func TestPokerHandTieBreaks(t *testing.T) {
+	board := []Card{{Rank: Ace, Suit: Clubs}, {Rank: Ace, Suit: Diamonds}, {Rank: Eight, Suit: Spades}, {Rank: Five, Suit: Hearts}, {Rank: Two, Suit: Clubs}}
+	evaluate := func(hole ...Card) PokerHand {
+		hand, err := EvaluatePokerHand(pokerCards(append(hole, board...)...))
+		require.NoError(t, err)
+		return hand
+	}
+
+	kingKicker := evaluate(Card{Rank: King, Suit: Hearts}, Card{Rank: Three, Suit: Spades})
+	queenKicker := evaluate(Card{Rank: Queen, Suit: Hearts}, Card{Rank: Jack, Suit: Spades})
+	assert.Equal(t, 1, kingKicker.Compare(queenKicker))
+	assert.Equal(t, -1, queenKicker.Compare(kingKicker))
+
+	// Both players play the same five cards and split
+	first := evaluate(Card{Rank: King, Suit: Hearts}, Card{Rank: Three, Suit: Spades})
+	second := evaluate(Card{Rank: King, Suit: Diamonds}, Card{Rank: Four, Suit: Spades})
+	assert.Equal(t, 0, first.Compare(second))
+
+	// A six-high straight beats the wheel
+	wheel := evaluate(Card{Rank: Three, Suit: Hearts}, Card{Rank: Four, Suit: Hearts})
+	sixHigh, err := EvaluatePokerHand(pokerCards(Card{Rank: Two, Suit: Hearts}, Card{Rank: Three, Suit: Clubs}, Card{Rank: Four, Suit: Spades}, Card{Rank: Five, Suit: Clubs}, Card{Rank: Six, Suit: Diamonds}))
+	require.NoError(t, err)
+	assert.Equal(t, Straight, wheel.Category)
+	assert.Equal(t, 1, sixHigh.Compare(wheel))
+}
+
+func cardValues(cards []*Card) []Card {
+	values := make([]Card, len(cards))
+	for i, card := range cards {
+		values[i] = *card
+	}
+	return values
+}
+
+func TestPokerHandKickersAndCards(t *testing.T) {
+	hand, err := EvaluatePokerHand(pokerCards(
+		Card{Rank: Eight, Suit: Hearts}, Card{Rank: Eight, Suit: Clubs},
+		Card{Rank: King, Suit: Spades}, Card{Rank: Four, Suit: Diamonds}, Card{Rank: Two, Suit: Hearts},
+		Card{Rank: Ace, Suit: Clubs}, Card{Rank: Three, Suit: Clubs},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, OnePair, hand.Category)
+	assert.Equal(t, []int{14, 13, 4}, hand.Kickers)
+	assert.Equal(t, []Card{{Rank: Eight, Suit: Hearts}, {Rank: Eight, Suit: Clubs}, {Rank: Ace, Suit: Clubs}, {Rank: King, Suit: Spades}, {Rank: Four, Suit: Diamonds}}, hand.Cards)
+
+	// The wheel straight flush lists the Ace last
+	hand, err = EvaluatePokerHand(pokerCards(
+		Card{Rank: Ace, Suit: Clubs}, Card{Rank: Two, Suit: Clubs}, Card{Rank: Three, Suit: Clubs},
+		Card{Rank: Four, Suit: Clubs}, Card{Rank: Five, Suit: Clubs}, Card{Rank: Five, Suit: Hearts},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, StraightFlush, hand.Category)
+	assert.Empty(t, hand.Kickers)
+	assert.Equal(t, Card{Rank: Ace, Suit: Clubs}, hand.Cards[4])
+
+	_, err = EvaluatePokerHand(pokerCards(Card{Rank: 0, Suit: Hearts}, Card{Rank: Two, Suit: Hearts}, Card{Rank: Three, Suit: Hearts}, Card{Rank: Four, Suit: Hearts}, Card{Rank: Five, Suit: Hearts}))
+	assert.Error(t, err)
+}
+
+func TestComparePokerHands(t *testing.T) {
+	board := []Card{{Rank: King, Suit: Clubs}, {Rank: Nine, Suit: Diamonds}, {Rank: Four, Suit: Spades}, {Rank: Four, Suit: Hearts}, {Rank: Two, Suit: Clubs}}
+	hand := func(hole ...Card) []*Card {
+		return pokerCards(append(hole, board...)...)
+	}
+
+	hands, winners, err := ComparePokerHands([][]*Card{
+		hand(Card{Rank: Ace, Suit: Hearts}, Card{Rank: Queen, Suit: Spades}),
+		hand(Card{Rank: Nine, Suit: Hearts}, Card{Rank: Three, Suit: Spades}),
+		hand(Card{Rank: Nine, Suit: Clubs}, Card{Rank: Three, Suit: Hearts}),
+	})
+	require.NoError(t, err)
+	assert.Len(t, hands, 3)
+	assert.Equal(t, []int{1, 2}, winners)
+	assert.Equal(t, TwoPair, hands[1].Category)
+
+	_, _, err = ComparePokerHands(nil)
+	assert.Error(t, err)
+	_, _, err = ComparePokerHands([][]*Card{hand(), pokerCards(board[0])})
+	assert.Error(t, err)
+}
+
+// referencePokerValue scores every five-card combination the slow, obvious way.
+func referencePokerValue(cards []*Card) int {
+	best := 0
+	n := len(cards)
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			for c := b + 1; c < n; c++ {
+				for d := c + 1; d < n; d++ {
+					for e := d + 1; e < n; e++ {
+						five := []Card{*cards[a], *cards[b], *cards[c], *cards[d], *cards[e]}
+						best = max(best, referenceFiveValue(five))
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+func referenceFiveValue(five []Card) int {
+	counts := map[int]int{}
+	flush := true
+	for _, card := range five {
+		counts[pokerRank(card)]++
+		flush = flush && card.Suit == five[0].Suit
+	}
+	groups := []int{}
+	for rank := range counts {
+		groups = append(groups, rank)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] > groups[j]
+	})
+
+	straight := 0
+	if len(groups) == 5 && groups[0]-groups[4] == 4 {
+		straight = groups[0]
+	} else if len(groups) == 5 && groups[0] == 14 && groups[1] == 5 {
+		straight = 5
+	}
+
+	var category PokerHandCategory
+	ranks := groups
+	switch {
+	case straight > 0 && flush:
+		category, ranks = StraightFlush, []int{straight}
+	case counts[groups[0]] == 4:
+		category = FourOfAKind
+	case counts[groups[0]] == 3 && counts[groups[1]] == 2:
+		category = FullHouse
+	case flush:
+		category = Flush
+	case straight > 0:
+		category, ranks = Straight, []int{straight}
+	case counts[groups[0]] == 3:
+		category = ThreeOfAKind
+	case counts[groups[0]] == 2 && counts[groups[1]] == 2:
+		category = TwoPair
+	case counts[groups[0]] == 2:
+		category = OnePair
+	}
+
+	value := int(category)
+	for i := 0; i < 5; i++ {
+		value <<= 4
+		if i < len(ranks) {
+			value |= ranks[i]
+		}
+	}
+	return value
+}
+
+func randomPokerHands(count, size int, seed int64) [][]*Card {
+	random := rand.New(rand.NewSource(seed))
+	deck := NewDeck()
+	hands := make([][]*Card, count)
+	for i := range hands {
+		random.Shuffle(len(deck.Cards), func(a, b int) {
+			deck.Cards[a], deck.Cards[b] = deck.Cards[b], deck.Cards[a]
+		})
+		hands[i] = pokerCards(append([]Card(nil), deck.Cards[:size]...)...)
+	}
+	return hands
+}
+
+func TestPokerHandValueMatchesReference(t *testing.T) {
+	for _, size := range []int{5, 6, 7} {
+		for _, cards := range randomPokerHands(20000, size, int64(size)) {
+			value, err := PokerHandValue(cards)
+			require.NoError(t, err)
+			require.Equal(t, referencePokerValue(cards), value, "%v", cardValues(cards))
+
+			hand := newPokerHand(value, cards)
+			require.Len(t, hand.Cards, 5)
+			require.Equal(t, value, referencePokerValue(pokerCards(hand.Cards...)), "the chosen five cards make the hand")
+		}
+	}
+}
+
+func BenchmarkPokerHandValue(b *testing.B) {
+	hands := randomPokerHands(4096, 7, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := PokerHandValue(hands[i%len(hands)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPokerGame creates a poker game with the named players and the given cards on top of the deck.
+func newPokerGame(t *testing.T, top []Card, names ...string) *Game {
+	game := NewGameWithType(1, Standard, Poker, 10)
+	for _, name := range names {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+	if len(top) > 0 {
+		game.Deck.Cards = append(top, game.Deck.Cards...)
+	}
+	return game
+}
+
+func pokerAct(t *testing.T, game *Game, seat int, action PokerAction, amount int) {
+	require.NoError(t, game.PokerAct(game.Players[seat].ID, action, amount))
+}
+
+func TestStartPokerGamePostsBlindsAndDealsHoleCards(t *testing.T) {
+	game := newPokerGame(t, nil, "Alice", "Bob", "Carol")
+	require.Error(t, game.StartPokerGame(PokerConfig{SmallBlind: 10, BigBlind: 5, StartingStack: 100}))
+	require.NoError(t, game.StartPokerGame(DefaultPokerConfig()))
+
+	state := game.PokerState
+	assert.Equal(t, GameInProgress, game.Status)
+	assert.Equal(t, PokerPreflop, state.Phase)
+	assert.Equal(t, 0, state.Button)
+	assert.Equal(t, 5, state.Seats[1].Bet)
+	assert.Equal(t, 10, state.Seats[2].Bet)
+	assert.Equal(t, 15, state.Pot)
+	assert.Equal(t, 0, game.CurrentPlayer, "first to act is left of the big blind")
+	for _, player := range game.Players {
+		require.Len(t, player.Hand, 2)
+		assert.False(t, player.Hand[0].FaceUp)
+	}
+
+	require.Error(t, game.PokerAct(game.Players[1].ID, PokerCall, 0))
+	assert.Error(t, game.PokerAct(game.Players[0].ID, PokerCheck, 0))
+	assert.Error(t, game.PokerAct(game.Players[0].ID, PokerBet, 50))
+	assert.Error(t, game.PokerAct(game.Players[0].ID, PokerRaise, 15))
+	assert.Error(t, game.PokerAct(game.Players[0].ID, PokerRaise, 5000))
+}
+
+func TestPokerFoldAwardsPotHeadsUp(t *testing.T) {
+	game := newPokerGame(t, nil, "Alice", "Bob")
+	require.NoError(t, game.StartPokerGame(DefaultPokerConfig()))
+
+	// Heads-up the button posts the small blind and acts first
+	assert.Equal(t, 5, game.PokerState.Seats[0].Bet)
+	assert.Equal(t, 0, game.CurrentPlayer)
+	pokerAct(t, game, 0, PokerFold, 0)
+
+	state := game.PokerState
+	assert.Equal(t, PokerShowdown, state.Phase)
+	assert.Equal(t, 995, state.Seats[0].Stack)
+	assert.Equal(t, 1005, state.Seats[1].Stack)
+	require.Len(t, state.Results, 1)
+	assert.Equal(t, []string{game.Players[1].ID}, state.Results[0].Winners)
+	assert.Empty(t, state.Results[0].Hand)
+	assert.Empty(t, state.Community)
+	assert.False(t, game.Players[0].Hand[0].FaceUp, "folded hands are never shown")
+}
+
+func TestPokerHandPlaysToShowdown(t *testing.T) {
+	top := []Card{
+		{Rank: Ace, Suit: Spades}, {Rank: King, Suit: Spades}, // Bob, Alice
+		{Rank: Ace, Suit: Hearts}, {Rank: King, Suit: Hearts},
+		{Rank: Two, Suit: Clubs}, // Burn
+		{Rank: Two, Suit: Diamonds}, {Rank: Seven, Suit: Clubs}, {Rank: Nine, Suit: Hearts},
+		{Rank: Three, Suit: Clubs}, {Rank: Jack, Suit: Diamonds},
+		{Rank: Five, Suit: Clubs}, {Rank: Four, Suit: Spades},
+	}
+	game := newPokerGame(t, top, "Alice", "Bob")
+	require.NoError(t, game.StartPokerGame(DefaultPokerConfig()))
+
+	pokerAct(t, game, 0, PokerCall, 0)
+	pokerAct(t, game, 1, PokerCheck, 0)
+	assert.Equal(t, PokerFlop, game.PokerState.Phase)
+	assert.Len(t, game.PokerState.Community, 3)
+	assert.Len(t, game.PokerState.Burned, 1)
+	assert.Equal(t, 1, game.CurrentPlayer, "the player left of the button acts first after the flop")
+
+	pokerAct(t, game, 1, PokerBet, 20)
+	pokerAct(t, game, 0, PokerRaise, 60)
+	assert.Error(t, game.PokerAct(game.Players[1].ID, PokerRaise, 80), "a re-raise must be at least the last raise")
+	pokerAct(t, game, 1, PokerCall, 0)
+	assert.Equal(t, PokerTurn, game.PokerState.Phase)
+
+	for _, street := range []PokerPhase{PokerTurn, PokerRiver} {
+		require.Equal(t, street, game.PokerState.Phase)
+		pokerAct(t, game, 1, PokerCheck, 0)
+		pokerAct(t, game, 0, PokerCheck, 0)
+	}
+
+	state := game.PokerState
+	assert.Equal(t, PokerShowdown, state.Phase)
+	assert.Len(t, state.Community, 5)
+	assert.Equal(t, 930, state.Seats[0].Stack)
+	assert.Equal(t, 1070, state.Seats[1].Stack)
+	require.Len(t, state.Results, 1)
+	assert.Equal(t, 140, state.Results[0].Amount)
+	assert.Equal(t, "one_pair", state.Results[0].Hand)
+	assert.Len(t, state.Showdown, 2)
+	assert.True(t, game.Players[0].Hand[0].FaceUp, "hands reaching showdown are revealed")
+
+	require.NoError(t, game.NextPokerHand())
+	assert.Equal(t, 2, game.PokerState.HandNumber)
+	assert.Equal(t, 1, game.PokerState.Button)
+	assert.Len(t, game.Players[0].Hand, 2)
+}
+
+func TestPokerSidePots(t *testing.T) {
+	top := []Card{
+		{Rank: Two, Suit: Clubs}, {Rank: Queen, Suit: Hearts}, {Rank: Ace, Suit: Spades}, // Bob, Carol, Alice
+		{Rank: Three, Suit: Diamonds}, {Rank: Queen, Suit: Diamonds}, {Rank: Ace, Suit: Hearts},
+		{Rank: Five, Suit: Spades},
+		{Rank: Nine, Suit: Clubs}, {Rank: Eight, Suit: Diamonds}, {Rank: Four, Suit: Hearts},
+		{Rank: Five, Suit: Clubs}, {Rank: Jack, Suit: Spades},
+		{Rank: Six, Suit: Diamonds}, {Rank: King, Suit: Spades},
+	}
+	game := newPokerGame(t, top, "Alice", "Bob", "Carol")
+	require.NoError(t, game.StartPokerGame(DefaultPokerConfig()))
+	game.PokerState.Seats[0].Stack = 100
+
+	pokerAct(t, game, 0, PokerAllIn, 0)
+	pokerAct(t, game, 1, PokerRaise, 300)
+	pokerAct(t, game, 2, PokerCall, 0)
+	for game.PokerState.Phase < PokerShowdown {
+		pokerAct(t, game, game.CurrentPlayer, PokerCheck, 0)
+	}
+
+	state := game.PokerState
+	require.Len(t, state.Pots, 2)
+	assert.Equal(t, 300, state.Pots[0].Amount)
+	assert.Len(t, state.Pots[0].Eligible, 3)
+	assert.Equal(t, 400, state.Pots[1].Amount)
+	assert.Len(t, state.Pots[1].Eligible, 2)
+
+	assert.Equal(t, []string{game.Players[0].ID}, state.Results[0].Winners)
+	assert.Equal(t, []string{game.Players[2].ID}, state.Results[1].Winners)
+	assert.Equal(t, 300, state.Seats[0].Stack)
+	assert.Equal(t, 700, state.Seats[1].Stack)
+	assert.Equal(t, 1100, state.Seats[2].Stack)
+}
+
+func TestPokerSplitPotAndAllInRunout(t *testing.T) {
+	top := []Card{
+		{Rank: Two, Suit: Clubs}, {Rank: Two, Suit: Hearts},
+		{Rank: Three, Suit: Clubs}, {Rank: Three, Suit: Hearts},
+		{Rank: Four, Suit: Spades},
+		{Rank: Ace, Suit: Clubs}, {Rank: King, Suit: Diamonds}, {Rank: Queen, Suit: Hearts},
+		{Rank: Five, Suit: Spades}, {Rank: Jack, Suit: Spades},
+		{Rank: Six, Suit: Spades}, {Rank: Ten, Suit: Diamonds},
+	}
+	game := newPokerGame(t, top, "Alice", "Bob")
+	require.NoError(t, game.StartPokerGame(PokerConfig{SmallBlind: 5, BigBlind: 10, StartingStack: 100}))
+
+	// Once everyone is all in the board is dealt out without further betting
+	pokerAct(t, game, 0, PokerAllIn, 0)
+	pokerAct(t, game, 1, PokerCall, 0)
+
+	state := game.PokerState
+	assert.Equal(t, PokerShowdown, state.Phase)
+	assert.Len(t, state.Community, 5)
+	require.Len(t, state.Results, 1)
+	assert.Len(t, state.Results[0].Winners, 2)
+	assert.Equal(t, "straight", state.Results[0].Hand)
+	assert.Equal(t, 100, state.Seats[0].Stack)
+	assert.Equal(t, 100, state.Seats[1].Stack)
+}
+
+func TestPokerShortAllInDoesNotReopenBetting(t *testing.T) {
+	game := newPokerGame(t, nil, "Alice", "Bob", "Carol")
+	require.NoError(t, game.StartPokerGame(DefaultPokerConfig()))
+	game.PokerState.Seats[2].Stack = 45 // Carol can go to 55 in total
+
+	pokerAct(t, game, 0, PokerRaise, 40)
+	pokerAct(t, game, 1, PokerFold, 0)
+	pokerAct(t, game, 2, PokerAllIn, 0) // To 55, less than a full raise
+
+	assert.Equal(t, 55, game.PokerState.CurrentBet)
+	assert.True(t, game.PokerState.Seats[0].CallOnly)
+	assert.Error(t, game.PokerAct(game.Players[0].ID, PokerRaise, 200))
+	pokerAct(t, game, 0, PokerCall, 0)
+	assert.Equal(t, PokerShowdown, game.PokerState.Phase)
+}
+
+func TestPokerGameEndsWhenOnePlayerHasChips(t *testing.T) {
+	top := []Card{
+		{Rank: Ace, Suit: Spades}, {Rank: Two, Suit: Clubs},
+		{Rank: Ace, Suit: Hearts}, {Rank: Seven, Suit: Diamonds},
+		{Rank: Four, Suit: Spades},
+		{Rank: King, Suit: Clubs}, {Rank: Queen, Suit: Diamonds}, {Rank: Nine, Suit: Spades},
+		{Rank: Four, Suit: Clubs}, {Rank: Five, Suit: Diamonds},
+		{Rank: Six, Suit: Clubs}, {Rank: Three, Suit: Spades},
+	}
+	game := newPokerGame(t, top, "Alice", "Bob")
+	require.NoError(t, game.StartPokerGame(PokerConfig{SmallBlind: 5, BigBlind: 10, StartingStack: 100}))
+
+	pokerAct(t, game, 0, PokerAllIn, 0)
+	pokerAct(t, game, 1, PokerCall, 0)
+
+	assert.Equal(t, PokerFinished, game.PokerState.Phase)
+	assert.Equal(t, GameFinished, game.Status)
+	assert.Equal(t, 200, game.PokerState.Seats[1].Stack)
+	assert.Error(t, game.NextPokerHand())
+}
+
+func TestPokerViewAndReplay(t *testing.T) {
+	game := newPokerGame(t, nil, "Alice", "Bob")
+	alice, bob := game.Players[0], game.Players[1]
+	require.NoError(t, game.StartPokerGame(DefaultPokerConfig()))
+	pokerAct(t, game, 0, PokerCall, 0)
+	pokerAct(t, game, 1, PokerCheck, 0)
+
+	view := game.ViewFor(Viewer{PlayerID: alice.ID})
+	assert.Equal(t, *alice.Hand[0], *view.Players[0].Hand[0])
+	assert.Equal(t, Card{}, *view.Players[1].Hand[0])
+	assert.Equal(t, *game.PokerState.Community[0], *view.PokerState.Community[0])
+	assert.Equal(t, Card{}, *view.PokerState.Burned[0])
+	view.PokerState.Seats[0].Stack = 0
+	assert.NotZero(t, game.PokerState.Seats[0].Stack, "views do not alias game state")
+
+	for game.PokerState.Phase < PokerShowdown {
+		pokerAct(t, game, game.CurrentPlayer, PokerCheck, 0)
+	}
+	require.NoError(t, game.NextPokerHand())
+	require.NoError(t, game.PokerAct(bob.ID, PokerRaise, 30))
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	require.NotNil(t, replayed.PokerState)
+	for i, seat := range game.PokerState.Seats {
+		assert.Equal(t, *seat, *replayed.PokerState.Seats[i])
+	}
+	assert.Equal(t, game.PokerState.HandNumber, replayed.PokerState.HandNumber)
+	assert.Equal(t, game.Players[0].Hand, replayed.Players[0].Hand)
+}
+
+func TestFirstPokerHandDealsTheDeckAsItStands(t *testing.T) {
+	newSeededPokerGame := func(seed uint64, shuffle bool) *Game {
+		game := NewGameWithType(1, Standard, Poker, 10)
+		require.NoError(t, game.SetSeed(seed))
+		require.NotNil(t, game.AddPlayer("Alice"))
+		require.NotNil(t, game.AddPlayer("Bob"))
+		if shuffle {
+			game.ShuffleDeck()
+		}
+		require.NoError(t, game.StartPokerGame(DefaultPokerConfig()))
+		return game
+	}
+
+	// Starting does not shuffle, so the seed only reaches the first hand through an explicit shuffle
+	assert.Equal(t, newSeededPokerGame(1, false).Players[0].Hand, newSeededPokerGame(2, false).Players[0].Hand)
+	first, second := newSeededPokerGame(42, true), newSeededPokerGame(42, true)
+	assert.Equal(t, first.Players[0].Hand, second.Players[0].Hand)
+	assert.NotEqual(t, first.Players[0].Hand, newSeededPokerGame(1, false).Players[0].Hand)
+
+	replayed, err := ReplayGame(first.Events, len(first.Events))
+	require.NoError(t, err)
+	assert.Equal(t, first.Players[0].Hand, replayed.Players[0].Hand)
+}
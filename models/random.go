@@ -0,0 +1,55 @@
+package models
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand/v2"
+)
+
+// secureRand is the default randomness for shuffles, Glitchjack decks and deck names. It draws
+// from the operating system's secure source and keeps no state, so it is safe to share between goroutines.
+var secureRand = NewCryptoRand()
+
+// cryptoSource is a math/rand/v2 source backed by crypto/rand.
+type cryptoSource struct{}
+
+// Uint64 reads eight bytes from the secure source. crypto/rand.Read never fails; it aborts the program
+// if the OS source is broken.
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	crand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// NewCryptoRand returns a generator backed by crypto/rand, the default for production decks.
+func NewCryptoRand() *rand.Rand {
+	return rand.New(cryptoSource{})
+}
+
+// NewSeededRand returns a deterministic generator: the same seed always gives the same shuffles.
+// It is meant for tests and is not safe for concurrent use.
+func NewSeededRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// SeededSource is the deterministic source of a seeded game. It is SplitMix64, whose whole state
+// is a single counter, so a saved game carries on from the same place in its sequence when it is loaded.
+// It is not safe for concurrent use.
+type SeededSource struct {
+	Seed  uint64 `json:"seed"`
+	State uint64 `json:"state"`
+}
+
+// NewSeededSource returns a source at the start of the seed's sequence.
+func NewSeededSource(seed uint64) *SeededSource {
+	return &SeededSource{Seed: seed, State: seed}
+}
+
+// Uint64 advances the counter and returns the next value in the sequence.
+func (s *SeededSource) Uint64() uint64 {
+	s.State += 0x9e3779b97f4a7c15
+	z := s.State
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Chi-square critical values at p = 0.000001, so a fair source fails about once in a million runs.
+const (
+	chiSquare23 = 71.2  // 23 degrees of freedom: the orderings of four cards
+	chiSquare51 = 114.5 // 51 degrees of freedom: positions in, or cards of, a 52-card deck
+)
+
+// chiSquare measures how far the observed counts stray from an even spread.
+func chiSquare(counts []int, expected float64) float64 {
+	total := 0.0
+	for _, count := range counts {
+		diff := float64(count) - expected
+		total += diff * diff / expected
+	}
+	return total
+}
+
+func TestShuffleOrderingsAreUniform(t *testing.T) {
+	for name, deck := range map[string]*Deck{"crypto": {}, "seeded": {rng: NewSeededRand(42)}} {
+		const rounds = 24000
+		counts := map[[4]Rank]int{}
+		for i := 0; i < rounds; i++ {
+			deck.Cards = []Card{{Rank: Ace}, {Rank: Two}, {Rank: Three}, {Rank: Four}}
+			deck.Shuffle()
+			counts[[4]Rank{deck.Cards[0].Rank, deck.Cards[1].Rank, deck.Cards[2].Rank, deck.Cards[3].Rank}]++
+		}
+		require.Len(t, counts, 24, name)
+
+		observed := []int{}
+		for _, count := range counts {
+			observed = append(observed, count)
+		}
+		assert.Less(t, chiSquare(observed, rounds/24), chiSquare23, name)
+	}
+}
+
+func TestShufflePositionsAreUniform(t *testing.T) {
+	const rounds = 52 * 400
+	positions := make([]int, 52)
+	deck := NewDeck()
+	for i := 0; i < rounds; i++ {
+		deck.Shuffle()
+		for position, card := range deck.Cards {
+			if card.Rank == Ace && card.Suit == Hearts {
+				positions[position]++
+			}
+		}
+	}
+	assert.Less(t, chiSquare(positions, rounds/52), chiSquare51)
+}
+
+func TestGlitchjackDecksDrawUniformly(t *testing.T) {
+	const decks = 400
+	counts := make([]int, 52)
+	rng := NewSeededRand(7)
+	for i := 0; i < decks; i++ {
+		for _, card := range NewGlitchjackDeckWithRand(rng).Cards {
+			counts[int(card.Suit)*13+int(card.Rank)-1]++
+		}
+	}
+	assert.Less(t, chiSquare(counts, decks), chiSquare51)
+
+	counts = make([]int, 52)
+	for i := 0; i < decks; i++ {
+		for _, card := range NewGlitchjackDeck().Cards {
+			counts[int(card.Suit)*13+int(card.Rank)-1]++
+		}
+	}
+	assert.Less(t, chiSquare(counts, decks), chiSquare51)
+}
+
+func TestSeededShufflesRepeat(t *testing.T) {
+	first, second, other := NewDeck(), NewDeck(), NewDeck()
+	first.SetRand(NewSeededRand(1))
+	second.SetRand(NewSeededRand(1))
+	other.SetRand(NewSeededRand(2))
+	for _, deck := range []*Deck{first, second, other} {
+		deck.Reset()
+		deck.Shuffle()
+	}
+	assert.Equal(t, first.Cards, second.Cards)
+	assert.NotEqual(t, first.Cards, other.Cards)
+
+	assert.Equal(t, NewGlitchjackDeckWithRand(NewSeededRand(3)).Cards, NewGlitchjackDeckWithRand(NewSeededRand(3)).Cards)
+
+	first.SetRand(nil)
+	assert.Same(t, secureRand, first.random())
+}
+
+func TestConcurrentShufflesShareTheSecureSource(t *testing.T) {
+	var wg sync.WaitGroup
+	decks := make([]*Deck, 8)
+	for i := range decks {
+		decks[i] = NewDeck()
+		wg.Add(1)
+		go func(deck *Deck) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				deck.Shuffle()
+			}
+		}(decks[i])
+	}
+	wg.Wait()
+
+	for _, deck := range decks {
+		seen := map[Card]bool{}
+		for _, card := range deck.Cards {
+			seen[card] = true
+		}
+		assert.Len(t, seen, 52)
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// MaxSeed is the largest seed a game takes, so it survives a round trip through JSON numbers.
+const MaxSeed = 1<<53 - 1
+
+// SetSeed makes a freshly created game deterministic: its deck name, Glitchjack deck composition and
+// every later shuffle are drawn from the seed, so the same seed and the same actions always deal the same cards.
+// Seeded games are meant for testing; anyone who knows the seed can predict the deck.
+func (g *Game) SetSeed(seed uint64) error {
+	if seed > MaxSeed {
+		return fmt.Errorf("seed must be 0-%d", uint64(MaxSeed))
+	}
+	if g.Seed != nil {
+		return fmt.Errorf("game is already seeded")
+	}
+	if g.Fair != nil {
+		return fmt.Errorf("provably fair games cannot be seeded")
+	}
+	if g.Status != GameWaiting || len(g.Players) > 0 {
+		return fmt.Errorf("games can only be seeded before anyone joins")
+	}
+
+	source := NewSeededSource(seed)
+	rng := rand.New(source)
+	if g.GameType == Glitchjack {
+		g.Deck = NewGlitchjackShoe(max(1, len(g.Deck.Cards)/52), rng)
+		g.Deck.SetRand(nil)
+	} else {
+		g.Deck.Name = generateDeckName(rng)
+	}
+	g.Deck.Source = source
+	g.Seed = &seed
+	g.record(GameEvent{Type: EventGameSeeded, Seed: &seed, Deck: g.Deck.snapshot()})
+	return nil
+}
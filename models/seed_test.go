@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSeededCribbageGame shuffles and deals a two-player cribbage game from the seed.
+func newSeededCribbageGame(t *testing.T, seed uint64) *Game {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	require.NoError(t, game.SetSeed(seed))
+	game.ShuffleDeck()
+	require.NotNil(t, game.AddPlayer("Alice"))
+	require.NotNil(t, game.AddPlayer("Bob"))
+	require.NoError(t, game.StartCribbageGame())
+	return game
+}
+
+func TestSeededGamesRepeat(t *testing.T) {
+	first, second, other := newSeededCribbageGame(t, 42), newSeededCribbageGame(t, 42), newSeededCribbageGame(t, 43)
+	assert.Equal(t, first.Deck.Name, second.Deck.Name)
+	assert.Equal(t, first.Deck.Cards, second.Deck.Cards)
+	assert.Equal(t, first.Players[0].Hand, second.Players[0].Hand)
+	assert.NotEqual(t, first.Deck.Cards, other.Deck.Cards)
+	assert.Equal(t, uint64(42), *first.Seed)
+
+	// A saved game picks up from the same place in its sequence
+	data, err := json.Marshal(first)
+	require.NoError(t, err)
+	var loaded Game
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	loaded.ShuffleDeck()
+	second.ShuffleDeck()
+	assert.Equal(t, second.Deck.Cards, loaded.Deck.Cards)
+
+	replayed, err := ReplayGame(first.Events, len(first.Events))
+	require.NoError(t, err)
+	assert.Equal(t, first.Deck.Cards, replayed.Deck.Cards)
+	assert.Equal(t, first.Seed, replayed.Seed)
+}
+
+func TestSeededGlitchjackComposition(t *testing.T) {
+	deal := func(seed uint64) *Game {
+		game := NewGameWithType(1, Standard, Glitchjack, 6)
+		game.ReplaceDeck(NewGlitchjackShoe(2, nil))
+		require.NoError(t, game.SetSeed(seed))
+		return game
+	}
+	first, second := deal(7), deal(7)
+	assert.Len(t, first.Deck.Cards, 104)
+	assert.Equal(t, first.Deck.Cards, second.Deck.Cards)
+	assert.Equal(t, first.Deck.Name, second.Deck.Name)
+	assert.NotEqual(t, first.Deck.Cards, deal(8).Deck.Cards)
+
+	replayed, err := ReplayGame(first.Events, len(first.Events))
+	require.NoError(t, err)
+	assert.Equal(t, first.Deck.Cards, replayed.Deck.Cards)
+}
+
+func TestSetSeedRestrictions(t *testing.T) {
+	game := NewGame(1)
+	assert.Error(t, game.SetSeed(MaxSeed+1))
+	require.NoError(t, game.SetSeed(1))
+	assert.EqualError(t, game.SetSeed(2), "game is already seeded")
+	assert.EqualError(t, game.MakeProvablyFair(), "seeded games cannot be provably fair")
+
+	game = NewGame(1)
+	require.NotNil(t, game.AddPlayer("Alice"))
+	assert.EqualError(t, game.SetSeed(1), "games can only be seeded before anyone joins")
+
+	game = NewGame(1)
+	require.NoError(t, game.MakeProvablyFair())
+	assert.EqualError(t, game.SetSeed(1), "provably fair games cannot be seeded")
+
+	assert.Nil(t, newSeededCribbageGame(t, 5).ViewFor(Spectator).Deck.Source, "spectators do not see the source state")
+}
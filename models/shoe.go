@@ -0,0 +1,136 @@
+package models
+
+import "math"
+
+// maxBurnCards caps the cards burned from the top of a freshly shuffled shoe.
+const maxBurnCards = 10
+
+// Shoe is the dealing shoe of a blackjack or Glitchjack table: the game's deck as it stood when it was
+// last shuffled, with a cut card placed at the table's penetration and the burn cards taken off the top.
+// Once the cut card comes out the round is finished from the same shoe, then the discards and burn
+// cards are collected and shuffled back in.
+type Shoe struct {
+	Number         int     `json:"number"`           // Shoes dealt at the table, from 1
+	Size           int     `json:"size"`             // Cards in the shoe when it was shuffled
+	CutCard        int     `json:"cut_card"`         // Cards dealt, burns included, before the cut card comes out
+	CutCardReached bool    `json:"cut_card_reached"` // The shoe is reshuffled once the current round is over
+	Burned         []*Card `json:"burned"`
+}
+
+// ShoeStatus is how far the deal has got through a table's shoe.
+type ShoeStatus struct {
+	Number         int     `json:"number"`
+	Size           int     `json:"size"`
+	Dealt          int     `json:"dealt"` // Cards dealt or burned since the shoe was shuffled
+	Remaining      int     `json:"remaining"`
+	Penetration    float64 `json:"penetration_percent"` // Percent of the shoe dealt
+	CutCard        int     `json:"cut_card"`
+	CutCardReached bool    `json:"cut_card_reached"`
+	Burned         int     `json:"burned"`
+	Discards       int     `json:"discards"` // Cards waiting in the main discard pile to be shuffled back in
+}
+
+// ShoePenetration returns the share of the shoe dealt since it was last shuffled.
+func (g *Game) ShoePenetration() float64 {
+	if g.Shoe == nil || g.Shoe.Size == 0 {
+		return 0
+	}
+	return float64(g.Shoe.dealt(g.Deck)) / float64(g.Shoe.Size)
+}
+
+// ShoeStatus describes the shoe of a blackjack or Glitchjack table, or the one it will deal from
+// before the first round. Other games, and provably fair tables, which deal every round from a
+// fresh deck, have no shoe and get nil.
+func (g *Game) ShoeStatus() *ShoeStatus {
+	if (g.GameType != Blackjack && g.GameType != Glitchjack) || g.Fair != nil {
+		return nil
+	}
+	shoe := g.Shoe
+	if shoe == nil {
+		shoe = g.placeCutCard(0)
+	}
+	status := &ShoeStatus{
+		Number:         shoe.Number,
+		Size:           shoe.Size,
+		Dealt:          shoe.dealt(g.Deck),
+		Remaining:      g.Deck.RemainingCards(),
+		Penetration:    math.Round(g.ShoePenetration()*1000) / 10,
+		CutCard:        shoe.CutCard,
+		CutCardReached: shoe.CutCardReached,
+		Burned:         len(shoe.Burned),
+	}
+	if pile := g.DiscardPiles["main"]; pile != nil {
+		status.Discards = pile.Size()
+	}
+	return status
+}
+
+// openShoe numbers the first round and fills the shoe it is dealt from, unless the table is provably fair.
+func (g *Game) openShoe() {
+	if g.Round == 0 {
+		g.Round = 1
+	}
+	if g.Shoe == nil && g.Fair == nil {
+		g.fillShoe()
+	}
+}
+
+// fillShoe starts a new shoe from the deck as it now stands: the cut card goes in at the table's
+// penetration and the burn cards are dealt face down off the top.
+func (g *Game) fillShoe() {
+	number := 1
+	if g.Shoe != nil {
+		number = g.Shoe.Number + 1
+	}
+	g.Shoe = g.placeCutCard(number)
+	for i := 0; i < g.Rules().BurnCards; i++ {
+		card := g.drawCard()
+		if card == nil {
+			break
+		}
+		card.FaceUp = false
+		g.Shoe.Burned = append(g.Shoe.Burned, card)
+	}
+}
+
+// placeCutCard returns a shoe of the deck as it now stands with the cut card at the table's penetration.
+func (g *Game) placeCutCard(number int) *Shoe {
+	size := g.Deck.RemainingCards()
+	return &Shoe{
+		Number:  number,
+		Size:    size,
+		CutCard: int(math.Round(float64(size) * g.Rules().Penetration)),
+		Burned:  []*Card{},
+	}
+}
+
+// collectShoe returns the burn cards and the main discard pile to the deck, face down, ready to be shuffled.
+func (g *Game) collectShoe() {
+	cards := g.DiscardPiles["main"].Clear()
+	if g.Shoe != nil {
+		cards = append(cards, g.Shoe.Burned...)
+		g.Shoe.Burned = []*Card{}
+	}
+	for _, card := range cards {
+		g.Deck.Cards = append(g.Deck.Cards, Card{Rank: card.Rank, Suit: card.Suit})
+	}
+}
+
+// checkCutCard notes when the deal reaches the cut card, so the shoe is reshuffled after the round.
+func (g *Game) checkCutCard() {
+	if g.Shoe != nil && !g.Shoe.CutCardReached && g.Shoe.dealt(g.Deck) >= g.Shoe.CutCard {
+		g.Shoe.CutCardReached = true
+	}
+}
+
+// dealt returns the cards dealt or burned from the shoe since it was shuffled.
+func (s *Shoe) dealt(deck *Deck) int {
+	return max(s.Size-deck.RemainingCards(), 0)
+}
+
+// snapshot returns a copy of the shoe that shares no cards with it.
+func (s *Shoe) snapshot() *Shoe {
+	copied := *s
+	copied.Burned = copyCards(s.Burned)
+	return &copied
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playSplittingRound deals a round in which every pair is split and every other hand stands,
+// returning how many splits were made.
+func playSplittingRound(t *testing.T, game *Game) int {
+	splits := 0
+	require.NoError(t, game.StartBlackjackGame())
+	for game.Status == GameInProgress {
+		player := game.Players[game.CurrentPlayer]
+		if game.PlayerSplit(player.ID) == nil {
+			splits++
+		} else {
+			require.NoError(t, game.PlayerStand(player.ID))
+		}
+	}
+	return splits
+}
+
+func TestShoeBurnsAndReshufflesAtTheCutCard(t *testing.T) {
+	rules := DefaultBlackjackRules()
+	rules.Decks = 2
+	rules.Penetration = 0.3
+	rules.BurnCards = 2
+	game := NewGame(2)
+	require.NoError(t, game.SetBlackjackRules(rules))
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+
+	status := game.ShoeStatus()
+	require.NotNil(t, status)
+	assert.Equal(t, 104, status.Size)
+	assert.Equal(t, 31, status.CutCard, "the cut card sits at the table's penetration")
+	assert.Zero(t, status.Dealt)
+
+	// After the two burn cards Alice is dealt the third and seventh cards: make them a pair
+	stacked := game.Deck.snapshot()
+	stacked.Cards[6], stacked.Cards[15] = stacked.Cards[15], stacked.Cards[6]
+	game.ReplaceDeck(stacked)
+	splits := playSplittingRound(t, game)
+	require.NotZero(t, splits, "Alice splits her pair")
+	require.NotNil(t, game.Shoe)
+	assert.Equal(t, 1, game.Shoe.Number)
+	assert.Len(t, game.Shoe.Burned, 2)
+	assert.False(t, game.Shoe.Burned[0].FaceUp)
+	dealt := 2
+	for _, player := range append([]*Player{game.Dealer}, game.Players...) {
+		dealt += len(player.tableCards())
+	}
+	status = game.ShoeStatus()
+	assert.Equal(t, dealt, status.Dealt, "burn cards count towards the penetration")
+	assert.Equal(t, 104-dealt, status.Remaining)
+	assert.InDelta(t, float64(dealt)*100/104, status.Penetration, 0.05)
+
+	view := game.ViewFor(Spectator)
+	assert.Equal(t, Card{}, *view.Shoe.Burned[0], "burn cards are hidden")
+	assert.NotEqual(t, Card{}, *game.Shoe.Burned[0])
+
+	for round := 0; round < 10 && !game.Shoe.CutCardReached; round++ {
+		require.NoError(t, game.NextRound())
+		assert.Equal(t, 1, game.Shoe.Number, "the shoe is kept until the cut card comes out")
+		assert.Equal(t, 104, shoeCardCount(game))
+		splits += playSplittingRound(t, game)
+	}
+	require.True(t, game.Shoe.CutCardReached)
+	assert.GreaterOrEqual(t, game.ShoeStatus().Dealt, 31)
+
+	require.NoError(t, game.NextRound())
+	assert.Equal(t, 2, game.Shoe.Number)
+	assert.False(t, game.Shoe.CutCardReached)
+	assert.Len(t, game.Shoe.Burned, 2, "a fresh shoe is burned again")
+	assert.Equal(t, 104, game.Shoe.Size, "every discard and burn card is collected back into the shoe")
+	assert.Equal(t, 102, game.Deck.RemainingCards())
+	assert.Empty(t, game.DiscardPiles["main"].Cards)
+	assert.Equal(t, 104, shoeCardCount(game))
+
+	playBlackjackRound(t, game)
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.Shoe, replayed.Shoe)
+	assert.Equal(t, game.Deck.Cards, replayed.Deck.Cards)
+
+	rules.BurnCards = maxBurnCards + 1
+	assert.Error(t, rules.Validate())
+	assert.Nil(t, NewGameWithType(1, Standard, War, 2).ShoeStatus())
+}
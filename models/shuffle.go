@@ -0,0 +1,248 @@
+package models
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Shuffle modes. Every mode but perfect models how people really shuffle, so the deck keeps some of
+// its old order; casino runs a procedure made of the other steps.
+const (
+	ShufflePerfect  = "perfect"  // Fisher-Yates: every order equally likely
+	ShuffleRiffle   = "riffle"   // Gilbert-Shannon-Reeds riffle
+	ShuffleOverhand = "overhand" // Small packets run from the top of the deck onto the other hand
+	ShuffleStrip    = "strip"    // Larger packets stripped off the top onto the table
+	ShuffleWash     = "wash"     // Cards spread face down and swirled around the table
+	ShuffleCut      = "cut"      // A single cut near the middle
+	ShuffleCasino   = "casino"   // A dealer's procedure of the steps above
+)
+
+const (
+	maxShuffleCount = 20 // Most repeats of a step, or steps in a procedure
+	defaultRiffles  = 7  // Riffles that leave a 52-card deck close to random
+)
+
+// casinoProcedure is the procedure casino mode follows unless it is given its own steps.
+var casinoProcedure = []string{ShuffleRiffle, ShuffleRiffle, ShuffleStrip, ShuffleRiffle, ShuffleCut}
+
+// ShuffleMode is how a deck is shuffled.
+type ShuffleMode struct {
+	Mode  string   `json:"mode"`
+	Count int      `json:"count,omitempty"` // Times the step is repeated; 0 gives 7 riffles or one of anything else
+	Steps []string `json:"steps,omitempty"` // Casino procedure; empty follows riffle, riffle, strip, riffle, cut
+}
+
+// ParseShuffleMode reads a shuffle mode written as the mode name, optionally followed by a colon and
+// the number of times to repeat it, such as "riffle:3", or for casino the steps, such as "casino:wash,riffle,cut".
+func ParseShuffleMode(value string) (ShuffleMode, error) {
+	name, arg, found := strings.Cut(value, ":")
+	mode := ShuffleMode{Mode: name}
+	if found && name == ShuffleCasino {
+		mode.Steps = strings.Split(arg, ",")
+	} else if found {
+		count, err := strconv.Atoi(arg)
+		if err != nil {
+			return ShuffleMode{}, fmt.Errorf("shuffle count must be a number")
+		}
+		mode.Count = count
+	}
+	return mode, mode.Validate()
+}
+
+// String writes the mode the way ParseShuffleMode reads it.
+func (m ShuffleMode) String() string {
+	switch {
+	case len(m.Steps) > 0:
+		return m.Mode + ":" + strings.Join(m.Steps, ",")
+	case m.Count > 0:
+		return m.Mode + ":" + strconv.Itoa(m.Count)
+	default:
+		return m.Mode
+	}
+}
+
+// Validate reports the first part of the mode that is not allowed.
+func (m ShuffleMode) Validate() error {
+	if m.Count < 0 || m.Count > maxShuffleCount {
+		return fmt.Errorf("shuffle count must be 0-%d", maxShuffleCount)
+	}
+	if m.Mode == ShuffleCasino {
+		if m.Count > 0 {
+			return fmt.Errorf("a casino shuffle is repeated by listing its steps")
+		}
+		if len(m.Steps) > maxShuffleCount {
+			return fmt.Errorf("a casino shuffle has at most %d steps", maxShuffleCount)
+		}
+		for _, step := range m.Steps {
+			if !isShuffleStep(step) {
+				return fmt.Errorf("unknown shuffle step %q", step)
+			}
+		}
+		return nil
+	}
+	if !isShuffleStep(m.Mode) {
+		return fmt.Errorf("shuffle mode must be perfect, riffle, overhand, strip, wash, cut or casino")
+	}
+	if len(m.Steps) > 0 {
+		return fmt.Errorf("only a casino shuffle has steps")
+	}
+	return nil
+}
+
+// isShuffleStep reports whether the name is a single shuffle step rather than a procedure.
+func isShuffleStep(name string) bool {
+	switch name {
+	case ShufflePerfect, ShuffleRiffle, ShuffleOverhand, ShuffleStrip, ShuffleWash, ShuffleCut:
+		return true
+	}
+	return false
+}
+
+// ShuffleWith shuffles the deck the given way, drawing from the deck's randomness.
+func (d *Deck) ShuffleWith(mode ShuffleMode) {
+	if mode.Mode == ShuffleCasino {
+		steps := mode.Steps
+		if len(steps) == 0 {
+			steps = casinoProcedure
+		}
+		for _, step := range steps {
+			d.ShuffleWith(ShuffleMode{Mode: step})
+		}
+		return
+	}
+
+	count := mode.Count
+	if count == 0 {
+		count = 1
+		if mode.Mode == ShuffleRiffle {
+			count = defaultRiffles
+		}
+	}
+	rng := d.random()
+	for i := 0; i < count; i++ {
+		switch mode.Mode {
+		case ShufflePerfect:
+			d.Shuffle()
+		case ShuffleRiffle:
+			d.Cards = riffle(d.Cards, rng)
+		case ShuffleOverhand:
+			d.Cards = runPackets(d.Cards, rng, 1, 8)
+		case ShuffleStrip:
+			d.Cards = runPackets(d.Cards, rng, 4, 12)
+		case ShuffleWash:
+			d.Cards = wash(d.Cards, rng)
+		case ShuffleCut:
+			d.Cards = cut(d.Cards, rng)
+		}
+	}
+}
+
+// riffle is one Gilbert-Shannon-Reeds riffle: the deck is cut binomially into two halves, and each
+// card falls from a half with probability proportional to the cards left in it.
+func riffle(cards []Card, rng *rand.Rand) []Card {
+	split := 0
+	for range cards {
+		split += rng.IntN(2)
+	}
+	left, right := cards[:split], cards[split:]
+	mixed := make([]Card, 0, len(cards))
+	for len(left) > 0 || len(right) > 0 {
+		if rng.IntN(len(left)+len(right)) < len(left) {
+			mixed, left = append(mixed, left[0]), left[1:]
+		} else {
+			mixed, right = append(mixed, right[0]), right[1:]
+		}
+	}
+	return mixed
+}
+
+// runPackets takes packets of smallest to largest cards off the top and drops each one on the last, so the
+// packets come out in reverse order with the cards inside them still in order.
+func runPackets(cards []Card, rng *rand.Rand, smallest, largest int) []Card {
+	run := make([]Card, len(cards))
+	end := len(cards)
+	for start := 0; start < len(cards); {
+		size := smallest + rng.IntN(largest-smallest+1)
+		if start+size > len(cards) {
+			size = len(cards) - start
+		}
+		copy(run[end-size:end], cards[start:start+size])
+		start += size
+		end -= size
+	}
+	return run
+}
+
+// wash slides every card a random distance from where it lay, a quarter of the deck on average,
+// before the cards are gathered back up. Neighbours tend to stay close, unlike a perfect shuffle.
+func wash(cards []Card, rng *rand.Rand) []Card {
+	spread := float64(len(cards)) / 4
+	positions := make([]float64, len(cards))
+	order := make([]int, len(cards))
+	for i := range cards {
+		positions[i] = float64(i) + rng.NormFloat64()*spread
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return positions[order[a]] < positions[order[b]] })
+
+	washed := make([]Card, len(cards))
+	for i, from := range order {
+		washed[i] = cards[from]
+	}
+	return washed
+}
+
+// cut moves the top part of the deck, within an eighth of the deck of the middle, to the bottom.
+func cut(cards []Card, rng *rand.Rand) []Card {
+	if len(cards) < 2 {
+		return cards
+	}
+	eighth := len(cards) / 8
+	at := len(cards)/2 - eighth + rng.IntN(2*eighth+1)
+	return append(append(make([]Card, 0, len(cards)), cards[at:]...), cards[:at]...)
+}
+
+// SetShuffleMode chooses how every later shuffle of a newly created game is done, including reshuffles
+// between rounds and hands, and shuffles the new deck that way.
+func (g *Game) SetShuffleMode(mode ShuffleMode) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+	if g.Fair != nil {
+		return fmt.Errorf("provably fair games always use a perfect shuffle")
+	}
+	if g.Status != GameWaiting || len(g.Players) > 0 {
+		return fmt.Errorf("the shuffle mode can only be chosen before anyone joins")
+	}
+
+	g.ShuffleMode = &mode
+	g.Deck.ShuffleWith(mode)
+	g.record(GameEvent{Type: EventShuffleModeSet, ShuffleMode: &mode, Deck: g.Deck.snapshot()})
+	return nil
+}
+
+// ShuffleDeckWith shuffles the game's deck once the given way, whatever its shuffle mode, and records the result.
+func (g *Game) ShuffleDeckWith(mode ShuffleMode) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+	if g.Fair != nil {
+		return fmt.Errorf("provably fair games always use a perfect shuffle")
+	}
+
+	g.Deck.ShuffleWith(mode)
+	g.record(GameEvent{Type: EventDeckShuffled, ShuffleMode: &mode, Deck: g.Deck.snapshot()})
+	return nil
+}
+
+// shuffle shuffles the deck in the game's shuffle mode, or perfectly when it has none.
+func (g *Game) shuffle() {
+	if g.ShuffleMode != nil {
+		g.Deck.ShuffleWith(*g.ShuffleMode)
+		return
+	}
+	g.Deck.Shuffle()
+}
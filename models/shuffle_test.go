@@ -0,0 +1,143 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cardOrder numbers the cards of a fresh deck by where they started, so shuffles can be checked for what order survives.
+func cardOrder(cards []Card) []int {
+	order := make([]int, len(cards))
+	for i, card := range cards {
+		order[i] = int(card.Suit)*13 + int(card.Rank) - 1
+	}
+	return order
+}
+
+// risingSequences counts the runs of consecutive original positions a shuffled deck splits into;
+// a riffle at most doubles them.
+func risingSequences(order []int) int {
+	at := make([]int, len(order))
+	for i, card := range order {
+		at[card] = i
+	}
+	sequences := 1
+	for card := 1; card < len(at); card++ {
+		if at[card] < at[card-1] {
+			sequences++
+		}
+	}
+	return sequences
+}
+
+// packetSize returns the length of the run of consecutive cards at the bottom of the deck.
+func packetSize(order []int) int {
+	size := 1
+	for i := len(order) - 1; i > 0 && order[i] == order[i-1]+1; i-- {
+		size++
+	}
+	return size
+}
+
+func TestParseShuffleMode(t *testing.T) {
+	mode, err := ParseShuffleMode("riffle:3")
+	require.NoError(t, err)
+	assert.Equal(t, ShuffleMode{Mode: ShuffleRiffle, Count: 3}, mode)
+	assert.Equal(t, "riffle:3", mode.String())
+
+	mode, err = ParseShuffleMode("casino:wash,riffle,cut")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ShuffleWash, ShuffleRiffle, ShuffleCut}, mode.Steps)
+
+	for _, invalid := range []string{"", "shake", "riffle:x", "riffle:21", "casino:riffle,shake", "casino:", "strip:-1"} {
+		_, err := ParseShuffleMode(invalid)
+		assert.Error(t, err, invalid)
+	}
+	assert.Error(t, ShuffleMode{Mode: ShuffleRiffle, Steps: []string{ShuffleCut}}.Validate())
+}
+
+func TestShuffleModesKeepEveryCard(t *testing.T) {
+	for _, name := range []string{ShufflePerfect, ShuffleRiffle, ShuffleOverhand, ShuffleStrip, ShuffleWash, ShuffleCut, ShuffleCasino} {
+		deck := NewMultiDeck(6)
+		deck.SetRand(NewSeededRand(1))
+		deck.ShuffleWith(ShuffleMode{Mode: name})
+		assert.Len(t, deck.Cards, 312, name)
+
+		order := cardOrder(deck.Cards)
+		sort.Ints(order)
+		fresh := cardOrder(NewMultiDeck(6).Cards)
+		sort.Ints(fresh)
+		assert.Equal(t, fresh, order, name)
+	}
+}
+
+func TestImperfectShufflesLeaveOrder(t *testing.T) {
+	for riffles := 1; riffles <= 3; riffles++ {
+		deck := NewDeck()
+		deck.SetRand(NewSeededRand(uint64(riffles)))
+		deck.ShuffleWith(ShuffleMode{Mode: ShuffleRiffle, Count: riffles})
+		assert.LessOrEqual(t, risingSequences(cardOrder(deck.Cards)), 1<<riffles, "%d riffles", riffles)
+	}
+
+	// An overhand or strip run reverses the packets but keeps the cards inside them in order
+	for _, name := range []string{ShuffleOverhand, ShuffleStrip} {
+		deck := NewDeck()
+		deck.SetRand(NewSeededRand(2))
+		deck.ShuffleWith(ShuffleMode{Mode: name})
+		order := cardOrder(deck.Cards)
+		packets := 1
+		for i := 1; i < len(order); i++ {
+			if order[i] != order[i-1]+1 {
+				packets++
+				assert.Less(t, order[i], order[i-1], "%s: each packet came from higher in the deck than the one below it", name)
+			}
+		}
+		assert.Greater(t, packets, 3, name)
+		assert.Equal(t, 0, order[len(order)-packetSize(order)], "%s: the top packet ends up at the bottom", name)
+	}
+
+	deck := NewDeck()
+	deck.SetRand(NewSeededRand(3))
+	deck.ShuffleWith(ShuffleMode{Mode: ShuffleCut})
+	order := cardOrder(deck.Cards)
+	for i := 1; i < len(order); i++ {
+		assert.Equal(t, (order[i-1]+1)%52, order[i], "a cut keeps the cycle of the deck")
+	}
+	assert.InDelta(t, 26, 52-order[0], 7, "cut near the middle")
+}
+
+func TestGameShuffleMode(t *testing.T) {
+	game := NewGame(1)
+	require.NoError(t, game.SetShuffleMode(ShuffleMode{Mode: ShuffleCut}))
+	assert.Equal(t, EventShuffleModeSet, game.Events[len(game.Events)-1].Type)
+
+	// Every later shuffle of the game uses its mode
+	before := cardOrder(game.Deck.Cards)
+	game.ShuffleDeck()
+	after := cardOrder(game.Deck.Cards)
+	start := 0
+	for before[start] != after[0] {
+		start++
+	}
+	assert.Equal(t, append(before[start:], before[:start]...), after)
+
+	require.NoError(t, game.ShuffleDeckWith(ShuffleMode{Mode: ShuffleRiffle, Count: 2}))
+	assert.Equal(t, "riffle:2", game.Events[len(game.Events)-1].ShuffleMode.String())
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	assert.Equal(t, game.Deck.Cards, replayed.Deck.Cards)
+	assert.Equal(t, game.ShuffleMode, replayed.ShuffleMode)
+
+	require.NotNil(t, game.AddPlayer("Alice"))
+	assert.Error(t, game.SetShuffleMode(ShuffleMode{Mode: ShuffleWash}), "chosen before anyone joins")
+	assert.EqualError(t, game.MakeProvablyFair(), "provably fair games always use a perfect shuffle")
+
+	fair := NewGame(1)
+	require.NoError(t, fair.MakeProvablyFair())
+	assert.Error(t, fair.SetShuffleMode(ShuffleMode{Mode: ShuffleWash}))
+	assert.Error(t, fair.ShuffleDeckWith(ShuffleMode{Mode: ShuffleWash}))
+}
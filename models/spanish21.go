@@ -0,0 +1,65 @@
+package models
+
+// Spanish 21 bonuses, paid on a 21 that has not been doubled.
+const (
+	BonusFiveCard21  = "five_card_21"  // Pays 3:2
+	BonusSixCard21   = "six_card_21"   // Pays 2:1
+	BonusSevenCard21 = "seven_card_21" // Seven or more cards, pays 3:1
+	Bonus678         = "678"           // Mixed suits, pays 3:2
+	Bonus678Suited   = "678_suited"    // Pays 2:1
+	Bonus678Spades   = "678_spades"    // Pays 3:1
+	Bonus777         = "777"           // Mixed suits, pays 3:2
+	Bonus777Suited   = "777_suited"    // Pays 2:1
+	Bonus777Spades   = "777_spades"    // Pays 3:1
+)
+
+// maxSpanish21Redoubles is how many times a doubled Spanish 21 hand may double again.
+const maxSpanish21Redoubles = 2
+
+// spanish21 reports whether the game is blackjack dealt from a Spanish 21 deck, which plays Spanish 21 rules.
+func (g *Game) spanish21() bool {
+	return g.GameType == Blackjack && g.Deck != nil && g.Deck.DeckType == Spanish21
+}
+
+// spanish21Bonus returns the bonus a winning Spanish 21 hand earns and the odds it pays,
+// or "" when the hand is not a bonus 21.
+func spanish21Bonus(hand *BlackjackHand) (string, int, int) {
+	if total, _ := blackjackTotal(hand.Cards); total != 21 || hand.Doubled {
+		return "", 0, 0
+	}
+
+	if len(hand.Cards) == 3 {
+		ranks := map[Rank]int{}
+		suits := map[Suit]bool{}
+		for _, card := range hand.Cards {
+			ranks[card.Rank]++
+			suits[card.Suit] = true
+		}
+		name := ""
+		switch {
+		case ranks[Six] == 1 && ranks[Seven] == 1 && ranks[Eight] == 1:
+			name = Bonus678
+		case ranks[Seven] == 3:
+			name = Bonus777
+		}
+		switch {
+		case name == "":
+		case len(suits) == 1 && suits[Spades]:
+			return name + "_spades", 3, 1
+		case len(suits) == 1:
+			return name + "_suited", 2, 1
+		default:
+			return name, 3, 2
+		}
+	}
+
+	switch {
+	case len(hand.Cards) >= 7:
+		return BonusSevenCard21, 3, 1
+	case len(hand.Cards) == 6:
+		return BonusSixCard21, 2, 1
+	case len(hand.Cards) == 5:
+		return BonusFiveCard21, 3, 2
+	}
+	return "", 0, 0
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSpanish21Game seats the named players with 1000 chips and a bet of 100 at a Spanish 21 table,
+// with the ranks on top of the deck dealt as in newStackedBlackjackGame.
+func newSpanish21Game(t *testing.T, top []Rank, names ...string) *Game {
+	game := NewCustomGame(1, Spanish21)
+	for _, name := range names {
+		player := game.AddPlayer(name)
+		require.NotNil(t, player)
+		require.NoError(t, game.PlayerBuyIn(player.ID, 1000))
+		require.NoError(t, game.PlaceBet(player.ID, 100))
+	}
+	stackDeck(game, top)
+	require.NoError(t, game.StartBlackjackGame())
+	return game
+}
+
+func TestSpanish21Bonus(t *testing.T) {
+	hand := func(doubled bool, cards ...Card) *BlackjackHand {
+		h := &BlackjackHand{Doubled: doubled}
+		for i := range cards {
+			h.Cards = append(h.Cards, &cards[i])
+		}
+		return h
+	}
+	tests := []struct {
+		name  string
+		hand  *BlackjackHand
+		bonus string
+		odds  []int
+	}{
+		{"mixed 678", hand(false, Card{Six, Hearts, true}, Card{Seven, Clubs, true}, Card{Eight, Hearts, true}), Bonus678, []int{3, 2}},
+		{"suited 777", hand(false, Card{Seven, Hearts, true}, Card{Seven, Hearts, true}, Card{Seven, Hearts, true}), Bonus777Suited, []int{2, 1}},
+		{"spade 678", hand(false, Card{Eight, Spades, true}, Card{Six, Spades, true}, Card{Seven, Spades, true}), Bonus678Spades, []int{3, 1}},
+		{"five cards", hand(false, Card{Two, Hearts, true}, Card{Three, Clubs, true}, Card{Four, Hearts, true}, Card{Five, Hearts, true}, Card{Seven, Hearts, true}), BonusFiveCard21, []int{3, 2}},
+		{"six cards", hand(false, Card{Ace, Hearts, true}, Card{Two, Clubs, true}, Card{Three, Hearts, true}, Card{Four, Hearts, true}, Card{Five, Hearts, true}, Card{Six, Hearts, true}), BonusSixCard21, []int{2, 1}},
+		{"doubled 777", hand(true, Card{Seven, Hearts, true}, Card{Seven, Hearts, true}, Card{Seven, Hearts, true}), "", []int{0, 0}},
+		{"plain 21", hand(false, Card{Nine, Hearts, true}, Card{Five, Clubs, true}, Card{Seven, Hearts, true}), "", []int{0, 0}},
+	}
+	for _, tt := range tests {
+		bonus, win, stake := spanish21Bonus(tt.hand)
+		assert.Equal(t, tt.bonus, bonus, tt.name)
+		assert.Equal(t, tt.odds, []int{win, stake}, tt.name)
+	}
+}
+
+func TestSpanish21TwentyOneAlwaysWins(t *testing.T) {
+	// Alice makes 7-7-7 against a dealer 21 of three cards and is paid the bonus
+	game := newSpanish21Game(t, []Rank{Seven, King, Seven, Five, Seven, Six}, "Alice")
+	alice := game.Players[0]
+	require.NoError(t, game.PlayerHit(alice.ID))
+	require.NoError(t, game.PlayerStand(alice.ID))
+	require.Len(t, game.Dealer.Hand, 3)
+
+	result := game.GetHandResults()[alice.ID].Hands[0]
+	assert.Equal(t, "win", result.Result)
+	assert.Equal(t, Bonus777, result.Bonus)
+	assert.Equal(t, 250, result.Payout)
+	assert.Equal(t, 1150, alice.Bankroll)
+
+	// Without a peek she can make 7-7-7 against a dealer blackjack, which still beats it
+	require.False(t, game.Rules().DealerPeek)
+	game = newSpanish21Game(t, []Rank{Seven, King, Seven, Ace, Seven}, "Alice")
+	alice = game.Players[0]
+	require.NoError(t, game.PlayerHit(alice.ID))
+	require.NoError(t, game.PlayerStand(alice.ID))
+
+	result = game.GetHandResults()[alice.ID].Hands[0]
+	assert.Equal(t, "lose", result.Result)
+	assert.Empty(t, result.Bonus)
+	assert.Zero(t, result.Payout)
+	assert.Equal(t, 900, alice.Bankroll)
+
+	// A player blackjack beats a dealer blackjack rather than pushing
+	game = newSpanish21Game(t, []Rank{Ace, King, Queen, Ace}, "Alice")
+	require.NoError(t, game.PlayerStand(game.Players[0].ID))
+	assert.Equal(t, "blackjack", game.GetGameResult()[game.Players[0].ID])
+}
+
+func TestSpanish21Redoubling(t *testing.T) {
+	game := newSpanish21Game(t, []Rank{Two, Nine, Three, Seven, Two, Three, Two, Four}, "Alice")
+	alice := game.Players[0]
+
+	require.NoError(t, game.PlayerHit(alice.ID))
+	require.NoError(t, game.PlayerDouble(alice.ID), "Spanish 21 doubles on any number of cards")
+	assert.EqualError(t, game.PlayerHit(alice.ID), "a doubled hand can only re-double or stand")
+	require.NoError(t, game.PlayerDouble(alice.ID))
+	assert.Equal(t, GameInProgress, game.Status)
+	require.NoError(t, game.PlayerDouble(alice.ID))
+
+	hand := alice.Hands[0]
+	assert.Equal(t, 800, hand.Bet)
+	assert.Equal(t, maxSpanish21Redoubles, hand.Redoubles)
+	assert.True(t, hand.Stood, "the last re-double stands the hand")
+	assert.Equal(t, GameFinished, game.Status)
+
+	// Standard decks keep the two-card rule
+	game = newStackedBlackjackGame(t, []Rank{Two, Nine, Three, Seven, Two}, "Alice")
+	require.NoError(t, game.PlayerHit(game.Players[0].ID))
+	assert.Error(t, game.PlayerDouble(game.Players[0].ID))
+}
@@ -0,0 +1,248 @@
+package models
+
+// Viewer identifies who a game is being shown to and therefore which cards they may see.
+type Viewer struct {
+	PlayerID string `json:"player_id,omitempty"` // Seat whose own cards are visible; empty for spectators
+	Admin    bool   `json:"admin,omitempty"`     // Admins and observers see every card
+}
+
+// Spectator is the viewer used for requests that identify neither a player nor an admin.
+var Spectator = Viewer{}
+
+// CanSeeAll reports whether the viewer sees the game unredacted.
+func (v Viewer) CanSeeAll() bool {
+	return v.Admin
+}
+
+// hiddenCard stands in for a card the viewer may not see: a face-down card with no rank or suit.
+func hiddenCard() *Card {
+	return &Card{}
+}
+
+// ViewFor returns a copy of the game showing only what the viewer is allowed to see.
+// Non-admin viewers get the undealt deck, other players' face-down cards, opponents' cribbage
+// hands, the crib (until the show), burned poker and shoe cards and face-down War piles replaced by hidden cards, so counts stay accurate.
+// Player token hashes are stripped from every view, as is a provably fair server seed until the round is over,
+// and non-admins do not see where a seeded deck's source has got to.
+// The game itself is never modified.
+func (g *Game) ViewFor(viewer Viewer) *Game {
+	view := *g
+	view.drawn = nil
+
+	view.Players = make([]*Player, len(g.Players))
+	for i, player := range g.Players {
+		view.Players[i] = g.playerView(player, viewer)
+	}
+	if g.Dealer != nil {
+		view.Dealer = g.playerView(g.Dealer, viewer)
+	}
+
+	if g.Deck != nil {
+		deck := *g.Deck
+		deck.Cards = make([]Card, len(g.Deck.Cards))
+		copy(deck.Cards, g.Deck.Cards)
+		if !viewer.CanSeeAll() {
+			for i := range deck.Cards {
+				deck.Cards[i] = Card{}
+			}
+			deck.Source = nil
+		} else if g.Deck.Source != nil {
+			source := *g.Deck.Source
+			deck.Source = &source
+		}
+		view.Deck = &deck
+	}
+
+	if g.Shoe != nil {
+		view.Shoe = g.Shoe.snapshot()
+		if !viewer.CanSeeAll() {
+			for i := range view.Shoe.Burned {
+				view.Shoe.Burned[i] = hiddenCard()
+			}
+		}
+	}
+
+	if g.Fair != nil {
+		view.Fair = g.Fair.snapshot()
+		if !viewer.CanSeeAll() && !g.fairRevealed() {
+			view.Fair.ServerSeed = ""
+		}
+	}
+
+	if g.CribbageState != nil {
+		state := *g.CribbageState
+		state.Crib = copyCards(g.CribbageState.Crib)
+		if !viewer.CanSeeAll() && !g.cribbageShowing() {
+			for i := range state.Crib {
+				state.Crib[i] = hiddenCard()
+			}
+		}
+		view.CribbageState = &state
+	}
+
+	if g.PokerState != nil {
+		state := *g.PokerState
+		state.Community = copyCards(g.PokerState.Community)
+		state.Burned = copyCards(g.PokerState.Burned)
+		state.Seats = make([]*PokerSeat, len(g.PokerState.Seats))
+		for i, seat := range g.PokerState.Seats {
+			s := *seat
+			state.Seats[i] = &s
+		}
+		if !viewer.CanSeeAll() {
+			for i := range state.Burned {
+				state.Burned[i] = hiddenCard()
+			}
+		}
+		view.PokerState = &state
+	}
+
+	if g.WarState != nil {
+		state := *g.WarState
+		state.Seats = make([]*WarSeat, len(g.WarState.Seats))
+		for i, seat := range g.WarState.Seats {
+			s := *seat
+			s.WonPile = copyCards(seat.WonPile)
+			if !viewer.CanSeeAll() {
+				for j := range s.WonPile {
+					s.WonPile[j] = hiddenCard()
+				}
+			}
+			state.Seats[i] = &s
+		}
+		if g.WarState.LastBattle != nil {
+			battle := *g.WarState.LastBattle
+			battle.Plays = make([]WarPlay, len(g.WarState.LastBattle.Plays))
+			for i, play := range g.WarState.LastBattle.Plays {
+				play.Cards = copyCards(play.Cards)
+				for j, card := range play.Cards {
+					if !viewer.CanSeeAll() && !card.FaceUp {
+						play.Cards[j] = hiddenCard()
+					}
+				}
+				battle.Plays[i] = play
+			}
+			state.LastBattle = &battle
+		}
+		view.WarState = &state
+	}
+
+	if g.GoFishState != nil {
+		state := *g.GoFishState
+		state.Seats = make([]*GoFishSeat, len(g.GoFishState.Seats))
+		for i, seat := range g.GoFishState.Seats {
+			s := *seat
+			s.Books = append([]Rank{}, seat.Books...)
+			state.Seats[i] = &s
+		}
+		view.GoFishState = &state
+	}
+
+	view.Events = make([]GameEvent, len(g.Events))
+	for i, event := range g.Events {
+		view.Events[i] = g.eventView(event, viewer)
+	}
+
+	return &view
+}
+
+// EventsFor returns the events recorded after seq as the viewer is allowed to see them.
+func (g *Game) EventsFor(viewer Viewer, seq int) []GameEvent {
+	events := g.EventsSince(seq)
+	for i, event := range events {
+		events[i] = g.eventView(event, viewer)
+	}
+	return events
+}
+
+// PlayerByToken returns the player the token was issued to, or nil if it matches nobody.
+func (g *Game) PlayerByToken(token string) *Player {
+	for _, player := range g.Players {
+		if player.HasToken(token) {
+			return player
+		}
+	}
+	return nil
+}
+
+// playerView copies a player, hiding the cards in their hand that the viewer may not see.
+func (g *Game) playerView(player *Player, viewer Viewer) *Player {
+	view := *player
+	view.TokenHash = ""
+	view.Hand = copyCards(player.Hand)
+	if player.Hands != nil {
+		view.Hands = make([]*BlackjackHand, len(player.Hands))
+		for i, hand := range player.Hands {
+			copied := *hand
+			copied.Cards = copyCards(hand.Cards)
+			view.Hands[i] = &copied
+		}
+	}
+
+	// A War stock is face down and unknown even to its owner
+	ownHand := viewer.PlayerID != "" && viewer.PlayerID == player.ID && g.GameType != War
+	if viewer.CanSeeAll() || ownHand {
+		return &view
+	}
+
+	// Cribbage hands are dealt face up to their owner but stay private until the show
+	privateHand := g.GameType == Cribbage && player != g.Dealer && !g.cribbageShowing()
+	for i, card := range view.Hand {
+		if privateHand || !card.FaceUp {
+			view.Hand[i] = hiddenCard()
+		}
+	}
+	return &view
+}
+
+// eventView copies an event, hiding deck snapshots and any card identity the viewer may not see.
+func (g *Game) eventView(event GameEvent, viewer Viewer) GameEvent {
+	if viewer.CanSeeAll() {
+		return event
+	}
+
+	own := viewer.PlayerID != "" && viewer.PlayerID == event.PlayerID
+	event.Deck = nil
+	if event.Fair != nil && g.Fair != nil && event.Fair.ServerSeed == g.Fair.ServerSeed && !g.fairRevealed() {
+		event.Fair = event.Fair.snapshot()
+		event.Fair.ServerSeed = ""
+	}
+
+	event.Dealt = append([]Card(nil), event.Dealt...)
+	for i, card := range event.Dealt {
+		// The cribbage starter is cut face up for everyone; other dealt cards are public only when face up outside cribbage
+		public := event.Type == EventCribbageDiscard || (card.FaceUp && g.GameType != Cribbage)
+		if !own && !public {
+			event.Dealt[i] = Card{}
+		}
+	}
+
+	if event.Type == EventCribbageDiscard && !own {
+		event.Cards = make([]Card, len(event.Cards))
+	}
+	return event
+}
+
+// cribbageShowing reports whether hands and the crib have been revealed for counting.
+func (g *Game) cribbageShowing() bool {
+	if g.CribbageState == nil {
+		return false
+	}
+	return g.CribbageState.Phase == CribbageShow || g.CribbageState.Phase == CribbageFinished
+}
+
+// copyCards returns a new slice holding copies of the cards, so views never alias game state.
+func copyCards(cards []*Card) []*Card {
+	if cards == nil {
+		return nil
+	}
+	copied := make([]*Card, len(cards))
+	for i, card := range cards {
+		if card == nil {
+			continue
+		}
+		c := *card
+		copied[i] = &c
+	}
+	return copied
+}
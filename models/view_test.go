@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerTokens(t *testing.T) {
+	game := NewGame(1)
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+
+	aliceToken := alice.IssueToken()
+	bobToken := bob.IssueToken()
+	assert.Len(t, aliceToken, 64)
+	assert.NotEqual(t, aliceToken, bobToken)
+	assert.NotContains(t, alice.TokenHash, aliceToken)
+
+	assert.True(t, alice.HasToken(aliceToken))
+	assert.False(t, alice.HasToken(bobToken))
+	assert.False(t, alice.HasToken(""))
+	assert.Same(t, bob, game.PlayerByToken(bobToken))
+	assert.Nil(t, game.PlayerByToken("not-a-token"))
+}
+
+func TestBlackjackViewHidesHoleCardAndDeck(t *testing.T) {
+	game := NewGame(1)
+	alice := game.AddPlayer("Alice")
+	game.AddPlayer("Bob")
+	alice.IssueToken()
+	require.NoError(t, game.StartBlackjackGame())
+	hole := *game.Dealer.Hand[0]
+	require.False(t, hole.FaceUp)
+
+	view := game.ViewFor(Viewer{PlayerID: alice.ID})
+	assert.Equal(t, Card{}, *view.Dealer.Hand[0])
+	assert.Equal(t, *game.Dealer.Hand[1], *view.Dealer.Hand[1])
+	assert.Equal(t, *game.Players[1].Hand[0], *view.Players[1].Hand[0]) // Face-up cards are public
+	assert.Len(t, view.Deck.Cards, len(game.Deck.Cards))
+	assert.Equal(t, Card{}, view.Deck.Cards[0])
+	assert.Empty(t, view.Players[0].TokenHash)
+	assert.Nil(t, view.Events[0].Deck)
+
+	// The game itself is untouched
+	assert.Equal(t, hole, *game.Dealer.Hand[0])
+	assert.NotEqual(t, Card{}, game.Deck.Cards[0])
+	assert.NotEmpty(t, alice.TokenHash)
+
+	admin := game.ViewFor(Viewer{Admin: true})
+	assert.Equal(t, hole, *admin.Dealer.Hand[0])
+	assert.Equal(t, game.Deck.Cards, admin.Deck.Cards)
+	assert.NotNil(t, admin.Events[0].Deck)
+	assert.Empty(t, admin.Players[0].TokenHash)
+}
+
+func TestViewHidesOtherPlayersFaceDownCards(t *testing.T) {
+	game := NewGame(1)
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	game.DealToPlayer(alice.ID, false)
+	game.DealToPlayer(bob.ID, false)
+
+	view := game.ViewFor(Viewer{PlayerID: alice.ID})
+	assert.Equal(t, *alice.Hand[0], *view.Players[0].Hand[0])
+	assert.Equal(t, Card{}, *view.Players[1].Hand[0])
+
+	spectator := game.ViewFor(Spectator)
+	assert.Equal(t, Card{}, *spectator.Players[0].Hand[0])
+	assert.Equal(t, Card{}, *spectator.Players[1].Hand[0])
+
+	events := game.EventsFor(Viewer{PlayerID: alice.ID}, 0)
+	require.Len(t, events, 5)
+	assert.Equal(t, *alice.Hand[0], events[3].Dealt[0])
+	assert.Equal(t, Card{}, events[4].Dealt[0])
+	assert.NotEqual(t, Card{}, game.Events[4].Dealt[0])
+}
+
+func TestCribbageViewHidesHandsAndCribUntilShow(t *testing.T) {
+	game := NewGameWithType(1, Standard, Cribbage, 2)
+	alice := game.AddPlayer("Alice")
+	bob := game.AddPlayer("Bob")
+	require.NoError(t, game.StartCribbageGame())
+	require.NoError(t, game.CribbageDiscard(alice.ID, []int{0, 1}))
+	require.NoError(t, game.CribbageDiscard(bob.ID, []int{0, 1}))
+
+	view := game.ViewFor(Viewer{PlayerID: alice.ID})
+	for i, card := range view.Players[0].Hand {
+		assert.Equal(t, *alice.Hand[i], *card)
+	}
+	for _, card := range view.Players[1].Hand {
+		assert.Equal(t, Card{}, *card)
+	}
+	require.Len(t, view.CribbageState.Crib, 4)
+	for _, card := range view.CribbageState.Crib {
+		assert.Equal(t, Card{}, *card)
+	}
+	assert.Equal(t, *game.CribbageState.Starter, *view.CribbageState.Starter)
+
+	// Alice sees what she threw to the crib but not what Bob threw
+	events := game.EventsFor(Viewer{PlayerID: alice.ID}, 0)
+	discards := []GameEvent{}
+	for _, event := range events {
+		if event.Type == EventCribbageDiscard {
+			discards = append(discards, event)
+		}
+	}
+	require.Len(t, discards, 2)
+	assert.Equal(t, game.CribbageState.Crib[0].Rank, discards[0].Cards[0].Rank)
+	assert.Equal(t, []Card{{}, {}}, discards[1].Cards)
+	assert.Equal(t, *game.CribbageState.Starter, discards[1].Dealt[0])
+
+	game.CribbageState.Phase = CribbageShow
+	shown := game.ViewFor(Viewer{PlayerID: alice.ID})
+	assert.Equal(t, *bob.Hand[0], *shown.Players[1].Hand[0])
+	assert.Equal(t, *game.CribbageState.Crib[0], *shown.CribbageState.Crib[0])
+}
+
+func TestTokenHashPersistsButNotInViews(t *testing.T) {
+	game := NewGame(1)
+	alice := game.AddPlayer("Alice")
+	token := alice.IssueToken()
+
+	data, err := json.Marshal(game)
+	require.NoError(t, err)
+	var restored Game
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.NotNil(t, restored.PlayerByToken(token))
+
+	data, err = json.Marshal(game.ViewFor(Viewer{PlayerID: alice.ID}))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), alice.TokenHash)
+}
@@ -0,0 +1,270 @@
+package models
+
+import "fmt"
+
+// WarPhase is the stage a game of War is in.
+type WarPhase int
+
+const (
+	WarPlaying WarPhase = iota
+	WarFinished
+)
+
+// String returns the phase name used in API responses.
+func (wp WarPhase) String() string {
+	switch wp {
+	case WarPlaying:
+		return "playing"
+	case WarFinished:
+		return "finished"
+	default:
+		return "playing"
+	}
+}
+
+// DefaultWarMaxRounds caps how many battles a game of War may last before the player
+// holding the most cards is declared the winner. War can otherwise cycle forever.
+const DefaultWarMaxRounds = 1000
+
+// warFaceDownCards is how many cards each tied player puts face down before flipping again.
+const warFaceDownCards = 3
+
+// WarSeat tracks a player's pile of won cards alongside the face-down stock in their hand.
+type WarSeat struct {
+	PlayerID string  `json:"player_id"`
+	WonPile  []*Card `json:"won_pile"` // Won cards, picked up as the new stock when the hand runs out
+	Out      bool    `json:"out"`
+}
+
+// WarPlay is every card one player put into a battle, face-down war cards included.
+type WarPlay struct {
+	PlayerID string  `json:"player_id"`
+	Cards    []*Card `json:"cards"`
+}
+
+// WarBattle describes a single flip and any wars needed to settle it.
+type WarBattle struct {
+	Round  int       `json:"round"`
+	Plays  []WarPlay `json:"plays"`
+	Wars   int       `json:"wars"`
+	Winner string    `json:"winner,omitempty"` // Empty when every tied player ran out of cards mid-war
+	Cards  int       `json:"cards"`
+}
+
+// WarState holds all game state specific to War.
+type WarState struct {
+	Phase      WarPhase   `json:"phase"`
+	Round      int        `json:"round"`
+	MaxRounds  int        `json:"max_rounds"`
+	Seats      []*WarSeat `json:"seats"`
+	LastBattle *WarBattle `json:"last_battle,omitempty"`
+	Winner     string     `json:"winner,omitempty"` // Empty while playing and when the game ends in a draw
+}
+
+// WarCardCount returns how many cards the player in the seat still holds, stock and won pile together.
+func (g *Game) WarCardCount(seat int) int {
+	if g.WarState == nil || seat < 0 || seat >= len(g.WarState.Seats) || seat >= len(g.Players) {
+		return 0
+	}
+	return len(g.Players[seat].Hand) + len(g.WarState.Seats[seat].WonPile)
+}
+
+// StartWarGame splits the deck face down between 2 to 6 players and starts a game of War.
+// Cards that cannot be shared out evenly stay in the deck. A maxRounds of 0 uses DefaultWarMaxRounds.
+func (g *Game) StartWarGame(maxRounds int) error {
+	if len(g.Players) < 2 || len(g.Players) > 6 {
+		return fmt.Errorf("war requires 2 to 6 players")
+	}
+	if maxRounds < 0 {
+		return fmt.Errorf("max rounds cannot be negative")
+	}
+	if g.WarState != nil {
+		return fmt.Errorf("war game has already been started")
+	}
+	if g.Deck.RemainingCards() < len(g.Players) {
+		return fmt.Errorf("not enough cards in the deck to deal every player")
+	}
+	if maxRounds == 0 {
+		maxRounds = DefaultWarMaxRounds
+	}
+
+	g.GameType = War
+	g.Status = GameInProgress
+	g.CurrentPlayer = 0
+	defer g.record(GameEvent{Type: EventWarStarted, MaxRounds: maxRounds})
+
+	g.WarState = &WarState{
+		MaxRounds: maxRounds,
+		Seats:     make([]*WarSeat, len(g.Players)),
+	}
+	for i, player := range g.Players {
+		player.ClearHand()
+		g.WarState.Seats[i] = &WarSeat{PlayerID: player.ID, WonPile: []*Card{}}
+	}
+
+	perPlayer := g.Deck.RemainingCards() / len(g.Players)
+	for n := 0; n < perPlayer; n++ {
+		for _, player := range g.Players {
+			g.dealToPlayer(player.ID, false)
+		}
+	}
+	return nil
+}
+
+// WarFlip has every player still in the game flip their top card, settling ties with wars.
+func (g *Game) WarFlip() error {
+	if err := g.warPlayable(); err != nil {
+		return err
+	}
+
+	defer g.record(GameEvent{Type: EventWarFlip})
+	g.warBattle()
+	return nil
+}
+
+// WarAutoPlay flips until the game is over, which takes at most the remaining rounds.
+func (g *Game) WarAutoPlay() error {
+	if err := g.warPlayable(); err != nil {
+		return err
+	}
+
+	defer g.record(GameEvent{Type: EventWarAutoPlay})
+	for g.WarState.Phase == WarPlaying {
+		g.warBattle()
+	}
+	return nil
+}
+
+// warPlayable reports why no battle can be fought, if any.
+func (g *Game) warPlayable() error {
+	if g.WarState == nil {
+		return fmt.Errorf("war game has not been started")
+	}
+	if g.WarState.Phase == WarFinished {
+		return fmt.Errorf("war game is over")
+	}
+	return nil
+}
+
+// warBattle plays one round: everyone flips, the highest card takes them all, and tied
+// players go to war by laying cards face down and flipping again. A player short of cards
+// for a war lays down all but their last card and flips that; one with no cards left loses the war.
+func (g *Game) warBattle() {
+	state := g.WarState
+	state.Round++
+	battle := &WarBattle{Round: state.Round, Plays: []WarPlay{}}
+	state.LastBattle = battle
+
+	contenders := []int{}
+	played := make(map[int]int)
+	faceUp := make(map[int]*Card)
+	for i, seat := range state.Seats {
+		if seat.Out {
+			continue
+		}
+		played[i] = len(battle.Plays)
+		battle.Plays = append(battle.Plays, WarPlay{PlayerID: seat.PlayerID})
+		faceUp[i] = g.warPlayCard(i, &battle.Plays[played[i]], true)
+		contenders = append(contenders, i)
+	}
+
+	for len(contenders) > 1 {
+		best := 0
+		for _, i := range contenders {
+			best = max(best, pokerRank(*faceUp[i]))
+		}
+		tied := []int{}
+		for _, i := range contenders {
+			if pokerRank(*faceUp[i]) == best {
+				tied = append(tied, i)
+			}
+		}
+		if len(tied) == 1 {
+			contenders = tied
+			break
+		}
+
+		battle.Wars++
+		contenders = []int{}
+		for _, i := range tied {
+			available := g.WarCardCount(i)
+			if available == 0 {
+				continue
+			}
+			for n := 0; n < min(warFaceDownCards, available-1); n++ {
+				g.warPlayCard(i, &battle.Plays[played[i]], false)
+			}
+			faceUp[i] = g.warPlayCard(i, &battle.Plays[played[i]], true)
+			contenders = append(contenders, i)
+		}
+	}
+
+	pot := []*Card{}
+	for _, play := range battle.Plays {
+		pot = append(pot, play.Cards...)
+	}
+	battle.Cards = len(pot)
+	if len(contenders) == 1 {
+		winner := state.Seats[contenders[0]]
+		battle.Winner = winner.PlayerID
+		for _, card := range pot {
+			won := *card
+			won.FaceUp = false
+			winner.WonPile = append(winner.WonPile, &won)
+		}
+	}
+
+	g.finishWarRound()
+}
+
+// warPlayCard moves the top card of a player's stock into the battle, picking up their
+// won pile as the new stock when the hand is empty.
+func (g *Game) warPlayCard(seat int, play *WarPlay, faceUp bool) *Card {
+	player := g.Players[seat]
+	if len(player.Hand) == 0 {
+		player.Hand = g.WarState.Seats[seat].WonPile
+		g.WarState.Seats[seat].WonPile = []*Card{}
+	}
+
+	card := player.Hand[0]
+	player.Hand = player.Hand[1:]
+	card.FaceUp = faceUp
+	play.Cards = append(play.Cards, card)
+	return card
+}
+
+// finishWarRound knocks out players with no cards and ends the game when one player holds
+// every card or the round limit is reached, in which case the most cards wins.
+func (g *Game) finishWarRound() {
+	state := g.WarState
+	remaining := []int{}
+	for i, seat := range state.Seats {
+		seat.Out = g.WarCardCount(i) == 0
+		if !seat.Out {
+			remaining = append(remaining, i)
+		}
+	}
+
+	switch {
+	case len(remaining) == 1:
+		state.Winner = state.Seats[remaining[0]].PlayerID
+	case len(remaining) > 1 && state.Round >= state.MaxRounds:
+		most, leaders := 0, []int{}
+		for _, i := range remaining {
+			switch count := g.WarCardCount(i); {
+			case count > most:
+				most, leaders = count, []int{i}
+			case count == most:
+				leaders = append(leaders, i)
+			}
+		}
+		if len(leaders) == 1 {
+			state.Winner = state.Seats[leaders[0]].PlayerID
+		}
+	case len(remaining) > 1:
+		return
+	}
+
+	state.Phase = WarFinished
+	g.Status = GameFinished
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWarGame creates a war game whose deck is exactly the given cards, dealt alternately to the named players.
+func newWarGame(t *testing.T, deck []Rank, names ...string) *Game {
+	game := NewGameWithType(1, Standard, War, 6)
+	for _, name := range names {
+		require.NotNil(t, game.AddPlayer(name))
+	}
+	if deck != nil {
+		game.Deck.Cards = rankedCards(deck)
+	}
+	return game
+}
+
+// stockRanks returns the ranks of the player's stock from the top down.
+func stockRanks(player *Player) []Rank {
+	ranks := []Rank{}
+	for _, card := range player.Hand {
+		ranks = append(ranks, card.Rank)
+	}
+	return ranks
+}
+
+func TestStartWarGameSplitsDeck(t *testing.T) {
+	game := newWarGame(t, nil, "Alice")
+	assert.Error(t, game.StartWarGame(0))
+
+	game = newWarGame(t, nil, "Alice", "Bob", "Carol")
+	assert.Error(t, game.StartWarGame(-1))
+	require.NoError(t, game.StartWarGame(0))
+	assert.Error(t, game.StartWarGame(0))
+
+	assert.Equal(t, GameInProgress, game.Status)
+	assert.Equal(t, DefaultWarMaxRounds, game.WarState.MaxRounds)
+	for i, player := range game.Players {
+		assert.Len(t, player.Hand, 17)
+		assert.False(t, player.Hand[0].FaceUp)
+		assert.Equal(t, 17, game.WarCardCount(i))
+	}
+	assert.Equal(t, 1, game.Deck.RemainingCards(), "the odd card is left out")
+}
+
+func TestWarFlipHighCardWins(t *testing.T) {
+	game := newWarGame(t, []Rank{Ace, King, Two, Three}, "Alice", "Bob")
+	require.NoError(t, game.StartWarGame(0))
+
+	require.NoError(t, game.WarFlip())
+	battle := game.WarState.LastBattle
+	assert.Equal(t, game.Players[0].ID, battle.Winner, "Aces are high")
+	assert.Equal(t, 0, battle.Wars)
+	assert.Equal(t, 2, battle.Cards)
+	assert.Len(t, game.WarState.Seats[0].WonPile, 2)
+	assert.False(t, game.WarState.Seats[0].WonPile[0].FaceUp)
+
+	// Bob's Three beats Two and leaves Alice to pick up her won pile as her new stock
+	require.NoError(t, game.WarFlip())
+	assert.Equal(t, game.Players[1].ID, game.WarState.LastBattle.Winner)
+	require.NoError(t, game.WarFlip())
+	assert.Equal(t, []Rank{King}, stockRanks(game.Players[0]))
+	assert.Len(t, game.WarState.Seats[0].WonPile, 2, "her Ace took Bob's Two")
+	assert.Equal(t, 3, game.WarState.Round)
+}
+
+func TestWarTieGoesToWar(t *testing.T) {
+	// Alice gets King 2 3 4 Ace, Bob gets King 5 6 7 Queen
+	game := newWarGame(t, []Rank{King, King, Two, Five, Three, Six, Four, Seven, Ace, Queen, Nine, Eight}, "Alice", "Bob")
+	require.NoError(t, game.StartWarGame(0))
+
+	require.NoError(t, game.WarFlip())
+	battle := game.WarState.LastBattle
+	assert.Equal(t, 1, battle.Wars)
+	assert.Equal(t, game.Players[0].ID, battle.Winner)
+	assert.Equal(t, 10, battle.Cards)
+	require.Len(t, battle.Plays[0].Cards, 5)
+	assert.True(t, battle.Plays[0].Cards[0].FaceUp)
+	assert.False(t, battle.Plays[0].Cards[1].FaceUp)
+	assert.True(t, battle.Plays[0].Cards[4].FaceUp)
+	assert.Len(t, game.WarState.Seats[0].WonPile, 10)
+	assert.Equal(t, []Rank{Nine}, stockRanks(game.Players[0]))
+
+	// Face-down war cards stay hidden in a player's view
+	view := game.ViewFor(Viewer{PlayerID: game.Players[0].ID})
+	assert.Equal(t, Card{}, *view.WarState.LastBattle.Plays[1].Cards[1])
+	assert.Equal(t, Queen, view.WarState.LastBattle.Plays[1].Cards[4].Rank)
+	assert.Equal(t, Card{}, *view.Players[0].Hand[0], "a war stock is hidden from its owner")
+	assert.Equal(t, Card{}, *view.WarState.Seats[0].WonPile[0])
+	assert.Equal(t, Nine, game.Players[0].Hand[0].Rank, "views do not alias game state")
+}
+
+func TestWarPlayerRunningOutMidWarLoses(t *testing.T) {
+	// Alice has King 2, Bob has King 5 and then 6 7 Queen
+	game := newWarGame(t, []Rank{King, King, Two, Five}, "Alice", "Bob")
+	require.NoError(t, game.StartWarGame(0))
+	game.Players[1].Hand = append(game.Players[1].Hand, &Card{Rank: Six}, &Card{Rank: Seven}, &Card{Rank: Queen})
+
+	require.NoError(t, game.WarFlip())
+	battle := game.WarState.LastBattle
+	assert.Len(t, battle.Plays[0].Cards, 2, "Alice flips her last card without laying any face down")
+	assert.Equal(t, game.Players[1].ID, battle.Winner)
+	assert.True(t, game.WarState.Seats[0].Out)
+	assert.Equal(t, WarFinished, game.WarState.Phase)
+	assert.Equal(t, game.Players[1].ID, game.WarState.Winner)
+	assert.Equal(t, GameFinished, game.Status)
+	assert.Error(t, game.WarFlip())
+}
+
+func TestWarBothRunningOutIsADraw(t *testing.T) {
+	game := newWarGame(t, []Rank{King, King}, "Alice", "Bob")
+	require.NoError(t, game.StartWarGame(0))
+
+	require.NoError(t, game.WarFlip())
+	assert.Empty(t, game.WarState.LastBattle.Winner)
+	assert.Equal(t, WarFinished, game.WarState.Phase)
+	assert.Empty(t, game.WarState.Winner)
+}
+
+func TestWarMaxRoundsAwardsMostCards(t *testing.T) {
+	game := newWarGame(t, []Rank{Ace, Two, Three, Four}, "Alice", "Bob")
+	require.NoError(t, game.StartWarGame(1))
+
+	require.NoError(t, game.WarFlip())
+	assert.Equal(t, WarFinished, game.WarState.Phase)
+	assert.Equal(t, game.Players[0].ID, game.WarState.Winner)
+	assert.Equal(t, 3, game.WarCardCount(0))
+}
+
+func TestWarAutoPlayAndReplay(t *testing.T) {
+	game := newWarGame(t, nil, "Alice", "Bob", "Carol")
+	assert.Error(t, game.WarAutoPlay())
+	require.NoError(t, game.StartWarGame(200))
+	require.NoError(t, game.WarFlip())
+	require.NoError(t, game.WarAutoPlay())
+
+	state := game.WarState
+	assert.Equal(t, WarFinished, state.Phase)
+	assert.LessOrEqual(t, state.Round, 200)
+	total := 0
+	for i := range game.Players {
+		total += game.WarCardCount(i)
+	}
+	assert.LessOrEqual(t, total, 51, "cards only leave play when every tied player runs out")
+
+	replayed, err := ReplayGame(game.Events, len(game.Events))
+	require.NoError(t, err)
+	require.NotNil(t, replayed.WarState)
+	assert.Equal(t, state.Round, replayed.WarState.Round)
+	assert.Equal(t, state.Winner, replayed.WarState.Winner)
+	for i := range game.Players {
+		assert.Equal(t, game.WarCardCount(i), replayed.WarCardCount(i))
+	}
+}
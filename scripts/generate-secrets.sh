@@ -15,12 +15,19 @@ echo -e "${GREEN}Generating secure secrets...${NC}"
 # Create secrets directory
 mkdir -p "$SECRETS_DIR"
 
-# Generate API key (32 bytes, base64 encoded)
-if [ ! -f "$SECRETS_DIR/api_key.txt" ]; then
-    openssl rand -base64 32 > "$SECRETS_DIR/api_key.txt"
-    echo -e "${GREEN}✓ Generated API key${NC}"
+# Generate an admin API key (32 bytes, hex encoded)
+# Only its SHA-256 hash is written to disk as id:role:hash; the key itself is shown once
+if [ ! -f "$SECRETS_DIR/api_keys.txt" ]; then
+    ADMIN_KEY=$(openssl rand -hex 32)
+    ADMIN_HASH=$(printf '%s' "$ADMIN_KEY" | openssl dgst -sha256 | awk '{print $NF}')
+    {
+        echo "# id:role:sha256-of-key, roles are admin, table-host and player"
+        echo "admin:admin:$ADMIN_HASH"
+    } > "$SECRETS_DIR/api_keys.txt"
+    echo -e "${GREEN}✓ Generated admin API key${NC}"
+    echo -e "${YELLOW}  Admin API key (store it now, it is not saved): $ADMIN_KEY${NC}"
 else
-    echo -e "${YELLOW}⚠ API key already exists${NC}"
+    echo -e "${YELLOW}⚠ API keys already exist${NC}"
 fi
 
 # Generate database password (24 characters)
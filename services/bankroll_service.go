@@ -0,0 +1,52 @@
+package services
+
+import (
+	"github.com/peteshima/cardgame-api/managers"
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// BankrollService provides chip and betting operations for blackjack and Glitchjack tables
+type BankrollService struct {
+	gameManager *managers.GameManager
+}
+
+// NewBankrollService creates a new bankroll service instance
+func NewBankrollService(gameManager *managers.GameManager) *BankrollService {
+	return &BankrollService{
+		gameManager: gameManager,
+	}
+}
+
+// BuyIn adds chips to a player's bankroll
+func (bs *BankrollService) BuyIn(gameID string, playerID string, amount int) (*models.Game, *models.Player, error) {
+	return bs.act(gameID, playerID, amount, (*models.Game).PlayerBuyIn)
+}
+
+// PlaceBet stakes chips on the next deal, replacing any earlier bet
+func (bs *BankrollService) PlaceBet(gameID string, playerID string, amount int) (*models.Game, *models.Player, error) {
+	return bs.act(gameID, playerID, amount, (*models.Game).PlaceBet)
+}
+
+// GetBankrolls returns a game so its players' bankrolls, bets and ledgers can be reported
+func (bs *BankrollService) GetBankrolls(gameID string) *models.Game {
+	game, exists := bs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil
+	}
+	return game
+}
+
+// act applies a chip movement to a game and returns the player it was for.
+func (bs *BankrollService) act(gameID string, playerID string, amount int, move func(*models.Game, string, int) error) (*models.Game, *models.Player, error) {
+	game, exists := bs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil, nil
+	}
+
+	err := move(game, playerID, amount)
+	commitGame(bs.gameManager, game)
+	if err != nil {
+		return game, nil, err
+	}
+	return game, game.GetPlayer(playerID), nil
+}
@@ -17,6 +17,19 @@ func NewBlackjackService(gameManager *managers.GameManager) *BlackjackService {
 	}
 }
 
+// CreateBlackjackGame creates a blackjack game dealt from the rules' deck count under those table rules.
+// A Spanish21 deck type plays Spanish 21.
+func (bs *BlackjackService) CreateBlackjackGame(rules models.BlackjackRules, deckType models.DeckType, maxPlayers int) (*models.Game, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	game := bs.gameManager.CreateGameWithType(rules.Decks, deckType, models.Blackjack, maxPlayers)
+	err := game.SetBlackjackRules(rules)
+	commitGame(bs.gameManager, game)
+	return game, err
+}
+
 // StartBlackjackGame starts a new blackjack game
 func (bs *BlackjackService) StartBlackjackGame(gameID string) (*models.Game, error) {
 	game, exists := bs.gameManager.GetGame(gameID)
@@ -25,6 +38,19 @@ func (bs *BlackjackService) StartBlackjackGame(gameID string) (*models.Game, err
 	}
 	
 	err := game.StartBlackjackGame()
+	commitGame(bs.gameManager, game)
+	return game, err
+}
+
+// NextRound clears a finished round so the table can bet and deal again from the same shoe
+func (bs *BlackjackService) NextRound(gameID string) (*models.Game, error) {
+	game, exists := bs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.NextRound()
+	commitGame(bs.gameManager, game)
 	return game, err
 }
 
@@ -36,6 +62,7 @@ func (bs *BlackjackService) PlayerHit(gameID string, playerID string) (*models.G
 	}
 	
 	err := game.PlayerHit(playerID)
+	commitGame(bs.gameManager, game)
 	if err != nil {
 		return game, nil, err
 	}
@@ -52,6 +79,7 @@ func (bs *BlackjackService) PlayerStand(gameID string, playerID string) (*models
 	}
 	
 	err := game.PlayerStand(playerID)
+	commitGame(bs.gameManager, game)
 	if err != nil {
 		return game, nil, err
 	}
@@ -60,6 +88,41 @@ func (bs *BlackjackService) PlayerStand(gameID string, playerID string) (*models
 	return game, player, nil
 }
 
+// PlayerSplit splits a player's pair into two hands
+func (bs *BlackjackService) PlayerSplit(gameID string, playerID string) (*models.Game, *models.Player, error) {
+	return bs.act(gameID, playerID, (*models.Game).PlayerSplit)
+}
+
+// PlayerDouble doubles down on a player's hand
+func (bs *BlackjackService) PlayerDouble(gameID string, playerID string) (*models.Game, *models.Player, error) {
+	return bs.act(gameID, playerID, (*models.Game).PlayerDouble)
+}
+
+// PlayerInsure takes insurance, or even money on a blackjack, for a player
+func (bs *BlackjackService) PlayerInsure(gameID string, playerID string) (*models.Game, *models.Player, error) {
+	return bs.act(gameID, playerID, (*models.Game).PlayerInsure)
+}
+
+// PlayerSurrender surrenders a player's hand
+func (bs *BlackjackService) PlayerSurrender(gameID string, playerID string) (*models.Game, *models.Player, error) {
+	return bs.act(gameID, playerID, (*models.Game).PlayerSurrender)
+}
+
+// act applies a player action to the game and commits it, returning the acting player on success
+func (bs *BlackjackService) act(gameID, playerID string, action func(*models.Game, string) error) (*models.Game, *models.Player, error) {
+	game, exists := bs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil, nil
+	}
+
+	err := action(game, playerID)
+	commitGame(bs.gameManager, game)
+	if err != nil {
+		return game, nil, err
+	}
+	return game, game.GetPlayer(playerID), nil
+}
+
 // GetGameResults returns the final results of a blackjack game
 func (bs *BlackjackService) GetGameResults(gameID string) (*models.Game, map[string]string, bool) {
 	game, exists := bs.gameManager.GetGame(gameID)
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"fmt"
+
 	"github.com/peteshima/cardgame-api/managers"
 	"github.com/peteshima/cardgame-api/models"
 )
@@ -19,7 +21,24 @@ func NewCribbageService(gameManager *managers.GameManager) *CribbageService {
 
 // CreateCribbageGame creates a new cribbage game
 func (cs *CribbageService) CreateCribbageGame() *models.Game {
-	return cs.gameManager.CreateGameWithType(1, models.Standard, models.Cribbage, 2)
+	return cs.CreateCribbageGameWithPlayers(2)
+}
+
+// CreateCribbageGameWithPlayers creates a cribbage game for 2 to 4 players
+func (cs *CribbageService) CreateCribbageGameWithPlayers(maxPlayers int) *models.Game {
+	return cs.gameManager.CreateGameWithType(1, models.Standard, models.Cribbage, maxPlayers)
+}
+
+// CreateCribbageTable creates a cribbage game for 2 to 4 players played under the given options
+func (cs *CribbageService) CreateCribbageTable(rules models.CribbageRules, maxPlayers int) (*models.Game, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	game := cs.CreateCribbageGameWithPlayers(maxPlayers)
+	err := game.SetCribbageRules(rules)
+	commitGame(cs.gameManager, game)
+	return game, err
 }
 
 // StartCribbageGame starts a cribbage game
@@ -30,6 +49,7 @@ func (cs *CribbageService) StartCribbageGame(gameID string) (*models.Game, error
 	}
 	
 	err := game.StartCribbageGame()
+	commitGame(cs.gameManager, game)
 	return game, err
 }
 
@@ -41,6 +61,7 @@ func (cs *CribbageService) CribbageDiscard(gameID string, playerID string, cardI
 	}
 	
 	err := game.CribbageDiscard(playerID, cardIndices)
+	commitGame(cs.gameManager, game)
 	if err != nil {
 		return game, nil, err
 	}
@@ -57,6 +78,7 @@ func (cs *CribbageService) CribbagePlay(gameID string, playerID string, cardInde
 	}
 	
 	err := game.CribbagePlay(playerID, cardIndex)
+	commitGame(cs.gameManager, game)
 	if err != nil {
 		return game, nil, err
 	}
@@ -73,6 +95,7 @@ func (cs *CribbageService) CribbageGo(gameID string, playerID string) (*models.G
 	}
 	
 	err := game.CribbageGo(playerID)
+	commitGame(cs.gameManager, game)
 	if err != nil {
 		return game, nil, err
 	}
@@ -92,6 +115,67 @@ func (cs *CribbageService) CribbageShow(gameID string) (*models.Game, map[string
 	if scores == nil {
 		return game, nil, false
 	}
+	commitGame(cs.gameManager, game)
 	
 	return game, scores, true
-}
\ No newline at end of file
+}
+
+// CribbageClaim records a player's claimed score for their hand or crib in a muggins game
+func (cs *CribbageService) CribbageClaim(gameID string, playerID string, points int) (*models.Game, error) {
+	game, exists := cs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.CribbageClaim(playerID, points)
+	commitGame(cs.gameManager, game)
+	return game, err
+}
+
+// CribbageMuggins lets an opponent take the points a claim missed
+func (cs *CribbageService) CribbageMuggins(gameID string, playerID string, claim int) (*models.Game, error) {
+	game, exists := cs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.CribbageMuggins(playerID, claim)
+	commitGame(cs.gameManager, game)
+	return game, err
+}
+
+// CribbageNextHand deals the next hand once the show has been counted
+func (cs *CribbageService) CribbageNextHand(gameID string) (*models.Game, error) {
+	game, exists := cs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.CribbageNextHand()
+	commitGame(cs.gameManager, game)
+	return game, err
+}
+
+// CribbageNextGame starts the next game of a match once the current one has been won
+func (cs *CribbageService) CribbageNextGame(gameID string) (*models.Game, error) {
+	game, exists := cs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.CribbageNextGame()
+	commitGame(cs.gameManager, game)
+	return game, err
+}
+
+// GetCribbageGame returns a game that has been started as cribbage
+func (cs *CribbageService) GetCribbageGame(gameID string) (*models.Game, error) {
+	game, exists := cs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+	if game.CribbageState == nil {
+		return game, fmt.Errorf("cribbage game has not been started")
+	}
+	return game, nil
+}
@@ -49,6 +49,7 @@ func (cds *CustomDeckService) AddCustomCard(deckID string, name string, rank int
 	}
 	
 	card := deck.AddCard(name, rank, suit, attributes)
+	cds.customDeckManager.SaveDeck(deck)
 	return deck, card, true
 }
 
@@ -86,5 +87,8 @@ func (cds *CustomDeckService) DeleteCustomCard(deckID string, cardIndex int) (*m
 	}
 	
 	deleted := deck.DeleteCard(cardIndex)
+	if deleted {
+		cds.customDeckManager.SaveDeck(deck)
+	}
 	return deck, deleted
 }
\ No newline at end of file
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"github.com/peteshima/cardgame-api/managers"
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// FairnessService provides commit-reveal operations for provably fair blackjack and Glitchjack tables
+type FairnessService struct {
+	gameManager *managers.GameManager
+}
+
+// NewFairnessService creates a new fairness service instance
+func NewFairnessService(gameManager *managers.GameManager) *FairnessService {
+	return &FairnessService{
+		gameManager: gameManager,
+	}
+}
+
+// MakeProvablyFair commits a new table to a server seed and deals its deck from it
+func (fs *FairnessService) MakeProvablyFair(gameID string) (*models.Game, error) {
+	game, exists := fs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.MakeProvablyFair()
+	commitGame(fs.gameManager, game)
+	return game, err
+}
+
+// SetClientSeed mixes a client seed into a provably fair table's next deal
+func (fs *FairnessService) SetClientSeed(gameID string, clientSeed string) (*models.Game, error) {
+	game, exists := fs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.SetClientSeed(clientSeed)
+	commitGame(fs.gameManager, game)
+	return game, err
+}
+
+// GetFairGame returns a game so its commitment and any revealed seeds can be reported
+func (fs *FairnessService) GetFairGame(gameID string) *models.Game {
+	game, exists := fs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil
+	}
+	return game
+}
@@ -57,6 +57,42 @@ func (gs *GameService) GetGameCount() int {
 	return gs.gameManager.GameCount()
 }
 
+// SeedGame makes a newly created game deterministic, drawing its deck name and shuffles from the seed
+func (gs *GameService) SeedGame(gameID string, seed uint64) (*models.Game, error) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.SetSeed(seed)
+	commitGame(gs.gameManager, game)
+	return game, err
+}
+
+// SetShuffleMode chooses how a newly created game's deck is shuffled from now on and shuffles it that way
+func (gs *GameService) SetShuffleMode(gameID string, mode models.ShuffleMode) (*models.Game, error) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.SetShuffleMode(mode)
+	commitGame(gs.gameManager, game)
+	return game, err
+}
+
+// ShuffleGameDeckWith shuffles a game's deck once in the given shuffle mode
+func (gs *GameService) ShuffleGameDeckWith(gameID string, mode models.ShuffleMode) (*models.Game, error) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.ShuffleDeckWith(mode)
+	commitGame(gs.gameManager, game)
+	return game, err
+}
+
 // ShuffleGameDeck shuffles the deck for a game
 func (gs *GameService) ShuffleGameDeck(gameID string) (*models.Game, bool) {
 	game, exists := gs.gameManager.GetGame(gameID)
@@ -64,7 +100,8 @@ func (gs *GameService) ShuffleGameDeck(gameID string) (*models.Game, bool) {
 		return nil, false
 	}
 	
-	game.Deck.Shuffle()
+	game.ShuffleDeck()
+	commitGame(gs.gameManager, game)
 	return game, true
 }
 
@@ -75,7 +112,8 @@ func (gs *GameService) ResetGameDeck(gameID string) (*models.Game, bool) {
 		return nil, false
 	}
 	
-	game.Deck.Reset()
+	game.ResetDeck(1, game.Deck.DeckType)
+	commitGame(gs.gameManager, game)
 	return game, true
 }
 
@@ -86,7 +124,8 @@ func (gs *GameService) ResetGameDeckWithDecks(gameID string, numDecks int) (*mod
 		return nil, false
 	}
 	
-	game.Deck.ResetWithDecks(numDecks)
+	game.ResetDeck(numDecks, game.Deck.DeckType)
+	commitGame(gs.gameManager, game)
 	return game, true
 }
 
@@ -97,23 +136,34 @@ func (gs *GameService) ResetGameDeckWithType(gameID string, numDecks int, deckTy
 		return nil, false
 	}
 	
-	game.Deck.ResetWithDecksAndType(numDecks, deckType)
+	game.ResetDeck(numDecks, deckType)
+	commitGame(gs.gameManager, game)
 	return game, true
 }
 
 // AddPlayerToGame adds a player to a game
 func (gs *GameService) AddPlayerToGame(gameID string, playerName string) (*models.Game, *models.Player, bool) {
+	game, player, _, success := gs.JoinGame(gameID, playerName)
+	return game, player, success
+}
+
+// JoinGame adds a player to a game and issues the secret token that identifies them.
+// The token is returned once and only its hash is kept, so callers must hand it to the player.
+func (gs *GameService) JoinGame(gameID string, playerName string) (*models.Game, *models.Player, string, bool) {
 	game, exists := gs.gameManager.GetGame(gameID)
 	if !exists {
-		return nil, nil, false
+		return nil, nil, "", false
 	}
 	
 	player := game.AddPlayer(playerName)
 	if player == nil {
-		return game, nil, false
+		return game, nil, "", false
 	}
 	
-	return game, player, true
+	token := player.IssueToken()
+	commitGame(gs.gameManager, game)
+	
+	return game, player, token, true
 }
 
 // RemovePlayerFromGame removes a player from a game
@@ -124,6 +174,9 @@ func (gs *GameService) RemovePlayerFromGame(gameID string, playerID string) (*mo
 	}
 	
 	removed := game.RemovePlayer(playerID)
+	if removed {
+		commitGame(gs.gameManager, game)
+	}
 	return game, removed
 }
 
@@ -134,13 +187,11 @@ func (gs *GameService) DealCard(gameID string) (*models.Game, *models.Card, bool
 		return nil, nil, false
 	}
 	
-	card := game.Deck.Deal()
+	card := game.DrawCard()
 	if card == nil {
 		return game, nil, false
 	}
-	
-	// Default to face up for dealt cards
-	card.FaceUp = true
+	commitGame(gs.gameManager, game)
 	return game, card, true
 }
 
@@ -157,14 +208,13 @@ func (gs *GameService) DealCards(gameID string, count int) (*models.Game, []*mod
 	
 	var cards []*models.Card
 	for i := 0; i < count; i++ {
-		card := game.Deck.Deal()
+		card := game.DrawCard()
 		if card == nil {
 			break
 		}
-		// Default to face up for dealt cards
-		card.FaceUp = true
 		cards = append(cards, card)
 	}
+	commitGame(gs.gameManager, game)
 	
 	return game, cards, true
 }
@@ -185,6 +235,7 @@ func (gs *GameService) DealToPlayer(gameID string, playerID string, faceUp bool)
 	if card == nil {
 		return game, player, nil, false
 	}
+	commitGame(gs.gameManager, game)
 	
 	return game, player, card, true
 }
@@ -206,11 +257,30 @@ func (gs *GameService) DiscardCard(gameID string, pileID string, playerID string
 		return game, player, nil, nil, false
 	}
 	
-	card := player.RemoveCard(cardIndex)
-	if card == nil {
+	card, err := game.DiscardCard(playerID, pileID, cardIndex)
+	if err != nil {
 		return game, player, pile, nil, false
 	}
-	
-	pile.AddCard(card)
+	commitGame(gs.gameManager, game)
 	return game, player, pile, card, true
-}
\ No newline at end of file
+}
+
+// GetGameEvents returns a game's event log after the given sequence number
+func (gs *GameService) GetGameEvents(gameID string, since int) (*models.Game, []models.GameEvent, bool) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil, false
+	}
+	
+	return game, game.EventsSince(since), true
+}
+
+// ReplayGameAt rebuilds a game as it was immediately after the given event
+func (gs *GameService) ReplayGameAt(gameID string, seq int) (*models.Game, error) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+	
+	return models.ReplayGame(game.Events, seq)
+}
@@ -358,4 +358,44 @@ func TestGameServiceDealToPlayer(t *testing.T) {
 	assert.NotNil(t, resultGame)
 	assert.Nil(t, missingPlayer)
 	assert.Nil(t, missingCard)
-}
\ No newline at end of file
+}
+func TestGameServiceEventsAndReplay(t *testing.T) {
+	gm := managers.NewGameManager()
+	gs := NewGameService(gm)
+	
+	game := gs.CreateGame(1)
+	_, player, success := gs.AddPlayerToGame(game.ID, "Alice")
+	assert.True(t, success)
+	_, shuffled := gs.ShuffleGameDeck(game.ID)
+	assert.True(t, shuffled)
+	_, _, card, success := gs.DealToPlayer(game.ID, player.ID, true)
+	assert.True(t, success)
+	_, _, _, _, success = gs.DiscardCard(game.ID, "main", player.ID, 0)
+	assert.True(t, success)
+	
+	resultGame, events, exists := gs.GetGameEvents(game.ID, 0)
+	assert.True(t, exists)
+	assert.Equal(t, game.ID, resultGame.ID)
+	assert.Len(t, events, 5)
+	assert.Equal(t, models.EventCardDiscarded, events[4].Type)
+	
+	_, events, _ = gs.GetGameEvents(game.ID, 3)
+	assert.Len(t, events, 2)
+	
+	// Rebuild the hand before the discard
+	replayed, err := gs.ReplayGameAt(game.ID, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Card{card}, replayed.Players[0].Hand)
+	assert.Empty(t, replayed.DiscardPiles["main"].Cards)
+	assert.Empty(t, game.Players[0].Hand)
+	
+	_, err = gs.ReplayGameAt(game.ID, 99)
+	assert.Error(t, err)
+	
+	missing, err := gs.ReplayGameAt("non-existent", 1)
+	assert.Nil(t, missing)
+	assert.NoError(t, err)
+	
+	_, _, exists = gs.GetGameEvents("non-existent", 0)
+	assert.False(t, exists)
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"strings"
+
 	"github.com/peteshima/cardgame-api/managers"
 	"github.com/peteshima/cardgame-api/models"
 )
@@ -29,21 +31,24 @@ func (gs *GlitchjackService) CreateGlitchjackGameWithOptions(numDecks int, maxPl
 	game := gs.gameManager.CreateGameWithType(1, models.Standard, models.Glitchjack, maxPlayers)
 	
 	// Replace the standard deck with Glitchjack deck(s)
-	game.Deck = models.NewGlitchjackDeck()
-	
-	// For multiple decks, append additional random cards
-	if numDecks > 1 {
-		for i := 1; i < numDecks; i++ {
-			additionalDeck := models.NewGlitchjackDeck()
-			game.Deck.Cards = append(game.Deck.Cards, additionalDeck.Cards...)
-		}
-		// Shuffle the combined deck
-		game.Deck.Shuffle()
-	}
+	game.ReplaceDeck(models.NewGlitchjackShoe(numDecks, nil))
+	commitGame(gs.gameManager, game)
 	
 	return game
 }
 
+// CreateGlitchjackGameWithRules creates a Glitchjack game with one random deck per deck in the table rules
+func (gs *GlitchjackService) CreateGlitchjackGameWithRules(rules models.BlackjackRules, maxPlayers int) (*models.Game, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	game := gs.CreateGlitchjackGameWithOptions(rules.Decks, maxPlayers)
+	err := game.SetBlackjackRules(rules)
+	commitGame(gs.gameManager, game)
+	return game, err
+}
+
 // StartGlitchjackGame initializes a new Glitchjack game by dealing initial cards
 func (gs *GlitchjackService) StartGlitchjackGame(gameID string) (*models.Game, bool, string) {
 	game, exists := gs.gameManager.GetGame(gameID)
@@ -63,53 +68,10 @@ func (gs *GlitchjackService) StartGlitchjackGame(gameID string) (*models.Game, b
 		return game, false, "No players in game"
 	}
 	
-	// Clear any existing cards and reset player state
-	for _, player := range game.Players {
-		player.Hand = []*models.Card{}
-		player.Standing = false
-		player.Busted = false
-	}
-	game.Dealer.Hand = []*models.Card{}
-	game.Dealer.Standing = false
-	game.Dealer.Busted = false
-	
-	// Deal initial cards (2 to each player and dealer)
-	// First card to each player
-	for _, player := range game.Players {
-		card := game.DealToPlayer(player.ID, true)
-		if card == nil {
-			return game, false, "Not enough cards in deck"
-		}
-	}
-	
-	// First card to dealer (face up)
-	dealerCard1 := game.Deck.Deal()
-	if dealerCard1 == nil {
-		return game, false, "Not enough cards for dealer"
-	}
-	dealerCard1.FaceUp = true
-	game.Dealer.Hand = append(game.Dealer.Hand, dealerCard1)
-	
-	// Second card to each player
-	for _, player := range game.Players {
-		card := game.DealToPlayer(player.ID, true)
-		if card == nil {
-			return game, false, "Not enough cards in deck"
-		}
-	}
-	
-	// Second card to dealer (face down - hole card)
-	dealerCard2 := game.Deck.Deal()
-	if dealerCard2 == nil {
-		return game, false, "Not enough cards for dealer"
-	}
-	dealerCard2.FaceUp = false
-	game.Dealer.Hand = append(game.Dealer.Hand, dealerCard2)
-	
-	// Set game status
-	game.Status = models.GameInProgress
-	if len(game.Players) > 0 {
-		game.CurrentPlayer = 0
+	err := game.StartGlitchjackGame()
+	commitGame(gs.gameManager, game)
+	if err != nil {
+		return game, false, errorMessage(err)
 	}
 	
 	return game, true, "Glitchjack game started"
@@ -146,17 +108,10 @@ func (gs *GlitchjackService) PlayerHit(gameID string, playerID string) (*models.
 	}
 	
 	// Deal a card to the player
-	card := game.DealToPlayer(playerID, true)
-	if card == nil {
-		return game, player, false, "No cards left in deck"
-	}
-	
-	// Check if player busted
-	handValue := models.CalculateGlitchjackHand(player.Hand)
-	if handValue > 21 {
-		player.Busted = true
-		gs.advanceToNextPlayer(game)
+	if err := game.GlitchjackHit(playerID); err != nil {
+		return game, player, false, errorMessage(err)
 	}
+	commitGame(gs.gameManager, game)
 	
 	return game, player, true, ""
 }
@@ -192,8 +147,10 @@ func (gs *GlitchjackService) PlayerStand(gameID string, playerID string) (*model
 	}
 	
 	// Mark player as standing
-	player.Standing = true
-	gs.advanceToNextPlayer(game)
+	if err := game.GlitchjackStand(playerID); err != nil {
+		return game, player, false, errorMessage(err)
+	}
+	commitGame(gs.gameManager, game)
 	
 	return game, player, true, ""
 }
@@ -248,45 +205,11 @@ func (gs *GlitchjackService) GetGlitchjackResults(gameID string) (*models.Game,
 	return game, results, true
 }
 
-// advanceToNextPlayer moves to the next player or dealer's turn
-func (gs *GlitchjackService) advanceToNextPlayer(game *models.Game) {
-	currentIndex := game.CurrentPlayer
-	
-	// Find next player who hasn't finished
-	for i := currentIndex + 1; i < len(game.Players); i++ {
-		if !game.Players[i].Standing && !game.Players[i].Busted {
-			game.CurrentPlayer = i
-			return
-		}
+// errorMessage converts a model error into the capitalised message style used by this service.
+func errorMessage(err error) string {
+	message := err.Error()
+	if message == "" {
+		return message
 	}
-	
-	// All players done, dealer's turn
-	gs.playDealerHand(game)
+	return strings.ToUpper(message[:1]) + message[1:]
 }
-
-// playDealerHand executes the dealer's turn according to Glitchjack rules
-func (gs *GlitchjackService) playDealerHand(game *models.Game) {
-	// Reveal dealer's hole card
-	if len(game.Dealer.Hand) > 1 {
-		game.Dealer.Hand[1].FaceUp = true
-	}
-	
-	// Dealer hits on 16 or less, stands on 17 or more
-	for {
-		dealerValue := models.CalculateGlitchjackHand(game.Dealer.Hand)
-		if dealerValue >= 17 {
-			break
-		}
-		
-		card := game.Deck.Deal()
-		if card == nil {
-			break
-		}
-		card.FaceUp = true
-		game.Dealer.Hand = append(game.Dealer.Hand, card)
-	}
-	
-	// Game is finished
-	game.Status = models.GameFinished
-	game.CurrentPlayer = -1
-}
\ No newline at end of file
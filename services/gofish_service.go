@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/peteshima/cardgame-api/managers"
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// GoFishService provides business logic operations for Go Fish games
+type GoFishService struct {
+	gameManager *managers.GameManager
+}
+
+// NewGoFishService creates a new go fish service instance
+func NewGoFishService(gameManager *managers.GameManager) *GoFishService {
+	return &GoFishService{
+		gameManager: gameManager,
+	}
+}
+
+// CreateGoFishGame creates a new Go Fish game played with a single standard deck
+func (gs *GoFishService) CreateGoFishGame(maxPlayers int) *models.Game {
+	return gs.gameManager.CreateGameWithType(1, models.Standard, models.GoFish, maxPlayers)
+}
+
+// StartGoFishGame deals the opening hands
+func (gs *GoFishService) StartGoFishGame(gameID string) (*models.Game, error) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.StartGoFishGame()
+	commitGame(gs.gameManager, game)
+	return game, err
+}
+
+// Ask has a player ask another player for all their cards of a rank
+func (gs *GoFishService) Ask(gameID string, playerID string, targetID string, rank models.Rank) (*models.Game, error) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.GoFishAsk(playerID, targetID, rank)
+	commitGame(gs.gameManager, game)
+	return game, err
+}
+
+// Draw draws a card for a player who has no cards or nobody left to ask
+func (gs *GoFishService) Draw(gameID string, playerID string) (*models.Game, error) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.GoFishDraw(playerID)
+	commitGame(gs.gameManager, game)
+	return game, err
+}
+
+// GetGoFishGame returns a game that has been started as Go Fish
+func (gs *GoFishService) GetGoFishGame(gameID string) (*models.Game, error) {
+	game, exists := gs.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+	if game.GoFishState == nil {
+		return game, fmt.Errorf("go fish game has not been started")
+	}
+	return game, nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/peteshima/cardgame-api/managers"
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// PokerService provides business logic operations for Texas Hold'em games
+type PokerService struct {
+	gameManager *managers.GameManager
+}
+
+// NewPokerService creates a new poker service instance
+func NewPokerService(gameManager *managers.GameManager) *PokerService {
+	return &PokerService{
+		gameManager: gameManager,
+	}
+}
+
+// CreatePokerGame creates a new Texas Hold'em game played with a single standard deck
+func (ps *PokerService) CreatePokerGame(maxPlayers int) *models.Game {
+	return ps.gameManager.CreateGameWithType(1, models.Standard, models.Poker, maxPlayers)
+}
+
+// StartPokerGame seats the players with their starting stacks and deals the first hand
+func (ps *PokerService) StartPokerGame(gameID string, config models.PokerConfig) (*models.Game, error) {
+	game, exists := ps.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.StartPokerGame(config)
+	commitGame(ps.gameManager, game)
+	return game, err
+}
+
+// PlayerAction applies a check, bet, call, raise, fold or all-in for the player to act
+func (ps *PokerService) PlayerAction(gameID string, playerID string, action models.PokerAction, amount int) (*models.Game, error) {
+	game, exists := ps.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.PokerAct(playerID, action, amount)
+	commitGame(ps.gameManager, game)
+	return game, err
+}
+
+// NextHand moves the button and deals the next hand once the current one is settled
+func (ps *PokerService) NextHand(gameID string) (*models.Game, error) {
+	game, exists := ps.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.NextPokerHand()
+	commitGame(ps.gameManager, game)
+	return game, err
+}
+
+// GetPokerGame returns a game that has a poker hand dealt
+func (ps *PokerService) GetPokerGame(gameID string) (*models.Game, error) {
+	game, exists := ps.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+	if game.PokerState == nil {
+		return game, fmt.Errorf("poker game has not been started")
+	}
+	return game, nil
+}
+
+// EvaluateHands ranks each hand together with the shared board cards and picks the winners.
+// The same card may not appear twice within a hand and its board.
+func (ps *PokerService) EvaluateHands(board []models.Card, hands [][]models.Card) ([]models.PokerHand, []int, error) {
+	combined := make([][]*models.Card, len(hands))
+	for i, hand := range hands {
+		seen := make(map[models.Card]bool)
+		for _, card := range append(append([]models.Card{}, hand...), board...) {
+			card.FaceUp = false
+			if seen[card] {
+				return nil, nil, fmt.Errorf("hand %d: %s appears more than once", i, card)
+			}
+			seen[card] = true
+			combined[i] = append(combined[i], &card)
+		}
+	}
+	return models.ComparePokerHands(combined)
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"github.com/peteshima/cardgame-api/managers"
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// commitGame persists a mutated game and publishes its changes to live subscribers.
+// Every service calls this after changing a game so storage and real-time clients stay in step.
+func commitGame(gameManager *managers.GameManager, game *models.Game) {
+	gameManager.SaveGame(game)
+	gameManager.Hub().PublishGame(game)
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/peteshima/cardgame-api/managers"
+	"github.com/peteshima/cardgame-api/models"
+)
+
+// WarService provides business logic operations for games of War
+type WarService struct {
+	gameManager *managers.GameManager
+}
+
+// NewWarService creates a new war service instance
+func NewWarService(gameManager *managers.GameManager) *WarService {
+	return &WarService{
+		gameManager: gameManager,
+	}
+}
+
+// CreateWarGame creates a new game of War played with a single standard deck
+func (ws *WarService) CreateWarGame(maxPlayers int) *models.Game {
+	return ws.gameManager.CreateGameWithType(1, models.Standard, models.War, maxPlayers)
+}
+
+// StartWarGame splits the deck between the players
+func (ws *WarService) StartWarGame(gameID string, maxRounds int) (*models.Game, error) {
+	game, exists := ws.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.StartWarGame(maxRounds)
+	commitGame(ws.gameManager, game)
+	return game, err
+}
+
+// Flip plays a single battle, including any wars needed to break ties
+func (ws *WarService) Flip(gameID string) (*models.Game, error) {
+	game, exists := ws.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.WarFlip()
+	commitGame(ws.gameManager, game)
+	return game, err
+}
+
+// AutoPlay plays battles until the game is over
+func (ws *WarService) AutoPlay(gameID string) (*models.Game, error) {
+	game, exists := ws.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+
+	err := game.WarAutoPlay()
+	commitGame(ws.gameManager, game)
+	return game, err
+}
+
+// GetWarGame returns a game that has been started as War
+func (ws *WarService) GetWarGame(gameID string) (*models.Game, error) {
+	game, exists := ws.gameManager.GetGame(gameID)
+	if !exists {
+		return nil, nil
+	}
+	if game.WarState == nil {
+		return game, fmt.Errorf("war game has not been started")
+	}
+	return game, nil
+}